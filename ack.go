@@ -0,0 +1,86 @@
+package promises
+
+import "sync"
+
+// AckTracker correlates asynchronous acknowledgements, such as Kafka delivery reports or AMQP publisher confirms,
+// with promises keyed by message. A publisher calls Track for each message it sends and the acknowledgement
+// handler calls Ack or Nack as confirmations arrive, so waiting for many acknowledgements is a matter of All.
+type AckTracker[K comparable] struct {
+	pending map[K]*ackEntry
+	mutex   sync.Mutex
+}
+
+type ackEntry struct {
+	promise *Promise[struct{}]
+	resolve Resolve[struct{}]
+	reject  Reject
+}
+
+// NewAckTracker creates an empty AckTracker.
+func NewAckTracker[K comparable]() *AckTracker[K] {
+	return &AckTracker[K]{pending: make(map[K]*ackEntry)}
+}
+
+// Track returns a promise that is fulfilled when the key is acknowledged and rejected when it is negatively acknowledged.
+// Tracking a key that is already pending returns the same promise.
+func (t *AckTracker[K]) Track(key K) *Promise[struct{}] {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if e, ok := t.pending[key]; ok {
+		return e.promise
+	}
+
+	p, resolve, reject := newPending[struct{}](nil)
+	t.pending[key] = &ackEntry{promise: p, resolve: resolve, reject: reject}
+	return p
+}
+
+// Ack fulfills the promise of the key. It reports false if the key is not pending.
+func (t *AckTracker[K]) Ack(key K) bool {
+	e, ok := t.take(key)
+	if ok {
+		e.resolve(struct{}{})
+	}
+
+	return ok
+}
+
+// Nack rejects the promise of the key with the reason. It reports false if the key is not pending.
+func (t *AckTracker[K]) Nack(key K, reason error) bool {
+	e, ok := t.take(key)
+	if ok {
+		e.reject(reason)
+	}
+
+	return ok
+}
+
+// RejectAll rejects every pending promise with the reason, for example when the underlying connection is closed.
+func (t *AckTracker[K]) RejectAll(reason error) {
+	t.mutex.Lock()
+	pending := t.pending
+	t.pending = make(map[K]*ackEntry)
+	t.mutex.Unlock()
+
+	for _, e := range pending {
+		e.reject(reason)
+	}
+}
+
+// Pending returns the number of keys awaiting acknowledgement.
+func (t *AckTracker[K]) Pending() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.pending)
+}
+
+func (t *AckTracker[K]) take(key K) (*ackEntry, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	e, ok := t.pending[key]
+	delete(t.pending, key)
+	return e, ok
+}
@@ -0,0 +1,74 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAckTracker(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tracker := NewAckTracker[int]()
+	var acks []*Promise[struct{}]
+	for i := 0; i < 500; i++ {
+		acks = append(acks, tracker.Track(i))
+	}
+
+	go func() {
+		for i := 0; i < 500; i++ {
+			tracker.Ack(i)
+		}
+	}()
+
+	if _, err := All(ctx, acks...).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if tracker.Pending() != 0 {
+		t.Errorf("expected pending to be 0, got %d", tracker.Pending())
+	}
+}
+
+func TestAckTrackerNack(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewAckTracker[string]()
+	p := tracker.Track("message-1")
+
+	if tracker.Track("message-1") != p {
+		t.Errorf("expected tracking the same key to return the same promise")
+	}
+
+	if !tracker.Nack("message-1", errors.New("broker rejected")) {
+		t.Errorf("expected nack to report a pending key")
+	}
+
+	if tracker.Ack("message-1") {
+		t.Errorf("expected ack to report a settled key as not pending")
+	}
+
+	if _, err := p.Await(ctx); err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
+
+func TestAckTrackerRejectAll(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewAckTracker[int]()
+	p1 := tracker.Track(1)
+	p2 := tracker.Track(2)
+
+	closed := errors.New("connection closed")
+	tracker.RejectAll(closed)
+
+	results, _ := AllSettled(ctx, p1, p2).Await(ctx)
+	for _, r := range results {
+		if !errors.Is(r.Reason, closed) {
+			t.Errorf("expected reason to be %v, got %v", closed, r.Reason)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrActorStopped is the reason an Ask is rejected with when the actor is stopped.
+	ErrActorStopped = errors.New("actor stopped")
+)
+
+// Actor processes messages one at a time on its own goroutine, so the state its handler closes over
+// needs no locking. Callers send messages with Ask and get the replies as promises.
+type Actor[M, R any] struct {
+	handler func(ctx context.Context, msg M) (R, error)
+	mailbox chan actorMessage[M, R]
+	stopped bool
+	mutex   sync.RWMutex
+	done    *Promise[struct{}]
+}
+
+type actorMessage[M, R any] struct {
+	ctx     context.Context
+	msg     M
+	resolve Resolve[R]
+	reject  Reject
+}
+
+// NewActor starts an actor that handles messages with handler. The mailbox holds up to mailboxSize messages
+// waiting to be handled.
+func NewActor[M, R any](handler func(ctx context.Context, msg M) (R, error), mailboxSize int) *Actor[M, R] {
+	a := &Actor[M, R]{handler: handler, mailbox: make(chan actorMessage[M, R], mailboxSize)}
+	a.done = New(func(resolve Resolve[struct{}], reject Reject) {
+		for m := range a.mailbox {
+			a.handle(m)
+		}
+
+		resolve(struct{}{})
+	})
+
+	return a
+}
+
+// Ask sends the message to the actor and returns a promise of the reply.
+// It blocks while the mailbox is full. The promise is rejected with the context error if ctx is done before
+// the message is handled, and with ErrActorStopped if the actor is stopped.
+func (a *Actor[M, R]) Ask(ctx context.Context, msg M) *Promise[R] {
+	p, resolve, reject := newPending[R](nil)
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if a.stopped {
+		reject(ErrActorStopped)
+		return p
+	}
+
+	select {
+	case a.mailbox <- actorMessage[M, R]{ctx: ctx, msg: msg, resolve: resolve, reject: reject}:
+	case <-ctx.Done():
+		reject(ctx.Err())
+	}
+
+	return p
+}
+
+// Stop stops accepting messages and returns a promise that is fulfilled once the messages already in the mailbox are handled.
+func (a *Actor[M, R]) Stop() *Promise[struct{}] {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.stopped {
+		a.stopped = true
+		close(a.mailbox)
+	}
+
+	return a.done
+}
+
+func (a *Actor[M, R]) handle(m actorMessage[M, R]) {
+	if err := m.ctx.Err(); err != nil {
+		m.reject(err)
+		return
+	}
+
+	r, err := a.handler(m.ctx, m.msg)
+	if err != nil {
+		m.reject(err)
+		return
+	}
+
+	m.resolve(r)
+}
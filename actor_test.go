@@ -0,0 +1,76 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func newCounterActor() *Actor[int, int] {
+	total := 0
+	return NewActor(func(ctx context.Context, delta int) (int, error) {
+		if delta < 0 {
+			return 0, errors.New("negative delta")
+		}
+
+		total += delta
+		return total, nil
+	}, 16)
+}
+
+func TestActorAsk(t *testing.T) {
+	ctx := context.Background()
+	a := newCounterActor()
+	defer a.Stop()
+
+	replies := make([]*Promise[int], 100)
+	for i := range replies {
+		replies[i] = a.Ask(ctx, 1)
+	}
+
+	v, err := All(ctx, replies...).Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	for i, total := range v {
+		if total != i+1 {
+			t.Errorf("expected messages to be handled in order, got %d at %d", total, i)
+		}
+	}
+
+	if _, err := a.Ask(ctx, -1).Await(ctx); err == nil {
+		t.Error("expected the handler error")
+	}
+}
+
+func TestActorStop(t *testing.T) {
+	ctx := context.Background()
+	a := newCounterActor()
+	queued := a.Ask(ctx, 1)
+
+	if _, err := a.Stop().Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v, err := queued.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the queued message to be handled, got %v, %v", v, err)
+	}
+
+	if _, err := a.Ask(ctx, 1).Await(ctx); !errors.Is(err, ErrActorStopped) {
+		t.Errorf("expected ErrActorStopped, got %v", err)
+	}
+}
+
+func TestActorAskCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := newCounterActor()
+	defer a.Stop()
+
+	if _, err := a.Ask(ctx, 1).Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
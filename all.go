@@ -0,0 +1,223 @@
+package promises
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AllOptions configures AllWith and AllSettledWith.
+type AllOptions struct {
+	// ChildTimeout is how long each promise is awaited on the package clock before it is considered rejected
+	// with an error wrapping ErrTimeout, so that one slow promise is reported on its own. Zero means no timeout.
+	ChildTimeout time.Duration
+
+	// Timeouts overrides ChildTimeout for the promise at the same index. Zero entries use ChildTimeout.
+	Timeouts []time.Duration
+
+	// CancelOnFailure cancels the promises still pending once AllWith is rejected, with the *IndexError as the cause,
+	// instead of leaving them running. It does not apply to AllSettledWith.
+	CancelOnFailure bool
+}
+
+// IndexError is the reason All and AllWith are rejected with, holding the index of the promise that failed first.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("promise %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// allState is the state shared by the goroutines of All, allocated once.
+type allState[T any] struct {
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	results   []T
+	remaining atomic.Int64
+	failed    atomic.Bool
+}
+
+// fail rejects All with the error of the promise at index i, unless it already failed, and cancels the waits
+// for the other promises. An error caused by parent, the context given to All, rejects with the context error instead.
+func (s *allState[T]) fail(parent context.Context, opts *AllOptions, i int, err error, reject Reject, promises []*Promise[T]) {
+	if !s.failed.CompareAndSwap(false, true) {
+		return
+	}
+
+	if parent.Err() != nil && s.ctx.Err() != nil {
+		reject(parent.Err())
+		s.cancel(parent.Err())
+		return
+	}
+
+	err = indexed(i, err)
+	reject(err)
+	s.cancel(err)
+	if opts != nil && opts.CancelOnFailure {
+		for _, p := range promises {
+			p.Cancel(err)
+		}
+	}
+}
+
+// AllWith is All configured with opts.
+func AllWith[T any](ctx context.Context, opts AllOptions, promises ...*Promise[T]) *Promise[[]T] {
+	return all(ctx, &opts, promises)
+}
+
+// AllSettledWith is AllSettled configured with opts.
+// A promise that times out is reported as rejected in its result, while the others are still awaited.
+func AllSettledWith[T any](ctx context.Context, opts AllOptions, promises ...*Promise[T]) *Promise[[]SettledResult[T]] {
+	return allSettled(ctx, &opts, promises)
+}
+
+func (o *AllOptions) timeout(i int) time.Duration {
+	if i < len(o.Timeouts) && o.Timeouts[i] > 0 {
+		return o.Timeouts[i]
+	}
+
+	return o.ChildTimeout
+}
+
+// errChildTimeout is the error awaitChild returns when the timeout of a promise expires,
+// which indexed tells apart from a promise rejected with ErrTimeout.
+var errChildTimeout = fmt.Errorf("child: %w", ErrTimeout)
+
+// indexed returns err as the reason of the promise at index i of a combinator.
+func indexed(i int, err error) *IndexError {
+	if err == errChildTimeout {
+		err = ErrTimeout
+	}
+
+	return &IndexError{Index: i, Err: err}
+}
+
+// awaitChild awaits the promise at index i of a combinator, within its timeout in opts, if any.
+// It returns errChildTimeout if the timeout expires first.
+func awaitChild[T any](ctx context.Context, opts *AllOptions, i int, p *Promise[T]) (T, error) {
+	if opts == nil {
+		return p.Await(ctx)
+	}
+
+	d := opts.timeout(i)
+	if d <= 0 {
+		return p.Await(ctx)
+	}
+
+	timer := CurrentClock().NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-p.Done():
+	case <-ctx.Done():
+	case <-timer.C():
+		var zero T
+		return zero, errChildTimeout
+	}
+
+	return p.Await(ctx)
+}
+
+// AllSettledUntil is AllSettled that stops waiting at the deadline on the package clock and fulfills with a snapshot:
+// the promises settled by then have their results, and the others have the Pending status, so that callers such as
+// dashboards can show a partial batch. If cancelPending is set, those others are canceled with ErrTimeout as the cause.
+// If ctx is done before the deadline, it is rejected with the context error.
+func AllSettledUntil[T any](ctx context.Context, deadline time.Time, cancelPending bool, promises ...*Promise[T]) *Promise[[]SettledResult[T]] {
+	p := New(func(resolve Resolve[[]SettledResult[T]], reject Reject) {
+		timer := CurrentClock().NewTimer(deadline.Sub(CurrentClock().Now()))
+		defer timer.Stop()
+
+	wait:
+		for _, promise := range promises {
+			select {
+			case <-promise.Done():
+			case <-timer.C():
+				break wait
+			case <-ctx.Done():
+				reject(ctx.Err())
+				return
+			}
+		}
+
+		results := make([]SettledResult[T], len(promises))
+		for i, promise := range promises {
+			r, ok := promise.Snapshot()
+			if !ok && cancelPending && !promise.Cancel(ErrTimeout) {
+				// It settled after all.
+				r, ok = promise.Snapshot()
+			}
+
+			if !ok {
+				r = SettledResult[T]{Status: Pending}
+			}
+
+			results[i] = r
+		}
+
+		resolve(results)
+	})
+
+	for _, promise := range promises {
+		link(p.info, promise.info)
+	}
+
+	return p
+}
+
+// AllWithLimit runs the tasks with at most limit of them at a time and returns a promise of their results in order.
+// Unlike All, which awaits promises whose executors are already running, the tasks wait in a queue that limit workers
+// take them from in order, so fanning out thousands of calls starts limit goroutines. A limit of zero or less means no limit.
+// If a task fails or panics, or ctx is done, the tasks not started yet are skipped, the context given to the running ones
+// is canceled, and the promise is rejected with the error.
+func AllWithLimit[T any](ctx context.Context, limit int, tasks ...func(ctx context.Context) (T, error)) *Promise[[]T] {
+	return New(func(resolve Resolve[[]T], reject Reject) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		if limit <= 0 || limit > len(tasks) {
+			limit = len(tasks)
+		}
+
+		results := make([]T, len(tasks))
+		var next atomic.Int64
+		var wg sync.WaitGroup
+		wg.Add(limit)
+		for w := 0; w < limit; w++ {
+			go func() {
+				defer wg.Done()
+				defer recoverTo(nil, Reject(cancel))
+
+				for {
+					i := int(next.Add(1) - 1)
+					if i >= len(tasks) || ctx.Err() != nil {
+						return
+					}
+
+					v, err := tasks[i](ctx)
+					if err != nil {
+						cancel(err)
+						return
+					}
+
+					results[i] = v
+				}
+			}()
+		}
+
+		wg.Wait()
+		if ctx.Err() != nil {
+			reject(context.Cause(ctx))
+			return
+		}
+
+		resolve(results)
+	})
+}
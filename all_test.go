@@ -0,0 +1,218 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAllWithChildTimeout(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	_, err := AllWith(ctx, AllOptions{ChildTimeout: 10 * time.Millisecond}, newResolved(1), blocked(block)).Await(ctx)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestAllIndexError(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	reason := errors.New("boom")
+	sibling := blocked(block)
+	_, err := All(ctx, sibling, after(0, 0, reason)).Await(ctx)
+
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 1 || !errors.Is(err, reason) {
+		t.Fatalf("expected an *IndexError of promise 1 wrapping boom, got %v", err)
+	}
+
+	if sibling.IsSettled() {
+		t.Error("expected the sibling to keep running")
+	}
+}
+
+func TestAllWithCancelOnFailure(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	reason := errors.New("boom")
+	sibling := blocked(block)
+	_, err := AllWith(ctx, AllOptions{CancelOnFailure: true}, sibling, after(0, 0, reason)).Await(ctx)
+	if !errors.Is(err, reason) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+
+	var indexErr *IndexError
+	if !errors.As(sibling.CancelCause(), &indexErr) || indexErr.Index != 1 {
+		t.Errorf("expected the sibling to be canceled with the *IndexError, got %v", sibling.CancelCause())
+	}
+}
+
+func TestAllSettledWithTimeouts(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	results, err := AllSettledWith(ctx, AllOptions{
+		ChildTimeout: time.Minute,
+		Timeouts:     []time.Duration{0, 10 * time.Millisecond},
+	}, after(20*time.Millisecond, 1, nil), blocked(block)).Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != Fulfilled || results[0].Value != 1 {
+		t.Errorf("expected the first promise to be fulfilled, got %v", results[0])
+	}
+
+	if results[1].Status != Rejected || !errors.Is(results[1].Reason, ErrTimeout) {
+		t.Errorf("expected the second promise to time out, got %v", results[1])
+	}
+}
+
+func TestAllSettledUntil(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	slow := blocked(block)
+	deadline := time.Now().Add(20 * time.Millisecond)
+	results, err := AllSettledUntil(ctx, deadline, false, newResolved(1), slow).Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != Fulfilled || results[0].Value != 1 {
+		t.Errorf("expected the first promise to be fulfilled, got %v", results[0])
+	}
+
+	if results[1].Status != Pending {
+		t.Errorf("expected the second promise to be pending, got %v", results[1])
+	}
+
+	if slow.State() != Pending {
+		t.Errorf("expected the pending promise to be left running, got %v", slow.State())
+	}
+}
+
+func TestAllSettledUntilCancel(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	slow := blocked(block)
+	results, err := AllSettledUntil(ctx, time.Now().Add(10*time.Millisecond), true, slow).Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != Pending {
+		t.Errorf("expected the promise to be pending in the snapshot, got %v", results[0])
+	}
+
+	if !errors.Is(slow.CancelCause(), ErrTimeout) {
+		t.Errorf("expected the promise to be canceled with ErrTimeout, got %v", slow.CancelCause())
+	}
+}
+
+func TestAllSettledUntilContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := AllSettledUntil(ctx, time.Now().Add(time.Minute), false, blocked(block)).Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestAllWithLimit(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	tasks := make([]func(ctx context.Context) (int, error), 50)
+	for i := range tasks {
+		i := i
+		tasks[i] = func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			return i * 2, nil
+		}
+	}
+
+	values, err := AllWithLimit(ctx, 4, tasks...).Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range values {
+		if v != i*2 {
+			t.Fatalf("expected the results in order, got %v", values)
+		}
+	}
+
+	if peak.Load() > 4 {
+		t.Errorf("expected at most 4 tasks at a time, got %d", peak.Load())
+	}
+}
+
+func TestAllWithLimitError(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	var started atomic.Int32
+	tasks := make([]func(ctx context.Context) (int, error), 10)
+	for i := range tasks {
+		i := i
+		tasks[i] = func(ctx context.Context) (int, error) {
+			started.Add(1)
+			if i == 1 {
+				return 0, cause
+			}
+
+			if i == 0 {
+				<-ctx.Done()
+			}
+
+			return i, nil
+		}
+	}
+
+	_, err := AllWithLimit(ctx, 2, tasks...).Await(ctx)
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the error of the task, got %v", err)
+	}
+
+	if n := started.Load(); n != 2 {
+		t.Errorf("expected the queued tasks to be skipped, got %d started", n)
+	}
+}
+
+func TestAllWithLimitPanic(t *testing.T) {
+	ctx := context.Background()
+	_, err := AllWithLimit(ctx, 1, func(ctx context.Context) (int, error) {
+		panic("boom")
+	}).Await(ctx)
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected *PanicError, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package promises
+
+import "context"
+
+// Apply returns a promise of the function pf is fulfilled with, applied to the value pa is fulfilled with.
+// Both promises are awaited with ctx at the same time, and the first rejection, the error of the function
+// or the context error rejects the result.
+func Apply[A, B any](ctx context.Context, pf *Promise[func(A) (B, error)], pa *Promise[A]) *Promise[B] {
+	p := New(func(resolve Resolve[B], reject Reject) {
+		f, a, err := Await2(ctx, pf, pa)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		b, err := f(a)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(b)
+	})
+
+	link(p.info, pf.info)
+	link(p.info, pa.info)
+	return p
+}
@@ -0,0 +1,45 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	pf := New(func(resolve Resolve[func(int) (string, error)], reject Reject) {
+		resolve(func(i int) (string, error) {
+			return strconv.Itoa(i * 2), nil
+		})
+	})
+
+	if v, err := Apply(ctx, pf, newResolved(21)).Await(ctx); err != nil || v != "42" {
+		t.Errorf("expected 42, got %v, %v", v, err)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("bad input")
+	failing := New(func(resolve Resolve[func(int) (int, error)], reject Reject) {
+		resolve(func(i int) (int, error) {
+			return 0, cause
+		})
+	})
+
+	if _, err := Apply(ctx, failing, newResolved(1)).Await(ctx); !errors.Is(err, cause) {
+		t.Errorf("expected the function error, got %v", err)
+	}
+
+	missing := errors.New("no value")
+	rejected := New(func(resolve Resolve[int], reject Reject) {
+		reject(missing)
+	})
+	if _, err := Apply(ctx, failing, rejected).Await(ctx); !errors.Is(err, missing) {
+		t.Errorf("expected the rejection of the value, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package promises
+
+import (
+	"context"
+	"time"
+)
+
+// Await2 blocks until both promises are fulfilled and returns their values.
+// If either promise is rejected, it returns the reason as soon as it is known, without waiting for the other.
+// If ctx is done first, it returns the context error.
+func Await2[A, B any](ctx context.Context, pa *Promise[A], pb *Promise[B]) (A, B, error) {
+	var a A
+	var b B
+	if err := awaitAll(ctx, pa, pb); err != nil {
+		return a, b, err
+	}
+
+	return pa.Value(), pb.Value(), nil
+}
+
+// Await3 is Await2 for three promises.
+func Await3[A, B, C any](ctx context.Context, pa *Promise[A], pb *Promise[B], pc *Promise[C]) (A, B, C, error) {
+	var a A
+	var b B
+	var c C
+	if err := awaitAll(ctx, pa, pb, pc); err != nil {
+		return a, b, c, err
+	}
+
+	return pa.Value(), pb.Value(), pc.Value(), nil
+}
+
+// AwaitAll blocks until all promises are fulfilled and returns their values in order,
+// without creating an aggregate promise like All. The promises are awaited in order,
+// so a rejection is returned once the promises before it are fulfilled. If ctx is done first, it returns the context error.
+func AwaitAll[T any](ctx context.Context, promises ...*Promise[T]) ([]T, error) {
+	values := make([]T, len(promises))
+	for i, p := range promises {
+		v, err := p.Await(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// awaitAll blocks until all promises are settled and returns the reason of the first one that is rejected, or the context error.
+func awaitAll(ctx context.Context, promises ...AnyPromise) error {
+	for len(promises) > 0 {
+		i, err := Select(ctx, promises...)
+		if err != nil {
+			return err
+		}
+
+		if err := promises[i].Err(); err != nil {
+			return err
+		}
+
+		promises = append(promises[:i], promises[i+1:]...)
+	}
+
+	return nil
+}
+
+// AwaitWithProgress is Await that calls fn with the elapsed time every interval on the package clock
+// while the promise is pending, for example to print that it is still waiting or to send keep-alives.
+// fn is called on the awaiting goroutine.
+func (p *Promise[T]) AwaitWithProgress(ctx context.Context, interval time.Duration, fn func(elapsed time.Duration)) (T, error) {
+	clock := CurrentClock()
+	start := clock.Now()
+	for {
+		timer := clock.NewTimer(interval)
+		select {
+		case <-p.Done():
+			timer.Stop()
+			return p.Await(ctx)
+		case <-ctx.Done():
+			timer.Stop()
+			return p.Await(ctx)
+		case <-timer.C():
+			fn(clock.Now().Sub(start))
+		}
+	}
+}
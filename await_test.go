@@ -0,0 +1,121 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAwait2(t *testing.T) {
+	ctx := context.Background()
+	s := New(func(resolve Resolve[string], reject Reject) {
+		resolve("one")
+	})
+
+	n, v, err := Await2(ctx, newResolved(1), s)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if n != 1 || v != "one" {
+		t.Errorf("expected 1 and one, got %v and %v", n, v)
+	}
+}
+
+func TestAwait2FailFast(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	pending := New(func(resolve Resolve[int], reject Reject) {})
+	rejected := New(func(resolve Resolve[string], reject Reject) {
+		reject(reason)
+	})
+
+	if _, _, err := Await2(ctx, pending, rejected); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
+
+func TestAwait3(t *testing.T) {
+	ctx := context.Background()
+	s := New(func(resolve Resolve[string], reject Reject) {
+		resolve("one")
+	})
+	b := New(func(resolve Resolve[bool], reject Reject) {
+		time.Sleep(10 * time.Millisecond)
+		resolve(true)
+	})
+
+	n, v, ok, err := Await3(ctx, newResolved(1), s, b)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if n != 1 || v != "one" || !ok {
+		t.Errorf("expected 1, one and true, got %v, %v and %v", n, v, ok)
+	}
+}
+
+func TestAwait3Canceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	pending := New(func(resolve Resolve[int], reject Reject) {})
+
+	if _, _, _, err := Await3(ctx, newResolved(1), pending, pending); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAwaitWithProgress(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		time.Sleep(50 * time.Millisecond)
+		resolve(1)
+	})
+
+	var elapsed []time.Duration
+	v, err := p.AwaitWithProgress(ctx, 10*time.Millisecond, func(d time.Duration) {
+		elapsed = append(elapsed, d)
+	})
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	if len(elapsed) == 0 {
+		t.Fatal("expected the callback to be called while waiting")
+	}
+
+	for i := 1; i < len(elapsed); i++ {
+		if elapsed[i] <= elapsed[i-1] {
+			t.Errorf("expected the elapsed time to grow, got %v", elapsed)
+		}
+	}
+}
+
+func TestAwaitWithProgressSettled(t *testing.T) {
+	ctx := context.Background()
+	p := newResolved(1)
+	<-p.Done()
+
+	v, err := p.AwaitWithProgress(ctx, time.Millisecond, func(time.Duration) {
+		t.Error("expected the callback not to be called")
+	})
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	ctx := context.Background()
+	v, err := AwaitAll(ctx, newResolved(1), newResolved(2), newResolved(3))
+	if err != nil || len(v) != 3 || v[0] != 1 || v[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v, %v", v, err)
+	}
+
+	reason := errors.New("reason")
+	if _, err := AwaitAll(ctx, newResolved(1), after(0, 0, reason)); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package promises
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+var defaultContext atomic.Pointer[context.Context]
+
+func init() {
+	SetDefaultContext(context.Background())
+}
+
+// SetDefaultContext replaces the context used by the BG variants and returns a function that restores the previous one.
+// The ctx-taking functions are the primary API; the BG variants are meant for scripts and tests.
+func SetDefaultContext(ctx context.Context) (restore func()) {
+	previous := defaultContext.Swap(&ctx)
+	return func() {
+		defaultContext.Store(previous)
+	}
+}
+
+// DefaultContext returns the context used by the BG variants. It is context.Background unless replaced with SetDefaultContext.
+func DefaultContext() context.Context {
+	return *defaultContext.Load()
+}
+
+// AwaitBG is Await with the default context.
+func (p *Promise[T]) AwaitBG() (T, error) {
+	return p.Await(DefaultContext())
+}
+
+// AllBG is All with the default context.
+func AllBG[T any](promises ...*Promise[T]) *Promise[[]T] {
+	return All(DefaultContext(), promises...)
+}
+
+// AllSettledBG is AllSettled with the default context.
+func AllSettledBG[T any](promises ...*Promise[T]) *Promise[[]SettledResult[T]] {
+	return AllSettled(DefaultContext(), promises...)
+}
@@ -0,0 +1,50 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAwaitBG(t *testing.T) {
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+
+	v, err := p.AwaitBG()
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestAllBG(t *testing.T) {
+	promises := newResolvedSlice(3)
+
+	v, err := AllBG(promises...).AwaitBG()
+	if err != nil || len(v) != 3 {
+		t.Errorf("expected 3 values, got %v, %v", v, err)
+	}
+
+	results, err := AllSettledBG(promises...).AwaitBG()
+	if err != nil || len(results) != 3 {
+		t.Errorf("expected 3 results, got %v, %v", results, err)
+	}
+}
+
+func TestSetDefaultContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	restore := SetDefaultContext(ctx)
+
+	p := New(func(resolve Resolve[int], reject Reject) {})
+	if _, err := p.AwaitBG(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	restore()
+	if DefaultContext() != context.Background() {
+		t.Error("expected the default context to be restored")
+	}
+}
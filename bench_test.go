@@ -0,0 +1,252 @@
+package promises_test
+
+import (
+	"context"
+	"runtime/metrics"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+const wideFanOut = 10000
+
+// assertMaxAllocs fails the test if fn allocates more than max times on average.
+func assertMaxAllocs(t *testing.T, max float64, fn func()) {
+	t.Helper()
+
+	allocs := testing.AllocsPerRun(100, fn)
+	if allocs > max {
+		t.Errorf("expected at most %v allocations, got %v", max, allocs)
+	}
+}
+
+// goroutinesCreated returns the number of goroutines created since the program started,
+// or skips tb if the runtime does not count them.
+func goroutinesCreated(tb testing.TB) uint64 {
+	tb.Helper()
+
+	samples := []metrics.Sample{{Name: "/sched/goroutines-created:goroutines"}}
+	metrics.Read(samples)
+	if samples[0].Value.Kind() != metrics.KindUint64 {
+		tb.Skip("the runtime does not count created goroutines")
+	}
+
+	return samples[0].Value.Uint64()
+}
+
+// reportGoroutines reports the goroutines created per operation once the benchmark ends.
+func reportGoroutines(b *testing.B) {
+	b.Helper()
+
+	start := goroutinesCreated(b)
+	b.Cleanup(func() {
+		b.ReportMetric(float64(goroutinesCreated(b)-start)/float64(b.N), "goroutines/op")
+	})
+}
+
+func newResolved(i int) *Promise[int] {
+	return New(func(resolve Resolve[int], reject Reject) {
+		resolve(i)
+	})
+}
+
+func newResolvedSlice(n int) []*Promise[int] {
+	promises := make([]*Promise[int], n)
+	for i := range promises {
+		promises[i] = newResolved(i)
+	}
+
+	return promises
+}
+
+func TestAllocsNewAwait(t *testing.T) {
+	ctx := context.Background()
+	assertMaxAllocs(t, 6, func() {
+		p := newResolved(1)
+		_, _ = p.Await(ctx)
+	})
+}
+
+func TestAllocsNewSyncAwait(t *testing.T) {
+	ctx := context.Background()
+	assertMaxAllocs(t, 4, func() {
+		_, _ = New(func(resolve Resolve[int], reject Reject) {
+			resolve(1)
+		}, WithSync()).Await(ctx)
+	})
+}
+
+func TestGoroutinesNew(t *testing.T) {
+	ctx := context.Background()
+	const n = 1000
+
+	start := goroutinesCreated(t)
+	for i := 0; i < n; i++ {
+		_, _ = New(func(resolve Resolve[int], reject Reject) {
+			resolve(i)
+		}, WithSync()).Await(ctx)
+		_, _ = NewResolved(i).Await(ctx)
+	}
+
+	// Other tests may leave goroutines behind that start a few more.
+	if created := goroutinesCreated(t) - start; created >= n/10 {
+		t.Errorf("expected WithSync and NewResolved to start no goroutines, got %d for %d promises", created, 2*n)
+	}
+
+	start = goroutinesCreated(t)
+	for i := 0; i < n; i++ {
+		_, _ = newResolved(i).Await(ctx)
+	}
+
+	if created := goroutinesCreated(t) - start; created < n {
+		t.Errorf("expected New to start a goroutine per executor, got %d for %d promises", created, n)
+	}
+}
+
+func TestAllocsAwaitSettled(t *testing.T) {
+	ctx := context.Background()
+	p := newResolved(1)
+	<-p.Done()
+	assertMaxAllocs(t, 0, func() {
+		_, _ = p.Await(ctx)
+	})
+}
+
+func TestAllocsAll(t *testing.T) {
+	ctx := context.Background()
+	promises := newResolvedSlice(3)
+	assertMaxAllocs(t, 13, func() {
+		_, _ = All(ctx, promises...).Await(ctx)
+	})
+}
+
+func TestAllocsAllSettled(t *testing.T) {
+	ctx := context.Background()
+	promises := newResolvedSlice(3)
+	assertMaxAllocs(t, 14, func() {
+		_, _ = AllSettled(ctx, promises...).Await(ctx)
+	})
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	reportGoroutines(b)
+	for i := 0; i < b.N; i++ {
+		newResolved(i)
+	}
+}
+
+func BenchmarkNewAwait(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	reportGoroutines(b)
+	for i := 0; i < b.N; i++ {
+		_, _ = newResolved(i).Await(ctx)
+	}
+}
+
+func BenchmarkNewSyncAwait(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	reportGoroutines(b)
+	for i := 0; i < b.N; i++ {
+		_, _ = New(func(resolve Resolve[int], reject Reject) {
+			resolve(i)
+		}, WithSync()).Await(ctx)
+	}
+}
+
+func BenchmarkAwaitSettled(b *testing.B) {
+	ctx := context.Background()
+	p := newResolved(1)
+	<-p.Done()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Await(ctx)
+	}
+}
+
+func BenchmarkThenChain(b *testing.B) {
+	ctx := context.Background()
+	inc := func(v int) (int, error) {
+		return v + 1, nil
+	}
+
+	b.ReportAllocs()
+	reportGoroutines(b)
+	for i := 0; i < b.N; i++ {
+		p := newResolved(i)
+		for j := 0; j < 5; j++ {
+			p = p.Then(ctx, inc)
+		}
+
+		_, _ = p.Await(ctx)
+	}
+}
+
+func benchmarkAll(b *testing.B, n int) {
+	ctx := context.Background()
+	promises := newResolvedSlice(n)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = All(ctx, promises...).Await(ctx)
+	}
+}
+
+func BenchmarkAllSmall(b *testing.B) {
+	benchmarkAll(b, 3)
+}
+
+func BenchmarkAllWide(b *testing.B) {
+	benchmarkAll(b, wideFanOut)
+}
+
+func benchmarkAllSettled(b *testing.B, n int) {
+	ctx := context.Background()
+	promises := newResolvedSlice(n)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = AllSettled(ctx, promises...).Await(ctx)
+	}
+}
+
+func BenchmarkAllSettledSmall(b *testing.B) {
+	benchmarkAllSettled(b, 3)
+}
+
+func BenchmarkAllSettledWide(b *testing.B) {
+	benchmarkAllSettled(b, wideFanOut)
+}
+
+func TestAllocsGetNow(t *testing.T) {
+	p := newResolved(1)
+	<-p.Done()
+	assertMaxAllocs(t, 0, func() {
+		_, _ = p.GetNow()
+	})
+}
+
+func TestAllocsAwaitAll(t *testing.T) {
+	ctx := context.Background()
+	promises := newResolvedSlice(3)
+	for _, p := range promises {
+		<-p.Done()
+	}
+
+	assertMaxAllocs(t, 1, func() {
+		_, _ = AwaitAll(ctx, promises...)
+	})
+}
+
+func TestAllocsNewResolved(t *testing.T) {
+	ctx := context.Background()
+	assertMaxAllocs(t, 3, func() {
+		_, _ = NewResolved(1).Await(ctx)
+	})
+}
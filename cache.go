@@ -0,0 +1,237 @@
+package promises
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheOptions configures a Cache.
+type CacheOptions[K comparable] struct {
+	// TTL is how long a fulfilled value is fresh. Zero means values never expire.
+	TTL time.Duration
+
+	// StaleWhileRevalidate is how long after the TTL a value is still served while it is refreshed in the background.
+	// Zero means expired values are fetched again before they are served.
+	StaleWhileRevalidate time.Duration
+
+	// OnRefreshError is called when a background refresh fails. The stale value keeps being served until it expires.
+	OnRefreshError func(key K, err error)
+
+	// OnRefresh is called when a background refresh succeeds and its value replaces the stale one.
+	OnRefresh func(key K)
+
+	// OnEvict is called when an entry is removed from the cache, with the reason it was removed.
+	OnEvict func(key K, reason EvictReason)
+}
+
+// EvictReason is the reason an entry was removed from a Cache.
+type EvictReason int
+
+const (
+	// EvictInvalidated means the entry was removed by Invalidate or InvalidateWhere.
+	EvictInvalidated EvictReason = iota
+	// EvictExpired means the entry expired and was replaced by a new fetch.
+	EvictExpired
+)
+
+// Cache memoizes promises by key. Concurrent gets of a key share one fetch, and fulfilled values are reused
+// until they expire. Rejected fetches are not cached, so the next get fetches again.
+// Fetches run detached from the callers, which wait with their own contexts.
+type Cache[K comparable, V any] struct {
+	fetch   func(ctx context.Context, key K) (V, error)
+	opts    CacheOptions[K]
+	entries map[K]*cacheEntry[V]
+	mutex   sync.Mutex
+}
+
+type cacheEntry[V any] struct {
+	promise    *Promise[V]
+	fetched    bool
+	fetchedAt  time.Time
+	refreshing bool
+	hits       int
+}
+
+// NewCache creates a cache that fetches missing and expired values with fetch.
+func NewCache[K comparable, V any](fetch func(ctx context.Context, key K) (V, error), opts CacheOptions[K]) *Cache[K, V] {
+	return &Cache[K, V]{fetch: fetch, opts: opts, entries: make(map[K]*cacheEntry[V])}
+}
+
+// Get returns the promise of the value of the key.
+// A fresh or in-flight promise is reused. A stale value is returned immediately while it is refreshed in the background.
+// Otherwise, a new fetch is started. Each caller gets a handle of its own to the promise, so canceling it
+// cancels the promise for the other callers only if they all canceled theirs.
+func (c *Cache[K, V]) Get(key K) *Promise[V] {
+	p, expired := c.get(key)
+	if expired && c.opts.OnEvict != nil {
+		c.opts.OnEvict(key, EvictExpired)
+	}
+
+	return p.handle()
+}
+
+// get returns the promise of the value of the key and whether an expired entry was replaced.
+func (c *Cache[K, V]) get(key K) (*Promise[V], bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[key]
+	if ok && (e.promise.isRejected() || e.promise.released()) {
+		// Every caller canceled the promise before it was fetched.
+		ok = false
+	}
+
+	if ok {
+		e.hits++
+		if !e.fetched {
+			return e.promise, false
+		}
+
+		age := CurrentClock().Now().Sub(e.fetchedAt)
+		if c.opts.TTL <= 0 || age < c.opts.TTL {
+			return e.promise, false
+		}
+
+		if age < c.opts.TTL+c.opts.StaleWhileRevalidate {
+			if !e.refreshing {
+				e.refreshing = true
+				go c.refresh(key, e)
+			}
+
+			return e.promise, false
+		}
+	}
+
+	e = &cacheEntry[V]{}
+	e.promise = New(func(resolve Resolve[V], reject Reject) {
+		v, err := c.fetch(context.Background(), key)
+
+		c.mutex.Lock()
+		if err != nil {
+			if c.entries[key] == e {
+				delete(c.entries, key)
+			}
+		} else {
+			e.fetched = true
+			e.fetchedAt = CurrentClock().Now()
+		}
+		c.mutex.Unlock()
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	}).share()
+
+	// An entry that was found but not returned above has expired.
+	c.entries[key] = e
+	return e.promise, ok
+}
+
+// Invalidate removes the key from the cache, so that the next get fetches it again.
+// A fetch in flight for the key still settles the promises already returned, but is not cached.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mutex.Lock()
+	_, ok := c.entries[key]
+	delete(c.entries, key)
+	c.mutex.Unlock()
+
+	if ok && c.opts.OnEvict != nil {
+		c.opts.OnEvict(key, EvictInvalidated)
+	}
+}
+
+// InvalidateWhere removes the keys for which pred returns true, like Invalidate, and returns how many were removed.
+func (c *Cache[K, V]) InvalidateWhere(pred func(key K) bool) int {
+	c.mutex.Lock()
+	var keys []K
+	for key := range c.entries {
+		if pred(key) {
+			keys = append(keys, key)
+			delete(c.entries, key)
+		}
+	}
+	c.mutex.Unlock()
+
+	if c.opts.OnEvict != nil {
+		for _, key := range keys {
+			c.opts.OnEvict(key, EvictInvalidated)
+		}
+	}
+
+	return len(keys)
+}
+
+// RefreshHot refreshes in the background, every interval on the package clock, the fetched keys that were got
+// at least minHits times since the previous interval, so that hot keys rarely expire into a slow miss.
+// It runs until stop is called.
+func (c *Cache[K, V]) RefreshHot(interval time.Duration, minHits int) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			timer := CurrentClock().NewTimer(interval)
+			select {
+			case <-timer.C():
+				c.refreshHot(minHits)
+			case <-done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+func (c *Cache[K, V]) refreshHot(minHits int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, e := range c.entries {
+		if e.fetched && !e.refreshing && e.hits >= minHits {
+			e.refreshing = true
+			go c.refresh(key, e)
+		}
+
+		e.hits = 0
+	}
+}
+
+// refresh fetches the value of a stale entry and replaces the entry if it succeeds.
+func (c *Cache[K, V]) refresh(key K, stale *cacheEntry[V]) {
+	v, err := c.fetch(context.Background(), key)
+	if err != nil {
+		c.mutex.Lock()
+		stale.refreshing = false
+		c.mutex.Unlock()
+
+		if c.opts.OnRefreshError != nil {
+			c.opts.OnRefreshError(key, err)
+		}
+
+		return
+	}
+
+	p, resolve, _ := newPending[V](nil)
+	p.share()
+	resolve(v)
+
+	c.mutex.Lock()
+	replaced := c.entries[key] == stale
+	if replaced {
+		c.entries[key] = &cacheEntry[V]{promise: p, fetched: true, fetchedAt: CurrentClock().Now()}
+	}
+	c.mutex.Unlock()
+
+	if replaced && c.opts.OnRefresh != nil {
+		c.opts.OnRefresh(key)
+	}
+}
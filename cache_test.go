@@ -0,0 +1,237 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+// manualClock is a Clock whose time only moves when set.
+type manualClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	return RealClock().NewTimer(d)
+}
+
+func (c *manualClock) Add(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+type countingFetch struct {
+	calls atomic.Int32
+	err   atomic.Pointer[error]
+}
+
+func (f *countingFetch) fetch(ctx context.Context, key string) (int, error) {
+	n := int(f.calls.Add(1))
+	if err := f.err.Load(); err != nil {
+		return 0, *err
+	}
+
+	return n, nil
+}
+
+func TestCacheShared(t *testing.T) {
+	ctx := context.Background()
+	f := &countingFetch{}
+	c := NewCache(f.fetch, CacheOptions[string]{})
+
+	p1, p2 := c.Get("a"), c.Get("a")
+	for _, p := range []*Promise[int]{p1, p2} {
+		if v, err := p.Await(ctx); err != nil || v != 1 {
+			t.Errorf("expected concurrent gets to share the fetch of 1, got %v, %v", v, err)
+		}
+	}
+
+	if v, _ := c.Get("a").Await(ctx); v != 1 {
+		t.Errorf("expected the cached value, got %v", v)
+	}
+
+	if v, _ := c.Get("b").Await(ctx); v != 2 {
+		t.Errorf("expected another key to be fetched, got %v", v)
+	}
+}
+
+func TestCacheRejectedIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	f := &countingFetch{}
+	reason := errors.New("reason")
+	f.err.Store(&reason)
+	c := NewCache(f.fetch, CacheOptions[string]{})
+
+	if _, err := c.Get("a").Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+
+	f.err.Store(nil)
+	if v, err := c.Get("a").Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected a new fetch, got %v, %v", v, err)
+	}
+}
+
+func TestCacheTimedOutCaller(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	c := NewCache(func(ctx context.Context, key string) (int, error) {
+		<-release
+		return 1, nil
+	}, CacheOptions[string]{})
+
+	other := c.Get("a")
+	if _, err := Timeout(c.Get("a"), 10*time.Millisecond).Await(ctx); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	// Give the timeout a chance to cancel its source before the fetch completes.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected a timed-out caller not to cancel the fetch of the others, got %v, %v", v, err)
+	}
+
+	if v, err := c.Get("a").Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the fetch to be cached, got %v, %v", v, err)
+	}
+
+	c.Get("b").Cancel(nil)
+	if v, err := c.Get("b").Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected a canceled fetch not to be cached, got %v, %v", v, err)
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Unix(0, 0)}
+	defer SetClock(clock)()
+
+	f := &countingFetch{}
+	refreshErrs := make(chan error, 1)
+	c := NewCache(f.fetch, CacheOptions[string]{
+		TTL:                  time.Minute,
+		StaleWhileRevalidate: time.Minute,
+		OnRefreshError: func(key string, err error) {
+			refreshErrs <- err
+		},
+	})
+
+	c.Get("a").Await(ctx)
+	clock.Add(90 * time.Second)
+
+	if v, _ := c.Get("a").Await(ctx); v != 1 {
+		t.Errorf("expected the stale value, got %v", v)
+	}
+
+	refreshed := Poll(ctx, time.Millisecond, func() bool {
+		v, _ := c.Get("a").Await(ctx)
+		return v == 2
+	}, WithTimeout(time.Second))
+	if _, err := refreshed.Await(ctx); err != nil {
+		t.Fatalf("expected the value to be refreshed, got %v", err)
+	}
+
+	clock.Add(90 * time.Second)
+	reason := errors.New("reason")
+	f.err.Store(&reason)
+	if v, _ := c.Get("a").Await(ctx); v != 2 {
+		t.Errorf("expected the stale value, got %v", v)
+	}
+
+	if err := <-refreshErrs; !errors.Is(err, reason) {
+		t.Errorf("expected the refresh error, got %v", err)
+	}
+
+	clock.Add(time.Minute)
+	f.err.Store(nil)
+	if v, _ := c.Get("a").Await(ctx); v != 4 {
+		t.Errorf("expected the expired value to be fetched again, got %v", v)
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	f := &countingFetch{}
+	var evicted []string
+	c := NewCache(f.fetch, CacheOptions[string]{
+		OnEvict: func(key string, reason EvictReason) {
+			if reason == EvictInvalidated {
+				evicted = append(evicted, key)
+			}
+		},
+	})
+
+	for _, key := range []string{"a", "b", "c"} {
+		c.Get(key).Await(ctx)
+	}
+
+	c.Invalidate("a")
+	c.Invalidate("missing")
+	if v, _ := c.Get("a").Await(ctx); v != 4 {
+		t.Errorf("expected the invalidated key to be fetched again, got %v", v)
+	}
+
+	if n := c.InvalidateWhere(func(key string) bool { return key != "a" }); n != 2 {
+		t.Errorf("expected 2 keys to be invalidated, got %d", n)
+	}
+
+	if v, _ := c.Get("a").Await(ctx); v != 4 {
+		t.Errorf("expected the remaining key to be cached, got %v", v)
+	}
+
+	sort.Strings(evicted[1:])
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("expected %v to be evicted, got %v", want, evicted)
+	}
+}
+
+func TestCacheRefreshHot(t *testing.T) {
+	ctx := context.Background()
+	f := &countingFetch{}
+	refreshed := make(chan string, 1)
+	c := NewCache(f.fetch, CacheOptions[string]{
+		TTL: time.Hour,
+		OnRefresh: func(key string) {
+			refreshed <- key
+		},
+	})
+
+	c.Get("hot").Await(ctx)
+	c.Get("cold").Await(ctx)
+	for i := 0; i < 3; i++ {
+		c.Get("hot")
+	}
+
+	stop := c.RefreshHot(10*time.Millisecond, 3)
+	defer stop()
+
+	if key := <-refreshed; key != "hot" {
+		t.Errorf("expected the hot key to be refreshed, got %v", key)
+	}
+
+	if v, _ := c.Get("hot").Await(ctx); v != 3 {
+		t.Errorf("expected the refreshed value, got %v", v)
+	}
+
+	if v, _ := c.Get("cold").Await(ctx); v != 2 {
+		t.Errorf("expected the cold key not to be refreshed, got %v", v)
+	}
+}
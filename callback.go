@@ -0,0 +1,145 @@
+package promises
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+)
+
+// WithOrderedCallbacks runs the callbacks registered with OnSettle one at a time, in registration order, on a single goroutine,
+// instead of each on its own goroutine. Use it when callbacks depend on the effects of earlier ones.
+func WithOrderedCallbacks() Option {
+	return func(o *options) {
+		o.orderedCallbacks = true
+	}
+}
+
+// OnSettle registers fn to be called with the outcome of the promise once it is settled, or right away if it already is.
+// Callbacks are called asynchronously. By default each runs on its own goroutine, concurrently with the others;
+// if the promise was created with WithOrderedCallbacks, they run in registration order on a single goroutine,
+// and if it was created with WithEventLoop, they are queued on the loop.
+func (p *Promise[T]) OnSettle(fn func(SettledResult[T])) {
+	p.pin()
+	p.target().addCallback(&fn)
+}
+
+// Subscribe is OnSettle that can be undone: fn is called once with the outcome of the promise, right away if it is
+// already settled, unless unsubscribe is called or ctx is done first. Each subscriber is independent of the others,
+// so a promise can notify any number of listeners. Unsubscribing after fn was called has no effect.
+func (p *Promise[T]) Subscribe(ctx context.Context, fn func(SettledResult[T])) (unsubscribe func()) {
+	p.pin()
+	p = p.target()
+
+	var called atomic.Bool
+	var stop func() bool
+	cb := func(r SettledResult[T]) {
+		if called.CompareAndSwap(false, true) && stop() && ctx.Err() == nil {
+			fn(r)
+		}
+	}
+
+	remove := func() {
+		if called.CompareAndSwap(false, true) {
+			p.removeCallback(&cb)
+		}
+	}
+
+	stop = context.AfterFunc(ctx, remove)
+	p.addCallback(&cb)
+	return func() {
+		stop()
+		remove()
+	}
+}
+
+func (p *Promise[T]) addCallback(fn *func(SettledResult[T])) {
+	p.startLazy()
+
+	p.handled.Store(true)
+	p.mutex.Lock()
+	p.callbacks = append(p.callbacks, fn)
+	settled := p.isSettled()
+	p.mutex.Unlock()
+
+	if settled {
+		p.dispatch()
+	}
+}
+
+func (p *Promise[T]) removeCallback(fn *func(SettledResult[T])) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if i := slices.Index(p.callbacks, fn); i >= 0 {
+		p.callbacks = slices.Delete(p.callbacks, i, i+1)
+	}
+}
+
+// dispatch runs the registered callbacks of the settled promise.
+func (p *Promise[T]) dispatch() {
+	p.mutex.RLock()
+	n := len(p.callbacks)
+	p.mutex.RUnlock()
+	if n == 0 {
+		return
+	}
+
+	result, _ := p.Snapshot()
+
+	p.mutex.Lock()
+	if len(p.callbacks) == 0 {
+		p.mutex.Unlock()
+		return
+	}
+
+	if p.opts != nil && p.opts.loop != nil {
+		callbacks := p.callbacks
+		p.callbacks = nil
+		p.mutex.Unlock()
+
+		for _, fn := range callbacks {
+			p.opts.loop.Schedule(func() {
+				(*fn)(result)
+			})
+		}
+
+		return
+	}
+
+	if !p.orderedCallbacks {
+		callbacks := p.callbacks
+		p.callbacks = nil
+		p.mutex.Unlock()
+
+		for _, fn := range callbacks {
+			go (*fn)(result)
+		}
+
+		return
+	}
+
+	if p.dispatching {
+		p.mutex.Unlock()
+		return
+	}
+
+	p.dispatching = true
+	p.mutex.Unlock()
+
+	go func() {
+		for {
+			p.mutex.Lock()
+			if len(p.callbacks) == 0 {
+				p.dispatching = false
+				p.mutex.Unlock()
+				return
+			}
+
+			fn := p.callbacks[0]
+			p.callbacks = p.callbacks[1:]
+			p.mutex.Unlock()
+
+			(*fn)(result)
+		}
+	}()
+}
@@ -0,0 +1,121 @@
+package promises_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestOnSettle(t *testing.T) {
+	release := make(chan struct{})
+	p := blocked(release)
+
+	var wg sync.WaitGroup
+	results := make(chan SettledResult[int], 2)
+	wg.Add(2)
+	p.OnSettle(func(r SettledResult[int]) {
+		defer wg.Done()
+		results <- r
+	})
+
+	close(release)
+	<-p.Done()
+	p.OnSettle(func(r SettledResult[int]) {
+		defer wg.Done()
+		results <- r
+	})
+
+	wg.Wait()
+	close(results)
+	for r := range results {
+		if r.Status != Fulfilled || r.Value != 1 {
+			t.Errorf("expected fulfilled with 1, got %v", r)
+		}
+	}
+}
+
+func TestOnSettleOrdered(t *testing.T) {
+	release := make(chan struct{})
+	p := blocked(release, WithOrderedCallbacks())
+
+	var order []int
+	var wg sync.WaitGroup
+	register := func(i int) {
+		wg.Add(1)
+		p.OnSettle(func(SettledResult[int]) {
+			defer wg.Done()
+			order = append(order, i)
+		})
+	}
+
+	for i := 0; i < 50; i++ {
+		register(i)
+	}
+
+	close(release)
+	if _, err := p.Await(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 50; i < 100; i++ {
+		register(i)
+	}
+
+	wg.Wait()
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i
+	}
+
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("expected callbacks in registration order, got %v", order)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	p := blocked(release)
+
+	results := make(chan int, 3)
+	subscribe := func(i int) func() {
+		return p.Subscribe(ctx, func(r SettledResult[int]) {
+			results <- i
+		})
+	}
+
+	subscribe(1)
+	unsubscribe := subscribe(2)
+	canceled, cancel := context.WithCancel(ctx)
+	p.Subscribe(canceled, func(SettledResult[int]) {
+		results <- 3
+	})
+
+	unsubscribe()
+	cancel()
+	close(release)
+	<-p.Done()
+
+	late := make(chan SettledResult[int], 1)
+	p.Subscribe(ctx, func(r SettledResult[int]) {
+		late <- r
+	})
+
+	if r := <-late; r.Status != Fulfilled || r.Value != 1 {
+		t.Errorf("expected the late subscriber to get fulfilled with 1, got %v", r)
+	}
+
+	if i := <-results; i != 1 {
+		t.Errorf("expected only subscriber 1 to be called, got %d", i)
+	}
+
+	select {
+	case i := <-results:
+		t.Errorf("expected subscriber %d not to be called", i)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
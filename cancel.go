@@ -0,0 +1,178 @@
+package promises
+
+import (
+	"context"
+	"errors"
+)
+
+// CancelError is the reason a promise is rejected with when it is canceled with Cancel.
+// It matches context.Canceled with errors.Is, and unwraps to the cause, so handlers can tell why the work was abandoned.
+type CancelError struct {
+	Cause error
+}
+
+func (e *CancelError) Error() string {
+	if e.Cause == context.Canceled {
+		return "promise canceled"
+	}
+
+	return "promise canceled: " + e.Cause.Error()
+}
+
+func (e *CancelError) Is(target error) bool {
+	return target == context.Canceled
+}
+
+func (e *CancelError) Unwrap() error {
+	return e.Cause
+}
+
+// NewWithContext creates a promise like New whose executor is given a context derived from ctx.
+// The context is canceled once the promise settles, so canceling the promise with Cancel aborts the executor:
+// context.Cause of the context is then the cause the promise was canceled with.
+// The executor decides what to do when ctx itself is done, typically rejecting with ctx.Err().
+func NewWithContext[T any](ctx context.Context, executor func(ctx context.Context, resolve Resolve[T], reject Reject), opts ...Option) *Promise[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.cancelExecutor = cancel
+	})
+
+	return New(func(resolve Resolve[T], reject Reject) {
+		executor(ctx, resolve, reject)
+	}, opts...)
+}
+
+// Cancel rejects the promise with a *CancelError carrying cause, like the cancel function of context.WithCancelCause.
+// A nil cause is context.Canceled. It reports whether the promise was canceled, which it is not if it had already settled.
+// The executor is not interrupted unless the promise was created with NewWithContext; its later settlement is ignored.
+// If the promise was derived with Then, Catch, Finally or Timeout from a source created with WithConsumerCancel
+// and was the last derived promise of that source still pending, the source is canceled with the same cause,
+// and so on up the chain. Promises handed out to several callers, such as those of a Cache, Memoize, Publish,
+// Refreshable and WithIdempotencyKey, give each caller a handle of its own: canceling it cancels the promise
+// they share only once every other handle was canceled, too.
+func (p *Promise[T]) Cancel(cause error) bool {
+	p = p.target()
+
+	if cause == nil {
+		cause = context.Canceled
+	}
+
+	if !p.reject(p.opts, &CancelError{Cause: cause}, nil) {
+		return false
+	}
+
+	if p.upstream != nil {
+		p.upstream(cause)
+	}
+
+	return true
+}
+
+// WithConsumerCancel cancels the promise once every promise derived from it with Then, Catch, Finally or Timeout
+// was canceled, or timed out, while it was pending, so that canceling the end of a chain stops the work at its start.
+// Promises derived from it, and from those, cancel their source the same way. Only use it for a promise that is
+// consumed through its derived promises alone: it is canceled for callers that Await it directly, pass it to All
+// or Fork it, too.
+func WithConsumerCancel() Option {
+	return func(o *options) {
+		o.consumerCancel = true
+	}
+}
+
+// consume counts a derived promise as a consumer of p and returns the function it calls when it is canceled,
+// which cancels p once no consumer is left, or nil if p is not canceled by its consumers, see WithConsumerCancel,
+// or is already being canceled by them.
+func (p *Promise[T]) consume() (release func(cause error)) {
+	p = p.target()
+	if p.upstream == nil && !p.shared.Load() && (p.opts == nil || !p.opts.consumerCancel) {
+		return nil
+	}
+
+	for {
+		n := p.consumers.Load()
+		if n < 0 {
+			return nil
+		}
+
+		if p.consumers.CompareAndSwap(n, n+1) {
+			break
+		}
+	}
+
+	return func(cause error) {
+		for {
+			// The last consumer leaves -1 behind, so that no new consumer joins a promise being canceled.
+			n := p.consumers.Load()
+			next := n - 1
+			if next == 0 {
+				next = -1
+			}
+
+			if p.consumers.CompareAndSwap(n, next) {
+				if next < 0 {
+					p.Cancel(cause)
+				}
+
+				return
+			}
+		}
+	}
+}
+
+// released reports whether every consumer of p canceled, so that p is canceled or about to be.
+func (p *Promise[T]) released() bool {
+	return p.target().consumers.Load() < 0
+}
+
+// share marks p as handed out to several callers, such as the callers of a Cache, through handles
+// so that it is canceled once all of them canceled, whether or not it was created with WithConsumerCancel.
+func (p *Promise[T]) share() *Promise[T] {
+	p.target().shared.Store(true)
+	return p
+}
+
+// handle returns a promise that settles like the shared promise p for one of its callers,
+// so that canceling it does not cancel p for the others. A settled p cannot be canceled, so it is its own handle.
+func (p *Promise[T]) handle() *Promise[T] {
+	if p.IsSettled() {
+		return p
+	}
+
+	return derive(context.Background(), p, settleLike[T])
+}
+
+// settleLike settles a derived promise with the result of its source.
+func settleLike[T any](r SettledResult[T], resolve Resolve[T], reject Reject) {
+	if r.Status == Rejected {
+		reject(r.Reason)
+		return
+	}
+
+	resolve(r.Value)
+}
+
+// cancelCause is the cause the context of the executor is canceled with once the promise settles with reason.
+func cancelCause(reason error) error {
+	var cancelErr *CancelError
+	if errors.As(reason, &cancelErr) {
+		return cancelErr.Cause
+	}
+
+	return context.Canceled
+}
+
+// CancelCause returns the cause the promise was canceled with, or nil if it was not canceled.
+func (p *Promise[T]) CancelCause() error {
+	p = p.target()
+
+	p.mutex.RLock()
+	r := p.reason
+	p.mutex.RUnlock()
+
+	var cancelErr *CancelError
+	if errors.As(r, &cancelErr) {
+		return cancelErr.Cause
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestCancel(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	cause := errors.New("user navigated away")
+	p := blocked(block)
+	if !p.Cancel(cause) {
+		t.Fatal("expected the pending promise to be canceled")
+	}
+
+	if p.Cancel(errors.New("again")) {
+		t.Error("expected a settled promise not to be canceled again")
+	}
+
+	_, err := p.Await(ctx)
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, cause) {
+		t.Errorf("expected the reason to match context.Canceled and the cause, got %v", err)
+	}
+
+	var cancelErr *CancelError
+	if !errors.As(err, &cancelErr) || cancelErr.Cause != cause {
+		t.Errorf("expected a *CancelError with the cause, got %v", err)
+	}
+
+	if p.CancelCause() != cause {
+		t.Errorf("expected the cancel cause, got %v", p.CancelCause())
+	}
+}
+
+func TestCancelNilCause(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	p := blocked(block)
+	p.Cancel(nil)
+	if p.CancelCause() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", p.CancelCause())
+	}
+
+	if p.Reason().Error() != "promise canceled" {
+		t.Errorf("expected promise canceled, got %v", p.Reason())
+	}
+}
+
+func TestCancelSettled(t *testing.T) {
+	p := newResolved(1)
+	<-p.Done()
+	if p.Cancel(nil) {
+		t.Error("expected a fulfilled promise not to be canceled")
+	}
+
+	if p.CancelCause() != nil {
+		t.Errorf("expected no cancel cause, got %v", p.CancelCause())
+	}
+
+	rejected := New(func(resolve Resolve[int], reject Reject) {
+		reject(context.Canceled)
+	})
+	<-rejected.Done()
+	if rejected.CancelCause() != nil {
+		t.Errorf("expected no cancel cause for a plain rejection, got %v", rejected.CancelCause())
+	}
+}
+
+func TestNewWithContext(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("no longer needed")
+	aborted := make(chan error, 1)
+	p := NewWithContext(ctx, func(ctx context.Context, resolve Resolve[int], reject Reject) {
+		<-ctx.Done()
+		aborted <- context.Cause(ctx)
+	})
+
+	p.Cancel(cause)
+	select {
+	case err := <-aborted:
+		if err != cause {
+			t.Errorf("expected the executor context to be canceled with the cause, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the executor to be aborted")
+	}
+}
+
+func TestNewWithContextSettled(t *testing.T) {
+	ctx := context.Background()
+	var executorCtx context.Context
+	p := NewWithContext(ctx, func(ctx context.Context, resolve Resolve[int], reject Reject) {
+		executorCtx = ctx
+		resolve(1)
+	})
+
+	if v, err := p.Await(ctx); err != nil || v != 1 {
+		t.Fatalf("expected 1, got %v, %v", v, err)
+	}
+
+	if executorCtx.Err() == nil {
+		t.Error("expected the executor context to be released once the promise settled")
+	}
+}
+
+func TestCancelPropagatesUpstream(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("stop")
+	aborted := make(chan struct{})
+	source := NewWithContext(ctx, func(ctx context.Context, resolve Resolve[int], reject Reject) {
+		<-ctx.Done()
+		close(aborted)
+	}, WithConsumerCancel())
+
+	inc := func(v int) (int, error) { return v + 1, nil }
+	a := source.Then(ctx, inc)
+	b := source.Then(ctx, inc)
+	c := a.Then(ctx, inc)
+
+	c.Cancel(cause)
+	if a.CancelCause() != cause {
+		t.Errorf("expected the sole source of c to be canceled, got %v", a.CancelCause())
+	}
+
+	if source.IsSettled() {
+		t.Fatal("expected the source to keep running for b")
+	}
+
+	b.Cancel(cause)
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("expected the source to be aborted once it had no consumer left")
+	}
+
+	if source.CancelCause() != cause {
+		t.Errorf("expected the source to be canceled with the cause, got %v", source.CancelCause())
+	}
+}
+
+func TestCancelKeepsSourceByDefault(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	source := blocked(block)
+	fork := source.Fork()
+
+	source.Then(ctx, func(v int) (int, error) { return v, nil }).Cancel(nil)
+	if source.IsSettled() {
+		t.Fatal("expected a source created without WithConsumerCancel to keep running")
+	}
+
+	close(block)
+	if v, err := fork.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the fork to be fulfilled with 1, got %v, %v", v, err)
+	}
+}
+
+func TestCancelSharedOrphanable(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	key := WithIdempotencyKey(t.Name() + time.Now().String())
+	source := blocked(block, key, WithOrphanCancel(), WithConsumerCancel())
+	other := blocked(block, key, WithOrphanCancel(), WithConsumerCancel())
+
+	source.Then(ctx, func(v int) (int, error) { return v, nil }).Cancel(nil)
+	if !errors.Is(source.CancelCause(), context.Canceled) {
+		t.Errorf("expected the canceled consumer to cancel its own promise, got %v", source.CancelCause())
+	}
+
+	close(block)
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected a shared orphanable source to keep running for the others, got %v, %v", v, err)
+	}
+}
+
+func TestCancelSharedLastHolder(t *testing.T) {
+	ctx := context.Background()
+	started, block := make(chan struct{}), make(chan struct{})
+	defer close(block)
+	var fetches atomic.Int32
+	c := NewCache(func(ctx context.Context, key string) (int, error) {
+		if fetches.Add(1) == 1 {
+			close(started)
+			<-block
+		}
+
+		return 2, nil
+	}, CacheOptions[string]{})
+
+	a, b := c.Get("a"), c.Get("a")
+	<-started
+	a.Cancel(errors.New("caller a gave up"))
+	if b.IsSettled() {
+		t.Fatal("expected a canceled caller not to cancel the fetch for the others")
+	}
+
+	b.Cancel(nil)
+	if v, err := c.Get("a").Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected the fetch canceled by every caller to be fetched again, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,54 @@
+package promises
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrChannelClosed is the reason a promise created with FromChannel or FromResultChannel is rejected with
+	// when the channel is closed before a value is received.
+	ErrChannelClosed = errors.New("channel closed")
+)
+
+// FromChannel returns a promise fulfilled with the first value received from c.
+// It is rejected with ErrChannelClosed if c is closed first, or with the context error if ctx is done first.
+// The options configure the promise as in New.
+func FromChannel[T any](ctx context.Context, c <-chan T, opts ...Option) *Promise[T] {
+	return New(func(resolve Resolve[T], reject Reject) {
+		select {
+		case v, ok := <-c:
+			if !ok {
+				reject(ErrChannelClosed)
+				return
+			}
+
+			resolve(v)
+		case <-ctx.Done():
+			reject(ctx.Err())
+		}
+	}, opts...)
+}
+
+// FromResultChannel is FromChannel for channels of results: the promise settles with the first result received,
+// fulfilled with its value or rejected with its error.
+func FromResultChannel[T any](ctx context.Context, c <-chan Result[T], opts ...Option) *Promise[T] {
+	return New(func(resolve Resolve[T], reject Reject) {
+		select {
+		case r, ok := <-c:
+			if !ok {
+				reject(ErrChannelClosed)
+				return
+			}
+
+			settleWith(resolve, reject)(r.Unwrap())
+		case <-ctx.Done():
+			reject(ctx.Err())
+		}
+	}, opts...)
+}
+
+// ToChannel is ResultChan, so that the promise can be received from in a select loop.
+func (p *Promise[T]) ToChannel(ctx context.Context) <-chan Result[T] {
+	return p.ResultChan(ctx)
+}
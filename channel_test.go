@@ -0,0 +1,54 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestFromChannel(t *testing.T) {
+	ctx := context.Background()
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+	if v, err := FromChannel(ctx, c).Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the first value, got %v, %v", v, err)
+	}
+
+	close(c)
+	<-c
+	if _, err := FromChannel(ctx, c).Await(ctx); err != ErrChannelClosed {
+		t.Errorf("expected ErrChannelClosed, got %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := FromChannel(canceled, make(chan int)).Await(ctx); err != context.Canceled {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
+
+func TestFromResultChannel(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	c := make(chan Result[int], 2)
+	c <- Result[int]{Value: 1}
+	c <- Result[int]{Err: cause}
+	if v, err := FromResultChannel(ctx, c).Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	if _, err := FromResultChannel(ctx, c).Await(ctx); err != cause {
+		t.Errorf("expected the error of the result, got %v", err)
+	}
+}
+
+func TestToChannel(t *testing.T) {
+	ctx := context.Background()
+	r := <-newResolved(1).ToChannel(ctx)
+	if r.Value != 1 || r.Err != nil {
+		t.Errorf("expected 1, got %v, %v", r.Value, r.Err)
+	}
+}
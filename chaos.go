@@ -0,0 +1,92 @@
+package promises
+
+import (
+	"context"
+	"math/rand"
+	"path"
+	"time"
+)
+
+// Chaos injects random latency, rejections and cancellations into executor runs, for chaos testing services without changing them.
+// Enable it for every promise with Config.Chaos, or for a single promise with WithChaos.
+type Chaos struct {
+	// Rules are applied in order to every run. Each rule that matches the name of the promise
+	// is triggered independently with its probability.
+	Rules []ChaosRule
+
+	// Rand returns a pseudo-random number in [0, 1) and must be safe for concurrent use.
+	// If nil, math/rand.Float64 is used.
+	Rand func() float64
+}
+
+// ChaosRule describes a fault injected into the runs of matching promises.
+type ChaosRule struct {
+	// Name is a path.Match pattern matched against the names given with WithName. Empty matches every promise.
+	Name string
+
+	// Probability is the chance, from 0 to 1, that the rule is triggered for a run.
+	Probability float64
+
+	// Latency is the maximum delay added before the executor runs, waited on the package clock.
+	// The actual delay is chosen uniformly between zero and Latency.
+	Latency time.Duration
+
+	// Reject, if set, rejects the promise with it instead of running the executor.
+	Reject error
+
+	// Cancel rejects the promise with context.Canceled instead of running the executor.
+	Cancel bool
+}
+
+// WithChaos injects the faults of c into the executor run of the promise, overriding Config.Chaos. nil disables injection.
+func WithChaos(c *Chaos) Option {
+	return func(o *options) {
+		o.chaos = c
+	}
+}
+
+func (r *ChaosRule) matches(name string) bool {
+	if r.Name == "" {
+		return true
+	}
+
+	ok, _ := path.Match(r.Name, name)
+	return ok
+}
+
+func (c *Chaos) float64() float64 {
+	if c.Rand != nil {
+		return c.Rand()
+	}
+
+	return rand.Float64()
+}
+
+// wrap returns run with the faults of c injected for the promise named name.
+func (c *Chaos) wrap(name string, reject Reject, run func()) func() {
+	return func() {
+		for i := range c.Rules {
+			rule := &c.Rules[i]
+			if !rule.matches(name) || c.float64() >= rule.Probability {
+				continue
+			}
+
+			if rule.Latency > 0 {
+				d := time.Duration(c.float64() * float64(rule.Latency))
+				<-CurrentClock().NewTimer(d).C()
+			}
+
+			if rule.Reject != nil {
+				reject(rule.Reject)
+				return
+			}
+
+			if rule.Cancel {
+				reject(context.Canceled)
+				return
+			}
+		}
+
+		run()
+	}
+}
@@ -0,0 +1,61 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func resolveOne(resolve Resolve[int], reject Reject) {
+	resolve(1)
+}
+
+func TestChaosReject(t *testing.T) {
+	ctx := context.Background()
+	injected := errors.New("injected")
+	restore := Configure(Config{Chaos: &Chaos{Rules: []ChaosRule{
+		{Name: "db.*", Probability: 1, Reject: injected},
+		{Name: "cache.*", Probability: 1, Cancel: true},
+		{Name: "never", Probability: 0, Reject: injected},
+	}}})
+	defer restore()
+
+	if _, err := New(resolveOne, WithName("db.query")).Await(ctx); !errors.Is(err, injected) {
+		t.Errorf("expected the injected error, got %v", err)
+	}
+
+	if _, err := New(resolveOne, WithName("cache.get")).Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	for _, name := range []string{"never", "http.get"} {
+		if v, err := New(resolveOne, WithName(name)).Await(ctx); err != nil || v != 1 {
+			t.Errorf("expected %s to run unaffected, got %v, %v", name, v, err)
+		}
+	}
+
+	if v, err := New(resolveOne, WithName("db.query"), WithChaos(nil)).Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected WithChaos(nil) to disable injection, got %v, %v", v, err)
+	}
+}
+
+func TestChaosLatency(t *testing.T) {
+	chaos := &Chaos{
+		Rules: []ChaosRule{{Probability: 1, Latency: 40 * time.Millisecond}},
+		Rand: func() float64 {
+			return 0.5
+		},
+	}
+
+	start := time.Now()
+	if _, err := New(resolveOne, WithChaos(chaos)).Await(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected at least 20ms of latency, got %v", elapsed)
+	}
+}
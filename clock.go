@@ -0,0 +1,84 @@
+package promises
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time for the timing features of the package.
+// Replace it with SetClock to control time in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It reports false if the timer has already fired or been stopped.
+	Stop() bool
+}
+
+type realClock struct{}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+var clock atomic.Pointer[Clock]
+
+func init() {
+	SetClock(RealClock())
+}
+
+// RealClock returns the Clock backed by the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+// SetClock replaces the clock used by the package and returns a function that restores the previous one.
+func SetClock(c Clock) (restore func()) {
+	previous := clock.Swap(&c)
+	return func() {
+		clock.Store(previous)
+	}
+}
+
+// CurrentClock returns the clock used by the package.
+func CurrentClock() Clock {
+	return *clock.Load()
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Delay returns a promise that is fulfilled after the duration has elapsed on the package clock.
+// Canceling the promise stops the timer.
+func Delay(d time.Duration, opts ...Option) *Promise[struct{}] {
+	timer := CurrentClock().NewTimer(d)
+
+	return NewWithContext(context.Background(), func(ctx context.Context, resolve Resolve[struct{}], reject Reject) {
+		select {
+		case <-timer.C():
+			resolve(struct{}{})
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}, opts...)
+}
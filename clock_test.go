@@ -0,0 +1,44 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestDelay(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+
+	if _, err := Delay(10 * time.Millisecond).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("expected at least 10ms to elapse, got %v", elapsed)
+	}
+}
+
+type instantClock struct{}
+
+func (instantClock) Now() time.Time { return time.Time{} }
+
+func (instantClock) NewTimer(d time.Duration) Timer {
+	return RealClock().NewTimer(0)
+}
+
+func TestSetClock(t *testing.T) {
+	ctx := context.Background()
+	restore := SetClock(instantClock{})
+
+	if _, err := Delay(time.Hour).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	restore()
+	if _, ok := CurrentClock().(instantClock); ok {
+		t.Errorf("expected clock to be restored")
+	}
+}
@@ -0,0 +1,73 @@
+package promises
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Config holds the defaults applied to every promise created with options support, such as New.
+// Options given to a constructor override the configured defaults.
+type Config struct {
+	// PanicHandler is called with the recovered panic when an executor panics.
+	PanicHandler func(*PanicError)
+
+	// FailFast lets panics in executors and in Then, Catch and Finally callbacks crash the process,
+	// as if every promise were created with WithFailFast.
+	FailFast bool
+
+	// Logger is used as if every promise were created with WithLogger.
+	Logger *slog.Logger
+
+	// DefaultTimeout is used as if every promise were created with WithTimeout. Zero means no timeout.
+	DefaultTimeout time.Duration
+
+	// Scheduler is used as if every promise were created with WithScheduler.
+	Scheduler Scheduler
+
+	// TrackInFlight lists every promise in InFlight as if created with WithTracking.
+	TrackInFlight bool
+
+	// PendingLimit, if set, caps the number of promises pending at the same time.
+	PendingLimit *PendingLimit
+
+	// Chaos is used as if every promise were created with WithChaos.
+	Chaos *Chaos
+
+	// Record is used as if every promise were created with WithRecord.
+	Record *Recording
+
+	// Replay is used as if every promise were created with WithReplay.
+	Replay *Recording
+
+	// IdempotencyStore saves the outcomes of promises created with WithIdempotencyKey. If nil, they are kept in memory.
+	IdempotencyStore IdempotencyStore
+
+	// IdempotencyWindow is how long the outcomes of promises created with WithIdempotencyKey are saved. Zero means forever.
+	IdempotencyWindow time.Duration
+}
+
+var config atomic.Pointer[Config]
+
+// Configure applies c to promises created from now on and returns a function that restores the previous configuration.
+func Configure(c Config) (restore func()) {
+	previous := config.Swap(&c)
+	return func() {
+		config.Store(previous)
+	}
+}
+
+func (c *Config) apply(o *options) {
+	o.logger = c.Logger
+	o.timeout = c.DefaultTimeout
+	o.scheduler = c.Scheduler
+	o.tracking = c.TrackInFlight
+	o.limit = c.PendingLimit
+	o.chaos = c.Chaos
+	o.record = c.Record
+	o.replay = c.Replay
+	o.idempotencyStore = c.IdempotencyStore
+	o.idempotencyWindow = c.IdempotencyWindow
+	o.panicHandler = c.PanicHandler
+	o.failFast = c.FailFast
+}
@@ -0,0 +1,66 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestConfigure(t *testing.T) {
+	ctx := context.Background()
+	var handled *PanicError
+	restore := Configure(Config{
+		PanicHandler: func(err *PanicError) {
+			handled = err
+		},
+		DefaultTimeout: 10 * time.Millisecond,
+		TrackInFlight:  true,
+	})
+	defer restore()
+
+	panicked := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	})
+
+	var panicErr *PanicError
+	if _, err := panicked.Await(ctx); !errors.As(err, &panicErr) {
+		t.Errorf("expected *PanicError, got %v", err)
+	}
+
+	if handled != panicErr {
+		t.Errorf("expected the panic handler to be called with %v, got %v", panicErr, handled)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	pending := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+	}, WithName("pending"))
+
+	if infos := InFlight(); len(infos) != 1 || infos[0].Name != "pending" {
+		t.Errorf("expected the pending promise in flight, got %v", infos)
+	}
+
+	if _, err := pending.Await(ctx); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestConfigureOverriddenByOptions(t *testing.T) {
+	ctx := context.Background()
+	restore := Configure(Config{DefaultTimeout: time.Millisecond})
+	defer restore()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		time.Sleep(20 * time.Millisecond)
+		resolve(1)
+	}, WithTimeout(0))
+
+	v, err := p.Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
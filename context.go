@@ -0,0 +1,92 @@
+package promises
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type mergedContext struct {
+	context.Context
+	parents []context.Context
+	mutex   sync.Mutex
+	err     error
+}
+
+// MergeContexts returns a context that is done as soon as any of the parents is done.
+// Its Err and context.Cause report the error and cause of the first parent to be done.
+// Its deadline is the earliest deadline of the parents and values are looked up in the parents in order.
+// Calling the returned cancel function releases the resources associated with the merged context.
+func MergeContexts(ctxs ...context.Context) (context.Context, context.CancelFunc) {
+	if len(ctxs) == 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	inner, cancel := context.WithCancelCause(ctxs[0])
+	m := &mergedContext{Context: inner, parents: ctxs}
+
+	stops := make([]func() bool, 0, len(ctxs)-1)
+	for _, parent := range ctxs[1:] {
+		parent := parent
+		stops = append(stops, context.AfterFunc(parent, func() {
+			m.mutex.Lock()
+			if m.err == nil && inner.Err() == nil {
+				m.err = parent.Err()
+			}
+			m.mutex.Unlock()
+
+			cancel(context.Cause(parent))
+		}))
+	}
+
+	return m, func() {
+		for _, stop := range stops {
+			stop()
+		}
+
+		cancel(context.Canceled)
+	}
+}
+
+func (m *mergedContext) Deadline() (time.Time, bool) {
+	var deadline time.Time
+	var ok bool
+	for _, parent := range m.parents {
+		d, has := parent.Deadline()
+		if has && (!ok || d.Before(deadline)) {
+			deadline, ok = d, true
+		}
+	}
+
+	return deadline, ok
+}
+
+func (m *mergedContext) Err() error {
+	err := m.Context.Err()
+	if err == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	return err
+}
+
+func (m *mergedContext) Value(key any) any {
+	if v := m.Context.Value(key); v != nil {
+		return v
+	}
+
+	for _, parent := range m.parents[1:] {
+		if v := parent.Value(key); v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type contextKey string
+
+func TestMergeContexts(t *testing.T) {
+	request, cancelRequest := context.WithCancel(context.Background())
+	defer cancelRequest()
+	shutdown, cancelShutdown := context.WithCancelCause(context.Background())
+
+	ctx, cancel := MergeContexts(request, shutdown)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		t.Errorf("expected error to be nil, got %v", ctx.Err())
+	}
+
+	cause := errors.New("shutting down")
+	cancelShutdown(cause)
+	<-ctx.Done()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", ctx.Err())
+	}
+
+	if context.Cause(ctx) != cause {
+		t.Errorf("expected cause to be %v, got %v", cause, context.Cause(ctx))
+	}
+}
+
+func TestMergeContextsDeadline(t *testing.T) {
+	first, cancelFirst := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelFirst()
+	second, cancelSecond := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelSecond()
+
+	ctx, cancel := MergeContexts(first, second)
+	defer cancel()
+
+	d, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("expected deadline to be set")
+	}
+
+	if want, _ := second.Deadline(); !d.Equal(want) {
+		t.Errorf("expected deadline to be %v, got %v", want, d)
+	}
+
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected error to be context.DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+func TestMergeContextsValue(t *testing.T) {
+	first := context.WithValue(context.Background(), contextKey("a"), 1)
+	second := context.WithValue(context.Background(), contextKey("b"), 2)
+
+	ctx, cancel := MergeContexts(first, second)
+	defer cancel()
+
+	if ctx.Value(contextKey("a")) != 1 {
+		t.Errorf("expected value a to be 1, got %v", ctx.Value(contextKey("a")))
+	}
+
+	if ctx.Value(contextKey("b")) != 2 {
+		t.Errorf("expected value b to be 2, got %v", ctx.Value(contextKey("b")))
+	}
+}
+
+func TestMergeContextsCancel(t *testing.T) {
+	ctx, cancel := MergeContexts(context.Background(), context.Background())
+	cancel()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", ctx.Err())
+	}
+}
+
+func TestMergeContextsAwait(t *testing.T) {
+	request := context.Background()
+	shutdown, cancelShutdown := context.WithCancel(context.Background())
+
+	ctx, cancel := MergeContexts(request, shutdown)
+	defer cancel()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		time.Sleep(time.Second)
+		resolve(1)
+	})
+
+	cancelShutdown()
+	_, err := p.Await(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrCast is the reason a promise returned by Cast is rejected with when the value is not of the requested type.
+	ErrCast = errors.New("invalid cast")
+)
+
+// ToAny returns a promise that settles with the same outcome as p, with the value as any.
+// It allows promises of different types to be stored together.
+func ToAny[T any](p *Promise[T]) *Promise[any] {
+	converted := New(func(resolve Resolve[any], reject Reject) {
+		v, err := p.Await(context.Background())
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	})
+
+	link(converted.info, p.info)
+	return converted
+}
+
+// Cast returns a promise that settles with the same outcome as p, with the value asserted to U.
+// If the value is not a U, the promise is rejected with an error wrapping ErrCast.
+func Cast[U any](p *Promise[any]) *Promise[U] {
+	converted := New(func(resolve Resolve[U], reject Reject) {
+		v, err := p.Await(context.Background())
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		u, ok := v.(U)
+		if !ok {
+			reject(fmt.Errorf("%w: %T is not %v", ErrCast, v, reflect.TypeOf((*U)(nil)).Elem()))
+			return
+		}
+
+		resolve(u)
+	})
+
+	link(converted.info, p.info)
+	return converted
+}
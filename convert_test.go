@@ -0,0 +1,50 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestToAnyAndCast(t *testing.T) {
+	ctx := context.Background()
+	promises := []*Promise[any]{
+		ToAny(newResolved(1)),
+		ToAny(New(func(resolve Resolve[string], reject Reject) {
+			resolve("one")
+		})),
+	}
+
+	n, err := Cast[int](promises[0]).Await(ctx)
+	if err != nil || n != 1 {
+		t.Errorf("expected 1, got %v, %v", n, err)
+	}
+
+	s, err := Cast[string](promises[1]).Await(ctx)
+	if err != nil || s != "one" {
+		t.Errorf("expected one, got %v, %v", s, err)
+	}
+}
+
+func TestCastMismatch(t *testing.T) {
+	ctx := context.Background()
+	_, err := Cast[string](ToAny(newResolved(1))).Await(ctx)
+	if !errors.Is(err, ErrCast) {
+		t.Errorf("expected ErrCast, got %v", err)
+	}
+}
+
+func TestToAnyRejected(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(reason)
+	})
+
+	_, err := Cast[int](ToAny(p)).Await(ctx)
+	if !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package promises
+
+import "sync"
+
+// Counter is a promise-backed WaitGroup. Its promise is fulfilled once the count reaches zero
+// and rejected as soon as Fail is called, so waiting for N independent completions takes one promise instead of N.
+type Counter struct {
+	promise *Promise[struct{}]
+	resolve Resolve[struct{}]
+	reject  Reject
+	count   int
+	mutex   sync.Mutex
+}
+
+// NewCounter creates a counter that expects n completions. If n is zero, its promise is already fulfilled.
+func NewCounter(n int) *Counter {
+	if n < 0 {
+		panic("promises: negative counter")
+	}
+
+	p, resolve, reject := newPending[struct{}](nil)
+	c := &Counter{promise: p, resolve: resolve, reject: reject, count: n}
+	if n == 0 {
+		resolve(struct{}{})
+	}
+
+	return c
+}
+
+// Add adds delta, which may be negative, to the count. Like sync.WaitGroup.Add, it panics if the count becomes negative.
+// Adding after the promise is settled has no effect on it.
+func (c *Counter) Add(delta int) {
+	c.mutex.Lock()
+	c.count += delta
+	count := c.count
+	c.mutex.Unlock()
+
+	if count < 0 {
+		panic("promises: negative counter")
+	}
+
+	if count == 0 {
+		c.resolve(struct{}{})
+	}
+}
+
+// Done decrements the count by one.
+func (c *Counter) Done() {
+	c.Add(-1)
+}
+
+// Fail rejects the promise with the reason, regardless of the count.
+func (c *Counter) Fail(reason error) {
+	c.reject(reason)
+}
+
+// Promise returns the promise of the counter.
+func (c *Counter) Promise() *Promise[struct{}] {
+	return c.promise
+}
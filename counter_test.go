@@ -0,0 +1,56 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestCounter(t *testing.T) {
+	ctx := context.Background()
+	c := NewCounter(2)
+	c.Add(1)
+
+	for i := 0; i < 3; i++ {
+		if c.Promise().IsSettled() {
+			t.Fatalf("expected the promise to be pending after %d completions", i)
+		}
+
+		go c.Done()
+	}
+
+	if _, err := c.Promise().Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+}
+
+func TestCounterZero(t *testing.T) {
+	if !NewCounter(0).Promise().IsFulfilled() {
+		t.Error("expected the promise to be fulfilled")
+	}
+}
+
+func TestCounterFail(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	c := NewCounter(2)
+	c.Done()
+	c.Fail(reason)
+	c.Done()
+
+	if _, err := c.Promise().Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
+
+func TestCounterNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	NewCounter(1).Add(-2)
+}
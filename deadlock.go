@@ -0,0 +1,110 @@
+package promises
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrDeadlock is wrapped by the error Await returns when awaiting would never return.
+	ErrDeadlock = errors.New("deadlock")
+)
+
+// DeadlockError describes a cycle of promises whose executors await each other.
+// Cycle starts with the promise whose executor called Await; each promise awaits the next,
+// and the last awaits the first. A promise awaiting itself is a cycle of one.
+type DeadlockError struct {
+	Cycle []Info
+}
+
+func (e *DeadlockError) Error() string {
+	names := make([]string, 0, len(e.Cycle)+1)
+	for _, info := range append(e.Cycle, e.Cycle[0]) {
+		if info.Name != "" {
+			names = append(names, fmt.Sprintf("%d (%s)", info.ID, info.Name))
+		} else {
+			names = append(names, strconv.FormatUint(info.ID, 10))
+		}
+	}
+
+	return "deadlock: promise " + strings.Join(names, " awaits ")
+}
+
+func (e *DeadlockError) Is(target error) bool {
+	return target == ErrDeadlock
+}
+
+// waits records which tracked executors are running on which goroutines and what they await,
+// so that Await can detect cycles before blocking. It is only consulted while a tracked executor runs.
+var waits = struct {
+	executors map[uint64]Info
+	waitsFor  map[uint64]Info
+	mutex     sync.Mutex
+	running   atomic.Int64
+}{executors: make(map[uint64]Info), waitsFor: make(map[uint64]Info)}
+
+// trackExecutor wraps run so that the goroutine it runs on is known to belong to the promise.
+func trackExecutor(info Info, run func()) func() {
+	return func() {
+		gid := goroutineID()
+
+		waits.mutex.Lock()
+		waits.executors[gid] = info
+		waits.mutex.Unlock()
+		waits.running.Add(1)
+
+		defer func() {
+			waits.running.Add(-1)
+			waits.mutex.Lock()
+			delete(waits.executors, gid)
+			waits.mutex.Unlock()
+		}()
+
+		run()
+	}
+}
+
+// beginWait records that the executor running on the current goroutine, if any, awaits target.
+// It returns a function that ends the wait, or a *DeadlockError if the wait closes a cycle.
+func beginWait(target Info) (end func(), err error) {
+	gid := goroutineID()
+
+	waits.mutex.Lock()
+	defer waits.mutex.Unlock()
+
+	waiter, ok := waits.executors[gid]
+	if !ok {
+		return func() {}, nil
+	}
+
+	cycle := []Info{waiter}
+	for next := target; next.ID != waiter.ID; {
+		cycle = append(cycle, next)
+		if next, ok = waits.waitsFor[next.ID]; !ok {
+			waits.waitsFor[waiter.ID] = target
+			return func() {
+				waits.mutex.Lock()
+				delete(waits.waitsFor, waiter.ID)
+				waits.mutex.Unlock()
+			}, nil
+		}
+	}
+
+	return nil, &DeadlockError{Cycle: cycle}
+}
+
+// goroutineID returns the ID of the current goroutine from its stack header, "goroutine 123 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b = b[:bytes.IndexByte(b, ' ')]
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,82 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAwaitSelfDeadlock(t *testing.T) {
+	self := make(chan *Promise[int], 1)
+	p := New(func(resolve Resolve[int], reject Reject) {
+		_, err := (<-self).Await(context.Background())
+		reject(err)
+	}, WithName("self"), WithTracking())
+	self <- p
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := p.Await(ctx)
+	if !errors.Is(err, ErrDeadlock) {
+		t.Fatalf("expected ErrDeadlock, got %v", err)
+	}
+
+	var deadlock *DeadlockError
+	if !errors.As(err, &deadlock) || len(deadlock.Cycle) != 1 || deadlock.Cycle[0].Name != "self" {
+		t.Fatalf("expected a cycle of self, got %v", err)
+	}
+}
+
+func TestAwaitCircularDeadlock(t *testing.T) {
+	pa := make(chan *Promise[int], 1)
+	pb := make(chan *Promise[int], 1)
+	started := make(chan struct{})
+
+	a := New(func(resolve Resolve[int], reject Reject) {
+		b := <-pb
+		<-started
+		_, err := b.Await(context.Background())
+		reject(err)
+	}, WithName("a"), WithTracking())
+	b := New(func(resolve Resolve[int], reject Reject) {
+		a := <-pa
+		close(started)
+		_, err := a.Await(context.Background())
+		reject(err)
+	}, WithName("b"), WithTracking())
+	pa <- a
+	pb <- b
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, errA := a.Await(ctx)
+	_, errB := b.Await(ctx)
+	if !errors.Is(errA, ErrDeadlock) && !errors.Is(errB, ErrDeadlock) {
+		t.Fatalf("expected ErrDeadlock, got %v and %v", errA, errB)
+	}
+}
+
+func TestAwaitWithoutDeadlock(t *testing.T) {
+	inner := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithTracking())
+	outer := New(func(resolve Resolve[int], reject Reject) {
+		v, err := inner.Await(context.Background())
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v + 1)
+	}, WithTracking())
+
+	v, err := outer.Await(context.Background())
+	if err != nil || v != 2 {
+		t.Fatalf("expected 2, got %v, %v", v, err)
+	}
+}
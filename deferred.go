@@ -0,0 +1,108 @@
+package promises
+
+// Deferred is a promise settled from outside, for when the settling side lives in a different component
+// than the creation site. It is safe to pass around and to settle from any goroutine; only the first settlement counts.
+type Deferred[T any] struct {
+	promise *Promise[T]
+	o       *options
+}
+
+// NewDeferred creates a pending deferred promise. The options apply as with New, except those about the executor.
+func NewDeferred[T any](opts ...Option) *Deferred[T] {
+	o := newOptions(opts)
+	p, _, _ := newPending[T](o)
+	return &Deferred[T]{promise: p, o: o}
+}
+
+// WithResolvers creates a pending promise and returns it with the functions that settle it, like the Resolve and Reject
+// methods of a Deferred. The options apply as with New, except those about the executor.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/withResolvers
+func WithResolvers[T any](opts ...Option) (*Promise[T], Resolve[T], Reject) {
+	d := NewDeferred[T](opts...)
+	return d.promise, d.Resolve, d.Reject
+}
+
+// Promise returns the promise settled by d.
+func (d *Deferred[T]) Promise() *Promise[T] {
+	return d.promise
+}
+
+// Resolve fulfills the promise with value.
+func (d *Deferred[T]) Resolve(value T) {
+	d.promise.fulfill(d.o, value, nil)
+}
+
+// Reject rejects the promise with reason.
+func (d *Deferred[T]) Reject(reason error) {
+	d.promise.reject(d.o, reason, nil)
+}
+
+// callbackBatch collects the callbacks of promises settled together, so that they are scheduled on one goroutine.
+type callbackBatch struct {
+	callbacks []func()
+}
+
+// batchCallbacks adds the callbacks of the settled promise to batch.
+// Callbacks of promises created with WithOrderedCallbacks or WithEventLoop keep their own dispatch.
+func batchCallbacks[T any](p *Promise[T], batch *callbackBatch) {
+	if p.orderedCallbacks || (p.opts != nil && p.opts.loop != nil) {
+		p.dispatch()
+		return
+	}
+
+	p.mutex.Lock()
+	callbacks := p.callbacks
+	p.callbacks = nil
+	p.mutex.Unlock()
+
+	if len(callbacks) == 0 {
+		return
+	}
+
+	result, _ := p.Snapshot()
+	for _, fn := range callbacks {
+		batch.callbacks = append(batch.callbacks, func() {
+			(*fn)(result)
+		})
+	}
+}
+
+func (b *callbackBatch) run() {
+	if len(b.callbacks) == 0 {
+		return
+	}
+
+	go func() {
+		for _, fn := range b.callbacks {
+			fn()
+		}
+	}()
+}
+
+// SettleAll fulfills every deferred promise with the value at the same index in one pass.
+// Instead of one goroutine per callback, the OnSettle callbacks of all the promises run one after another
+// on a single goroutine, which amortizes the settlement of many promises at once.
+// It panics if ds and values differ in length.
+func SettleAll[T any](ds []*Deferred[T], values []T) {
+	if len(ds) != len(values) {
+		panic("promises: SettleAll with different numbers of promises and values")
+	}
+
+	batch := &callbackBatch{}
+	for i, d := range ds {
+		d.promise.fulfill(d.o, values[i], batch)
+	}
+
+	batch.run()
+}
+
+// RejectAll rejects every deferred promise with reason in one pass, scheduling callbacks like SettleAll.
+func RejectAll[T any](ds []*Deferred[T], reason error) {
+	batch := &callbackBatch{}
+	for _, d := range ds {
+		d.promise.reject(d.o, reason, batch)
+	}
+
+	batch.run()
+}
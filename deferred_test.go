@@ -0,0 +1,80 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestDeferred(t *testing.T) {
+	ctx := context.Background()
+	d := NewDeferred[int](WithName("deferred"))
+	go d.Resolve(1)
+
+	if v, err := d.Promise().Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	d.Reject(errors.New("late"))
+	if err := d.Promise().Err(); err != nil {
+		t.Errorf("expected the first settlement to count, got %v", err)
+	}
+}
+
+func TestWithResolvers(t *testing.T) {
+	ctx := context.Background()
+	pending := map[int]Reject{}
+	p, resolve, reject := WithResolvers[string]()
+	pending[1] = reject
+
+	cause := errors.New("no response")
+	go pending[1](cause)
+	if _, err := p.Await(ctx); err != cause {
+		t.Errorf("expected the reason, got %v", err)
+	}
+
+	resolve("late")
+	if v := p.Value(); v != "" {
+		t.Errorf("expected the first settlement to count, got %q", v)
+	}
+}
+
+func TestSettleAll(t *testing.T) {
+	ctx := context.Background()
+	ds := make([]*Deferred[int], 100)
+	values := make([]int, len(ds))
+	var wg sync.WaitGroup
+	for i := range ds {
+		ds[i] = NewDeferred[int]()
+		values[i] = i
+		wg.Add(1)
+		ds[i].Promise().OnSettle(func(SettledResult[int]) {
+			wg.Done()
+		})
+	}
+
+	SettleAll(ds, values)
+	wg.Wait()
+
+	for i, d := range ds {
+		if v, err := d.Promise().Await(ctx); err != nil || v != i {
+			t.Errorf("expected %d, got %v, %v", i, v, err)
+		}
+	}
+}
+
+func TestRejectAll(t *testing.T) {
+	ctx := context.Background()
+	ds := []*Deferred[int]{NewDeferred[int](), NewDeferred[int]()}
+	reason := errors.New("reason")
+	RejectAll(ds, reason)
+
+	for _, d := range ds {
+		if _, err := d.Promise().Await(ctx); !errors.Is(err, reason) {
+			t.Errorf("expected reason, got %v", err)
+		}
+	}
+}
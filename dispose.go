@@ -0,0 +1,53 @@
+package promises
+
+// WithDisposer sets a function that releases a value the promise is fulfilled with but nobody will consume,
+// such as a connection or a file handle. It is called when the executor resolves after the promise was already
+// settled, because it was canceled or timed out, and for the values of the promises that lose a Race or Any.
+// The type of fn must match the type of the promise; New panics otherwise.
+func WithDisposer[T any](fn func(T)) Option {
+	return func(o *options) {
+		o.disposer = fn
+	}
+}
+
+// checkDisposer panics if the disposer in o does not take values of the promise type.
+func checkDisposer[T any](o *options) {
+	if o == nil || o.disposer == nil {
+		return
+	}
+
+	if _, ok := o.disposer.(func(T)); !ok {
+		panic("promises: WithDisposer type does not match the promise type")
+	}
+}
+
+// disposer returns the disposer of the promise, or nil if it has none.
+func (p *Promise[T]) disposer() func(T) {
+	if p.opts == nil || p.opts.disposer == nil {
+		return nil
+	}
+
+	return p.opts.disposer.(func(T))
+}
+
+// dispose releases the value with the disposer of the promise, if it has one.
+func (p *Promise[T]) dispose(v T) {
+	if d := p.disposer(); d != nil {
+		d(v)
+	}
+}
+
+// disposeLater releases the value of the promise once it is fulfilled, for a promise whose value will not be consumed.
+func (p *Promise[T]) disposeLater() {
+	d := p.disposer()
+	if d == nil {
+		return
+	}
+
+	go func() {
+		<-p.doneChan()
+		if v, ok := p.GetNow(); ok {
+			d(v)
+		}
+	}()
+}
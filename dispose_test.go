@@ -0,0 +1,119 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type conn struct {
+	id int
+}
+
+func TestDisposerAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+	disposed := make(chan *conn, 1)
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[*conn], reject Reject) {
+		<-block
+		resolve(&conn{id: 1})
+	}, WithTimeout(time.Millisecond), WithDisposer(func(c *conn) {
+		disposed <- c
+	}))
+
+	if _, err := p.Await(ctx); err == nil {
+		t.Fatal("expected the promise to time out")
+	}
+
+	close(block)
+	if c := <-disposed; c.id != 1 {
+		t.Errorf("expected the late connection to be disposed, got %v", c.id)
+	}
+}
+
+func TestDisposerAfterCancel(t *testing.T) {
+	disposed := make(chan *conn, 1)
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[*conn], reject Reject) {
+		<-block
+		resolve(&conn{id: 2})
+	}, WithDisposer(func(c *conn) {
+		disposed <- c
+	}))
+
+	p.Cancel(nil)
+	close(block)
+	if c := <-disposed; c.id != 2 {
+		t.Errorf("expected the late connection to be disposed, got %v", c.id)
+	}
+}
+
+func TestDisposerNotCalledWhenConsumed(t *testing.T) {
+	ctx := context.Background()
+	disposed := false
+	p := New(func(resolve Resolve[*conn], reject Reject) {
+		resolve(&conn{id: 3})
+	}, WithDisposer(func(c *conn) {
+		disposed = true
+	}))
+
+	if c, err := p.Await(ctx); err != nil || c.id != 3 {
+		t.Fatalf("expected connection 3, got %v, %v", c, err)
+	}
+
+	if disposed {
+		t.Error("expected a consumed value not to be disposed")
+	}
+}
+
+func TestDisposerRaceLosers(t *testing.T) {
+	ctx := context.Background()
+	disposed := make(chan *conn, 2)
+	dispose := WithDisposer(func(c *conn) {
+		disposed <- c
+	})
+
+	block := make(chan struct{})
+	dial := func(id int, wait chan struct{}) *Promise[*conn] {
+		return New(func(resolve Resolve[*conn], reject Reject) {
+			if wait != nil {
+				<-wait
+			}
+
+			resolve(&conn{id: id})
+		}, dispose)
+	}
+
+	winner := dial(1, nil)
+	<-winner.Done()
+	loser := dial(2, block)
+	c, err := Race(ctx, winner, loser).Await(ctx)
+	if err != nil || c.id != 1 {
+		t.Fatalf("expected connection 1 to win, got %v, %v", c, err)
+	}
+
+	close(block)
+	if c := <-disposed; c.id != 2 {
+		t.Errorf("expected the losing connection to be disposed, got %v", c.id)
+	}
+
+	select {
+	case c := <-disposed:
+		t.Errorf("expected only the loser to be disposed, got %v", c.id)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestDisposerTypeMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a mismatched disposer to panic")
+		}
+	}()
+
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithDisposer(func(s string) {}))
+}
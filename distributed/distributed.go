@@ -0,0 +1,187 @@
+// Package distributed provides keyed promises that are settled in one process and awaited in others.
+//
+// Settlements travel over a Transport, which adapts a messaging system such as Redis pub/sub or NATS.
+// Values are serialized with a Codec, which defaults to JSON.
+package distributed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Transport delivers settlement messages between processes.
+type Transport interface {
+	// Publish delivers the message to the current subscribers of key.
+	Publish(ctx context.Context, key string, message []byte) error
+
+	// Subscribe returns a channel of messages published to key and a function that ends the subscription.
+	Subscribe(ctx context.Context, key string) (<-chan []byte, func(), error)
+}
+
+// Retainer is implemented by transports that keep the last message published to a key,
+// so that subscribers arriving after the settlement still observe it.
+type Retainer interface {
+	Retained(ctx context.Context, key string) ([]byte, bool, error)
+}
+
+// Codec serializes values of type T.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(b []byte) (T, error)
+}
+
+// JSONCodec is the default Codec.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec[T]) Decode(b []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+// Options configures how a keyed promise is settled or awaited.
+type Options[T any] struct {
+	// Codec serializes the value. If nil, JSONCodec is used.
+	Codec Codec[T]
+
+	// Timeout bounds how long Remote waits for the settlement. Zero means no timeout.
+	Timeout time.Duration
+}
+
+type message struct {
+	Rejected bool   `json:"rejected,omitempty"`
+	Value    []byte `json:"value,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Resolve publishes the fulfillment of the keyed promise with value.
+func Resolve[T any](ctx context.Context, t Transport, key string, value T, opts Options[T]) error {
+	b, err := opts.codec().Encode(value)
+	if err != nil {
+		return err
+	}
+
+	return publish(ctx, t, key, message{Value: b})
+}
+
+// Reject publishes the rejection of the keyed promise with reason.
+// Remote promises are rejected with an error that has the same message.
+func Reject(ctx context.Context, t Transport, key string, reason error) error {
+	if reason == nil {
+		reason = errors.New("nil reason")
+	}
+
+	return publish(ctx, t, key, message{Rejected: true, Reason: reason.Error()})
+}
+
+// Forward publishes the settlement of p under key once p is settled.
+// The returned promise is fulfilled once the settlement is published.
+func Forward[T any](ctx context.Context, t Transport, key string, p *promises.Promise[T], opts Options[T]) *promises.Promise[struct{}] {
+	return promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+		v, err := p.Await(ctx)
+		if ctx.Err() != nil {
+			reject(ctx.Err())
+			return
+		}
+
+		if err != nil {
+			err = Reject(ctx, t, key, err)
+		} else {
+			err = Resolve(ctx, t, key, v, opts)
+		}
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(struct{}{})
+	})
+}
+
+// Remote returns a promise that is settled when the keyed promise is settled by any process.
+// It is rejected with context.DeadlineExceeded if the settlement does not arrive within the timeout,
+// or with the context error if ctx is done first.
+func Remote[T any](ctx context.Context, t Transport, key string, opts Options[T]) *promises.Promise[T] {
+	return promises.New(func(resolve promises.Resolve[T], reject promises.Reject) {
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
+		messages, unsubscribe, err := t.Subscribe(ctx, key)
+		if err != nil {
+			reject(err)
+			return
+		}
+		defer unsubscribe()
+
+		var b []byte
+		var ok bool
+		if r, retains := t.(Retainer); retains {
+			b, ok, err = r.Retained(ctx, key)
+			if err != nil {
+				reject(err)
+				return
+			}
+		}
+
+		if !ok {
+			select {
+			case <-ctx.Done():
+				reject(ctx.Err())
+				return
+			case b, ok = <-messages:
+				if !ok {
+					reject(errors.New("subscription closed"))
+					return
+				}
+			}
+		}
+
+		var m message
+		if err := json.Unmarshal(b, &m); err != nil {
+			reject(err)
+			return
+		}
+
+		if m.Rejected {
+			reject(errors.New(m.Reason))
+			return
+		}
+
+		v, err := opts.codec().Decode(m.Value)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	})
+}
+
+func publish(ctx context.Context, t Transport, key string, m message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return t.Publish(ctx, key, b)
+}
+
+func (o Options[T]) codec() Codec[T] {
+	if o.Codec == nil {
+		return JSONCodec[T]{}
+	}
+
+	return o.Codec
+}
@@ -0,0 +1,78 @@
+package distributed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/distributed"
+)
+
+type order struct {
+	ID int `json:"id"`
+}
+
+func TestRemote(t *testing.T) {
+	ctx := context.Background()
+	tr := NewMemoryTransport()
+
+	p := Remote(ctx, tr, "order", Options[order]{})
+	time.Sleep(10 * time.Millisecond)
+	if err := Resolve(ctx, tr, "order", order{ID: 1}, Options[order]{}); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v.ID != 1 {
+		t.Errorf("expected id to be 1, got %d", v.ID)
+	}
+}
+
+func TestRemoteRetained(t *testing.T) {
+	ctx := context.Background()
+	tr := NewMemoryTransport()
+
+	Reject(ctx, tr, "order", errors.New("out of stock"))
+	_, err := Remote(ctx, tr, "order", Options[order]{}).Await(ctx)
+	if err == nil || err.Error() != "out of stock" {
+		t.Errorf("expected error to be out of stock, got %v", err)
+	}
+}
+
+func TestRemoteTimeout(t *testing.T) {
+	ctx := context.Background()
+	tr := NewMemoryTransport()
+
+	_, err := Remote(ctx, tr, "order", Options[order]{Timeout: 10 * time.Millisecond}).Await(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to be context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestForward(t *testing.T) {
+	ctx := context.Background()
+	tr := NewMemoryTransport()
+
+	local := promises.New(func(resolve promises.Resolve[string], reject promises.Reject) {
+		resolve("hello")
+	})
+
+	if _, err := Forward(ctx, tr, "greeting", local, Options[string]{}).Await(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	v, err := Remote(ctx, tr, "greeting", Options[string]{}).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != "hello" {
+		t.Errorf("expected value to be hello, got %s", v)
+	}
+}
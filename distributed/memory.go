@@ -0,0 +1,63 @@
+package distributed
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryTransport is an in-process Transport that retains the last message of each key.
+// It is useful for tests and for running distributed workflows in a single process.
+type MemoryTransport struct {
+	subscribers map[string]map[chan []byte]struct{}
+	retained    map[string][]byte
+	mutex       sync.Mutex
+}
+
+// NewMemoryTransport creates an empty MemoryTransport.
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{
+		subscribers: make(map[string]map[chan []byte]struct{}),
+		retained:    make(map[string][]byte),
+	}
+}
+
+func (t *MemoryTransport) Publish(ctx context.Context, key string, message []byte) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.retained[key] = message
+	for c := range t.subscribers[key] {
+		select {
+		case c <- message:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (t *MemoryTransport) Subscribe(ctx context.Context, key string) (<-chan []byte, func(), error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	c := make(chan []byte, 1)
+	if t.subscribers[key] == nil {
+		t.subscribers[key] = make(map[chan []byte]struct{})
+	}
+
+	t.subscribers[key][c] = struct{}{}
+	return c, func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+
+		delete(t.subscribers[key], c)
+	}, nil
+}
+
+func (t *MemoryTransport) Retained(ctx context.Context, key string) ([]byte, bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	b, ok := t.retained[key]
+	return b, ok, nil
+}
@@ -0,0 +1,197 @@
+// Package durable provides promises whose state is persisted to a store, so they survive process restarts.
+//
+// A durable promise records that it is pending before its executor runs and records its outcome once settled.
+// After a restart, Recover restores settled promises from the store and re-invokes the executors of pending ones,
+// which gives at-least-once execution semantics.
+// Executors of long operations can save checkpoints with NewWithCheckpoints, so that they resume from the last one after a restart.
+package durable
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var (
+	// ErrSettled is returned by a checkpoint function called after the promise is settled.
+	ErrSettled = errors.New("durable promise already settled")
+)
+
+// State is the persisted state of a durable promise.
+type State string
+
+const (
+	Pending   State = "pending"
+	Fulfilled State = "fulfilled"
+	Rejected  State = "rejected"
+)
+
+// Record is the persisted form of a durable promise.
+type Record struct {
+	Key    string          `json:"key"`
+	State  State           `json:"state"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Reason string          `json:"reason,omitempty"`
+
+	// Checkpoint is the state last saved by the executor of a pending promise created with NewWithCheckpoints.
+	Checkpoint json.RawMessage `json:"checkpoint,omitempty"`
+}
+
+// Store persists records by key. Implementations must be safe for concurrent use.
+type Store interface {
+	Save(r Record) error
+	Load(key string) (Record, bool, error)
+	List() ([]Record, error)
+	Delete(key string) error
+}
+
+// New creates a durable promise identified by key.
+// If the store already holds a settled record for key, the promise is settled from it and the executor is not invoked.
+// Otherwise a pending record is saved, the executor is invoked and the outcome is saved when the promise settles.
+// Values are persisted as JSON. If the store fails, the promise is rejected with the store error.
+func New[T any](store Store, key string, executor promises.Executor[T]) *promises.Promise[T] {
+	return NewWithCheckpoints(store, key, func(resume struct{}, checkpoint func(struct{}) error, resolve promises.Resolve[T], reject promises.Reject) {
+		executor(resolve, reject)
+	})
+}
+
+// CheckpointExecutor is the executor of a durable promise that saves checkpoints of its progress.
+// resume is the state of the last checkpoint saved before the process restarted, or the zero value on the first run.
+// checkpoint saves state as JSON in the record of the promise, replacing the previous checkpoint;
+// it fails once the promise is settled.
+type CheckpointExecutor[T, S any] func(resume S, checkpoint func(state S) error, resolve promises.Resolve[T], reject promises.Reject)
+
+// NewWithCheckpoints is like New, but the executor can save checkpoints so that, when the promise is recovered
+// after a restart, the long operation resumes from the last checkpoint rather than from scratch.
+// The checkpoint is cleared once the promise settles.
+func NewWithCheckpoints[T, S any](store Store, key string, executor CheckpointExecutor[T, S]) *promises.Promise[T] {
+	return promises.New(func(resolve promises.Resolve[T], reject promises.Reject) {
+		r, ok, err := store.Load(key)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		if ok && r.State != Pending {
+			settle(r, resolve, reject)
+			return
+		}
+
+		var resume S
+		if len(r.Checkpoint) > 0 {
+			if err := json.Unmarshal(r.Checkpoint, &resume); err != nil {
+				reject(err)
+				return
+			}
+		}
+
+		if err := store.Save(Record{Key: key, State: Pending, Checkpoint: r.Checkpoint}); err != nil {
+			reject(err)
+			return
+		}
+
+		var mutex sync.Mutex
+		settled := false
+		checkpoint := func(state S) error {
+			b, err := json.Marshal(state)
+			if err != nil {
+				return err
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if settled {
+				return ErrSettled
+			}
+
+			return store.Save(Record{Key: key, State: Pending, Checkpoint: b})
+		}
+
+		executor(resume, checkpoint, func(value T) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if settled {
+				return
+			}
+
+			settled = true
+			b, err := json.Marshal(value)
+			if err == nil {
+				err = store.Save(Record{Key: key, State: Fulfilled, Value: b})
+			}
+
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			resolve(value)
+		}, func(reason error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if settled {
+				return
+			}
+
+			settled = true
+			if reason == nil {
+				reason = errors.New("nil reason")
+			}
+
+			if err := store.Save(Record{Key: key, State: Rejected, Reason: reason.Error()}); err != nil {
+				reject(errors.Join(reason, err))
+				return
+			}
+
+			reject(reason)
+		})
+	})
+}
+
+// Recover restores every promise recorded in the store.
+// Settled promises are restored from their records and pending ones are re-created with the executor returned by executors for their key.
+// All records in the store must hold values of type T.
+func Recover[T any](store Store, executors func(key string) promises.Executor[T]) (map[string]*promises.Promise[T], error) {
+	return RecoverWithCheckpoints(store, func(key string) CheckpointExecutor[T, struct{}] {
+		executor := executors(key)
+		return func(resume struct{}, checkpoint func(struct{}) error, resolve promises.Resolve[T], reject promises.Reject) {
+			executor(resolve, reject)
+		}
+	})
+}
+
+// RecoverWithCheckpoints is like Recover, but pending promises are re-created with NewWithCheckpoints,
+// so their executors resume from the last checkpoint. All checkpoints in the store must hold states of type S.
+func RecoverWithCheckpoints[T, S any](store Store, executors func(key string) CheckpointExecutor[T, S]) (map[string]*promises.Promise[T], error) {
+	records, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	recovered := make(map[string]*promises.Promise[T], len(records))
+	for _, r := range records {
+		recovered[r.Key] = NewWithCheckpoints(store, r.Key, executors(r.Key))
+	}
+
+	return recovered, nil
+}
+
+func settle[T any](r Record, resolve promises.Resolve[T], reject promises.Reject) {
+	if r.State == Rejected {
+		reject(errors.New(r.Reason))
+		return
+	}
+
+	var v T
+	if err := json.Unmarshal(r.Value, &v); err != nil {
+		reject(err)
+		return
+	}
+
+	resolve(v)
+}
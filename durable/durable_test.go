@@ -0,0 +1,185 @@
+package durable_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/durable"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	p := New(store, "job", func(resolve promises.Resolve[int], reject promises.Reject) {
+		resolve(1)
+	})
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+
+	r, ok, _ := store.Load("job")
+	if !ok || r.State != Fulfilled {
+		t.Errorf("expected record to be fulfilled, got %v", r.State)
+	}
+}
+
+func TestNewRestoresSettled(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	var calls atomic.Int32
+	executor := func(resolve promises.Resolve[string], reject promises.Reject) {
+		calls.Add(1)
+		resolve("hello")
+	}
+
+	New(store, "job", executor).Await(ctx)
+	v, err := New(store, "job", executor).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != "hello" {
+		t.Errorf("expected value to be hello, got %s", v)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected executor to be called once, got %d", calls.Load())
+	}
+}
+
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	store.Save(Record{Key: "done", State: Fulfilled, Value: []byte("1")})
+	store.Save(Record{Key: "failed", State: Rejected, Reason: "something went wrong"})
+	store.Save(Record{Key: "pending/1", State: Pending})
+	store.Save(Record{Key: ".job", State: Pending})
+
+	recovered, err := Recover(store, func(key string) promises.Executor[int] {
+		return func(resolve promises.Resolve[int], reject promises.Reject) {
+			resolve(2)
+		}
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if len(recovered) != 4 {
+		t.Fatalf("expected 4 promises, got %d", len(recovered))
+	}
+
+	if v, _ := recovered[".job"].Await(ctx); v != 2 {
+		t.Errorf("expected .job to be rerun, got %d", v)
+	}
+
+	if v, _ := recovered["done"].Await(ctx); v != 1 {
+		t.Errorf("expected done to be 1, got %d", v)
+	}
+
+	if _, err := recovered["failed"].Await(ctx); err == nil || err.Error() != "something went wrong" {
+		t.Errorf("expected failed to be rejected, got %v", err)
+	}
+
+	if v, _ := recovered["pending/1"].Await(ctx); v != 2 {
+		t.Errorf("expected pending to be re-run with 2, got %d", v)
+	}
+
+	if r, _, _ := store.Load("pending/1"); r.State != Fulfilled {
+		t.Errorf("expected pending record to be fulfilled, got %v", r.State)
+	}
+}
+
+func TestNewRejected(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	_, err := New(store, "job", func(resolve promises.Resolve[int], reject promises.Reject) {
+		reject(errors.New("something went wrong"))
+	}).Await(ctx)
+	if err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+
+	r, _, _ := store.Load("job")
+	if r.State != Rejected || r.Reason != "something went wrong" {
+		t.Errorf("expected record to be rejected, got %v %s", r.State, r.Reason)
+	}
+}
+
+func TestNewWithCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	crashed := make(chan struct{})
+
+	NewWithCheckpoints(store, "job", func(resume int, checkpoint func(int) error, resolve promises.Resolve[[]int], reject promises.Reject) {
+		for step := resume; step < 3; step++ {
+			if err := checkpoint(step + 1); err != nil {
+				reject(err)
+				return
+			}
+		}
+
+		close(crashed)
+	})
+	<-crashed
+
+	var steps []int
+	recovered, err := RecoverWithCheckpoints(store, func(key string) CheckpointExecutor[[]int, int] {
+		return func(resume int, checkpoint func(int) error, resolve promises.Resolve[[]int], reject promises.Reject) {
+			for step := resume; step < 5; step++ {
+				steps = append(steps, step)
+				if err := checkpoint(step + 1); err != nil {
+					reject(err)
+					return
+				}
+			}
+
+			resolve(steps)
+		}
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	v, err := recovered["job"].Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 2 || v[0] != 3 || v[1] != 4 {
+		t.Errorf("expected to resume from step 3, got %v", v)
+	}
+
+	r, _, _ := store.Load("job")
+	if r.State != Fulfilled || r.Checkpoint != nil {
+		t.Errorf("expected the record to be fulfilled without a checkpoint, got %v %s", r.State, r.Checkpoint)
+	}
+}
+
+func TestCheckpointAfterSettled(t *testing.T) {
+	ctx := context.Background()
+	checkpoints := make(chan func(int) error, 1)
+	p := NewWithCheckpoints(NewMemoryStore(), "job", func(resume int, checkpoint func(int) error, resolve promises.Resolve[int], reject promises.Reject) {
+		resolve(1)
+		checkpoints <- checkpoint
+	})
+	p.Await(ctx)
+
+	if err := (<-checkpoints)(1); !errors.Is(err, ErrSettled) {
+		t.Errorf("expected ErrSettled, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package durable
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	recordExt = ".json"
+
+	// tempPattern names the temporary files of records being saved. Their extension tells them apart from records,
+	// whatever the key.
+	tempPattern = "record-*.tmp"
+)
+
+// MemoryStore is a Store that keeps records in memory. It is useful for tests.
+type MemoryStore struct {
+	records map[string]Record
+	mutex   sync.Mutex
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]Record)}
+}
+
+func (s *MemoryStore) Save(r Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.records[r.Key] = r
+	return nil
+}
+
+func (s *MemoryStore) Load(key string) (Record, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, ok := s.records[key]
+	return r, ok, nil
+}
+
+func (s *MemoryStore) List() ([]Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records := make([]Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+
+	return records, nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// FileStore is a Store that keeps one JSON file per record in a directory.
+// Records are written atomically by renaming a temporary file, and synced to disk before Save returns.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Save(r Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, tempPattern)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), s.path(r.Key)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return syncDir(s.dir)
+}
+
+// syncDir flushes the entries of dir to disk, so that a renamed file survives a crash.
+// Windows cannot sync directories and makes renames durable on its own.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+
+	return d.Close()
+}
+
+func (s *FileStore) Load(key string) (Record, bool, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Record{}, false, nil
+	}
+
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	var r Record
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Record{}, false, err
+	}
+
+	return r, true, nil
+}
+
+func (s *FileStore) List() ([]Record, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, recordExt) {
+			continue
+		}
+
+		key, err := url.PathUnescape(strings.TrimSuffix(name, recordExt))
+		if err != nil {
+			continue
+		}
+
+		r, ok, err := s.Load(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			records = append(records, r)
+		}
+	}
+
+	return records, nil
+}
+
+func (s *FileStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+recordExt)
+}
@@ -0,0 +1,143 @@
+package promises
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+var (
+	errInvalidEncoding = errors.New("invalid settled result encoding")
+)
+
+type settledResultWire[T any] struct {
+	Status Status
+	Value  T
+	Reason string
+}
+
+// Snapshot returns the settled state of the promise.
+// If the promise is still pending, it returns false.
+func (p *Promise[T]) Snapshot() (SettledResult[T], bool) {
+	p = p.target()
+	status := p.loadStatus()
+	if status == Pending {
+		return SettledResult[T]{Status: Pending}, false
+	}
+
+	p.mutex.RLock()
+	v := p.value
+	r := p.reason
+	s := p.spilled
+	p.mutex.RUnlock()
+
+	if status == Rejected {
+		p.handled.Store(true)
+		return SettledResult[T]{Status: Rejected, Reason: r}, true
+	}
+
+	v, _ = p.load(v, s)
+	return SettledResult[T]{Status: Fulfilled, Value: v}, true
+}
+
+// GobEncode implements gob.GobEncoder.
+// The reason is encoded by its message, so it decodes to a plain error with the same message.
+func (r SettledResult[T]) GobEncode() ([]byte, error) {
+	w := settledResultWire[T]{Status: r.Status, Value: r.Value}
+	if r.Reason != nil {
+		w.Reason = r.Reason.Error()
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(w); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (r *SettledResult[T]) GobDecode(b []byte) error {
+	var w settledResultWire[T]
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&w); err != nil {
+		return err
+	}
+
+	*r = SettledResult[T]{Status: w.Status, Value: w.Value}
+	if w.Status == Rejected {
+		r.Reason = errors.New(w.Reason)
+	}
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact form:
+// the status byte, followed by the reason message if rejected or the gob-encoded value if fulfilled.
+func (r SettledResult[T]) MarshalBinary() ([]byte, error) {
+	b := []byte{byte(r.Status)}
+	switch r.Status {
+	case Rejected:
+		var reason string
+		if r.Reason != nil {
+			reason = r.Reason.Error()
+		}
+
+		b = binary.AppendUvarint(b, uint64(len(reason)))
+		return append(b, reason...), nil
+	case Fulfilled:
+		buf := bytes.NewBuffer(b)
+		if err := gob.NewEncoder(buf).Encode(&r.Value); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("%w: status %v", errInvalidEncoding, r.Status)
+	}
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *SettledResult[T]) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		return errInvalidEncoding
+	}
+
+	switch status := Status(b[0]); status {
+	case Rejected:
+		n, size := binary.Uvarint(b[1:])
+		if size <= 0 || uint64(len(b)-1-size) != n {
+			return errInvalidEncoding
+		}
+
+		*r = SettledResult[T]{Status: Rejected, Reason: errors.New(string(b[1+size:]))}
+		return nil
+	case Fulfilled:
+		var v T
+		if err := gob.NewDecoder(bytes.NewReader(b[1:])).Decode(&v); err != nil {
+			return err
+		}
+
+		*r = SettledResult[T]{Status: Fulfilled, Value: v}
+		return nil
+	default:
+		return fmt.Errorf("%w: status %v", errInvalidEncoding, status)
+	}
+}
+
+// settleEncoded settles a promise with an outcome encoded by SettledResult.MarshalBinary.
+func settleEncoded[T any](b []byte, resolve Resolve[T], reject Reject) {
+	var result SettledResult[T]
+	if err := result.UnmarshalBinary(b); err != nil {
+		reject(err)
+		return
+	}
+
+	if result.Status == Rejected {
+		reject(result.Reason)
+		return
+	}
+
+	resolve(result.Value)
+}
@@ -0,0 +1,104 @@
+package promises_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestSnapshot(t *testing.T) {
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+	<-p.Done()
+
+	r, ok := p.Snapshot()
+	if !ok {
+		t.Fatalf("expected snapshot to be available")
+	}
+
+	if r.Status != Fulfilled || r.Value != 1 {
+		t.Errorf("expected fulfilled with 1, got %v %d", r.Status, r.Value)
+	}
+}
+
+func TestSnapshotPending(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-release
+		resolve(1)
+	})
+
+	if r, ok := p.Snapshot(); ok || r.Status != Pending {
+		t.Errorf("expected snapshot to be unavailable with the Pending status, got %v", r.Status)
+	}
+}
+
+func TestSettledResultGob(t *testing.T) {
+	ctx := context.Background()
+	p1 := New(func(resolve Resolve[string], reject Reject) {
+		resolve("hello")
+	})
+	p2 := New(func(resolve Resolve[string], reject Reject) {
+		reject(errors.New("something went wrong"))
+	})
+
+	results, _ := AllSettled(ctx, p1, p2).Await(ctx)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(results); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	var decoded []SettledResult[string]
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if decoded[0].Status != Fulfilled || decoded[0].Value != "hello" {
+		t.Errorf("expected fulfilled with hello, got %v %s", decoded[0].Status, decoded[0].Value)
+	}
+
+	if decoded[1].Status != Rejected || decoded[1].Reason.Error() != "something went wrong" {
+		t.Errorf("expected rejected with something went wrong, got %v %v", decoded[1].Status, decoded[1].Reason)
+	}
+}
+
+func TestSettledResultBinary(t *testing.T) {
+	tests := []SettledResult[int]{
+		{Status: Fulfilled, Value: 42},
+		{Status: Rejected, Reason: errors.New("something went wrong")},
+	}
+
+	for _, want := range tests {
+		b, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("expected error to be nil, got %v", err)
+		}
+
+		var got SettledResult[int]
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("expected error to be nil, got %v", err)
+		}
+
+		if got.Status != want.Status || got.Value != want.Value {
+			t.Errorf("expected %v %d, got %v %d", want.Status, want.Value, got.Status, got.Value)
+		}
+
+		if (want.Reason == nil) != (got.Reason == nil) {
+			t.Errorf("expected reason %v, got %v", want.Reason, got.Reason)
+		}
+	}
+}
+
+func TestSettledResultBinaryInvalid(t *testing.T) {
+	var r SettledResult[int]
+	if err := r.UnmarshalBinary([]byte{9}); err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
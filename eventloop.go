@@ -0,0 +1,82 @@
+package promises
+
+import (
+	"context"
+	"sync"
+)
+
+// EventLoop runs executors and OnSettle callbacks one at a time, in the order they were queued, on whichever
+// goroutine drives it with Run or RunUntilIdle. Promises created with WithEventLoop start no goroutine of their own
+// unless they have a timeout, which keeps them deterministic and cheap where goroutines are scarce, such as js/wasm and TinyGo.
+//
+// Everything on the loop shares one goroutine, so executors and callbacks must not block: they settle promises
+// and register callbacks instead of awaiting. Combinators such as All await their promises, so they must not be run on it.
+type EventLoop struct {
+	queue []func()
+	wake  chan struct{}
+	mutex sync.Mutex
+}
+
+// NewEventLoop returns an empty event loop.
+func NewEventLoop() *EventLoop {
+	return &EventLoop{wake: make(chan struct{}, 1)}
+}
+
+// WithEventLoop runs the executor and the OnSettle callbacks of the promise on the event loop.
+func WithEventLoop(l *EventLoop) Option {
+	return func(o *options) {
+		o.scheduler = l
+		o.loop = l
+	}
+}
+
+// Schedule queues run on the loop, which makes the loop a Scheduler. It is safe to call from any goroutine.
+func (l *EventLoop) Schedule(run func()) {
+	l.mutex.Lock()
+	l.queue = append(l.queue, run)
+	l.mutex.Unlock()
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RunUntilIdle runs queued work, including the work it queues, until the queue is empty, and returns how many functions ran.
+func (l *EventLoop) RunUntilIdle() int {
+	n := 0
+	for l.step() {
+		n++
+	}
+
+	return n
+}
+
+// Run runs queued work as it arrives until ctx is done, and returns the context error.
+func (l *EventLoop) Run(ctx context.Context) error {
+	for {
+		l.RunUntilIdle()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.wake:
+		}
+	}
+}
+
+// step runs the next queued function and reports whether there was one.
+func (l *EventLoop) step() bool {
+	l.mutex.Lock()
+	if len(l.queue) == 0 {
+		l.mutex.Unlock()
+		return false
+	}
+
+	run := l.queue[0]
+	l.queue[0] = nil
+	l.queue = l.queue[1:]
+	l.mutex.Unlock()
+
+	run()
+	return true
+}
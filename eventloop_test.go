@@ -0,0 +1,111 @@
+package promises_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestEventLoop(t *testing.T) {
+	loop := NewEventLoop()
+	var order []string
+	p := New(func(resolve Resolve[int], reject Reject) {
+		order = append(order, "executor")
+		resolve(1)
+	}, WithEventLoop(loop))
+
+	p.OnSettle(func(r SettledResult[int]) {
+		order = append(order, "first")
+		q := New(func(resolve Resolve[int], reject Reject) {
+			order = append(order, "nested executor")
+			resolve(r.Value + 1)
+		}, WithEventLoop(loop))
+
+		q.OnSettle(func(r SettledResult[int]) {
+			order = append(order, "nested callback")
+		})
+	})
+	p.OnSettle(func(r SettledResult[int]) {
+		order = append(order, "second")
+	})
+
+	if len(order) != 0 {
+		t.Fatalf("expected nothing to run before the loop, got %v", order)
+	}
+
+	if n := loop.RunUntilIdle(); n != 5 {
+		t.Errorf("expected 5 functions to run, got %d", n)
+	}
+
+	want := []string{"executor", "first", "second", "nested executor", "nested callback"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestEventLoopRun(t *testing.T) {
+	loop := NewEventLoop()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- loop.Run(ctx)
+	}()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithEventLoop(loop))
+
+	select {
+	case <-p.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to pick up queued work")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEventLoopSettleAll(t *testing.T) {
+	loop := NewEventLoop()
+	ds := []*Deferred[int]{NewDeferred[int](WithEventLoop(loop)), NewDeferred[int](WithEventLoop(loop))}
+	var got []int
+	for _, d := range ds {
+		d.Promise().OnSettle(func(r SettledResult[int]) {
+			got = append(got, r.Value)
+		})
+	}
+
+	SettleAll(ds, []int{1, 2})
+	if n := loop.RunUntilIdle(); n != 2 {
+		t.Errorf("expected the callbacks to run on the loop, got %d functions", n)
+	}
+
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+}
+
+func TestEventLoopThen(t *testing.T) {
+	ctx := context.Background()
+	loop := NewEventLoop()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithEventLoop(loop))
+
+	var got []int
+	p.Then(ctx, func(v int) (int, error) {
+		return v + 1, nil
+	}).OnSettle(func(r SettledResult[int]) {
+		got = append(got, r.Value)
+	})
+
+	loop.RunUntilIdle()
+	if !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("expected the callback of the derived promise to run on the loop, got %v", got)
+	}
+}
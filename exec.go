@@ -0,0 +1,80 @@
+package promises
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// CommandResult is the outcome of a command run by RunCommand.
+type CommandResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// CommandError is the reason a command promise is rejected with when the command exits with a non-zero code.
+type CommandError struct {
+	Result CommandResult
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command exited with code %d: %v", e.Result.ExitCode, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// RunCommand starts the command and returns a promise that is fulfilled with its result when it exits with code 0.
+// Stdout and stderr are captured unless the command already has them set.
+// If the command exits with a non-zero code, the promise is rejected with a *CommandError.
+// If ctx is done before the command exits, the process is killed and the promise is rejected with the context error.
+// Canceling the promise with Cancel kills the process too.
+func RunCommand(ctx context.Context, cmd *exec.Cmd, opts ...Option) *Promise[CommandResult] {
+	return NewWithContext(ctx, func(ctx context.Context, resolve Resolve[CommandResult], reject Reject) {
+		var stdout, stderr bytes.Buffer
+		if cmd.Stdout == nil {
+			cmd.Stdout = &stdout
+		}
+
+		if cmd.Stderr == nil {
+			cmd.Stderr = &stderr
+		}
+
+		if err := cmd.Start(); err != nil {
+			reject(err)
+			return
+		}
+
+		waited := make(chan error, 1)
+		go func() {
+			waited <- cmd.Wait()
+		}()
+
+		var err error
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+			<-waited
+			reject(ctx.Err())
+			return
+		case err = <-waited:
+		}
+
+		result := CommandResult{
+			Stdout:   stdout.Bytes(),
+			Stderr:   stderr.Bytes(),
+			ExitCode: cmd.ProcessState.ExitCode(),
+		}
+
+		if err != nil {
+			reject(&CommandError{Result: result, Err: err})
+			return
+		}
+
+		resolve(result)
+	}, opts...)
+}
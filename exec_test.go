@@ -0,0 +1,99 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestRunCommand(t *testing.T) {
+	ctx := context.Background()
+	p := RunCommand(ctx, exec.Command("sh", "-c", "echo hello; echo world >&2"))
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if string(v.Stdout) != "hello\n" {
+		t.Errorf("expected stdout to be hello, got %q", v.Stdout)
+	}
+
+	if string(v.Stderr) != "world\n" {
+		t.Errorf("expected stderr to be world, got %q", v.Stderr)
+	}
+}
+
+func TestRunCommandNonZeroExit(t *testing.T) {
+	ctx := context.Background()
+	p := RunCommand(ctx, exec.Command("sh", "-c", "echo oops >&2; exit 3"))
+
+	_, err := p.Await(ctx)
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected error to be *CommandError, got %v", err)
+	}
+
+	if cmdErr.Result.ExitCode != 3 {
+		t.Errorf("expected exit code to be 3, got %d", cmdErr.Result.ExitCode)
+	}
+
+	if string(cmdErr.Result.Stderr) != "oops\n" {
+		t.Errorf("expected stderr to be oops, got %q", cmdErr.Result.Stderr)
+	}
+}
+
+func TestRunCommandCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	p := RunCommand(ctx, exec.Command("sleep", "10"))
+	_, err := p.Await(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to be context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRunCommandCancel(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	p := RunCommand(context.Background(), exec.Command("sh", "-c", "echo $$ > "+pidFile+"; exec sleep 10"))
+
+	var pid int
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		b, _ := os.ReadFile(pidFile)
+		if n, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil {
+			pid = n
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("expected the command to start")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	p.Cancel(nil)
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return
+	}
+
+	for proc.Signal(syscall.Signal(0)) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("expected canceling the promise to kill the process")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
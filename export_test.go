@@ -0,0 +1,20 @@
+package promises
+
+import "time"
+
+// SetAwaitSelectHook sets the hook called by Await right before it waits and returns a function that clears it.
+func SetAwaitSelectHook(fn func()) (restore func()) {
+	hooks.awaitSelect = fn
+	return func() { hooks.awaitSelect = nil }
+}
+
+// SetSettleHook sets the hook called once an outcome is stored, before waiters are woken up, and returns a function that clears it.
+func SetSettleHook(fn func()) (restore func()) {
+	hooks.settle = fn
+	return func() { hooks.settle = nil }
+}
+
+// AdvanceWheel moves the timing wheel one tick forward, as its ticker does.
+func AdvanceWheel(w *TimingWheel) {
+	w.advance(time.Now())
+}
@@ -0,0 +1,45 @@
+package promises
+
+import "context"
+
+// Future is a read-only view of a promise for handing results to callers. It can be awaited and observed,
+// but not canceled or settled, so an API can return it without giving away any control over the work.
+// A Future is a small value meant to be copied, and it is an AnyPromise.
+type Future[T any] struct {
+	p *Promise[T]
+}
+
+// Future returns a read-only view of the promise.
+func (p *Promise[T]) Future() Future[T] {
+	return Future[T]{p: p}
+}
+
+// Await is Await of the promise.
+func (f Future[T]) Await(ctx context.Context) (T, error) {
+	return f.p.Await(ctx)
+}
+
+// Done is Done of the promise.
+func (f Future[T]) Done() <-chan struct{} {
+	return f.p.Done()
+}
+
+// Status is Status of the promise.
+func (f Future[T]) Status() Status {
+	return f.p.Status()
+}
+
+// State is State of the promise.
+func (f Future[T]) State() Status {
+	return f.p.State()
+}
+
+// Err is Err of the promise.
+func (f Future[T]) Err() error {
+	return f.p.Err()
+}
+
+// OnSettle is OnSettle of the promise.
+func (f Future[T]) OnSettle(fn func(SettledResult[T])) {
+	f.p.OnSettle(fn)
+}
@@ -0,0 +1,40 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestFuture(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	f := blocked(block).Future()
+	if f.State() != Pending {
+		t.Errorf("expected pending, got %v", f.State())
+	}
+
+	settled := make(chan SettledResult[int], 1)
+	f.OnSettle(func(r SettledResult[int]) {
+		settled <- r
+	})
+
+	close(block)
+	if v, err := f.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	<-f.Done()
+	if f.State() != Fulfilled || f.Err() != nil {
+		t.Errorf("expected fulfilled, got %v, %v", f.State(), f.Err())
+	}
+
+	if r := <-settled; r.Value != 1 {
+		t.Errorf("expected the callback to get 1, got %v", r.Value)
+	}
+
+	if _, err := Join(ctx, f).Await(ctx); err != nil {
+		t.Errorf("expected a future to be joinable, got %v", err)
+	}
+}
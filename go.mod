@@ -1,5 +1,7 @@
 module github.com/oneofthezombies/promises
 
-go 1.21.1
+go 1.23
 
-require github.com/oneofthezombies/option v1.0.3
+require github.com/fsnotify/fsnotify v1.7.0
+
+require golang.org/x/sys v0.4.0 // indirect
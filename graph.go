@@ -0,0 +1,82 @@
+package promises
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// parents maps a tracked pending promise to the promises it was derived from by a combinator, guarded by inFlightMutex.
+var parents = make(map[uint64][]uint64)
+
+// link records that the promise child was derived from parent, if both are known and child is tracked and pending.
+func link(child, parent *Info) {
+	if child == nil || parent == nil {
+		return
+	}
+
+	inFlightMutex.Lock()
+	defer inFlightMutex.Unlock()
+
+	if _, ok := inFlight[child.ID]; ok {
+		parents[child.ID] = append(parents[child.ID], parent.ID)
+	}
+}
+
+// DumpGraph writes the tracked pending promises to w as a Graphviz DOT graph, for debugging stuck pipelines.
+// Each node is labeled with the name, ID, state and age of the promise,
+// and edges lead from the inputs of combinators such as All, Fork and Tap to the promises derived from them.
+// Inputs that are no longer pending or not tracked are drawn dashed.
+func DumpGraph(w io.Writer) error {
+	now := CurrentClock().Now()
+
+	inFlightMutex.Lock()
+	nodes := make([]Info, 0, len(inFlight))
+	for _, info := range inFlight {
+		nodes = append(nodes, info)
+	}
+
+	edges := make(map[uint64][]uint64, len(parents))
+	for child, ids := range parents {
+		edges[child] = append([]uint64(nil), ids...)
+	}
+	inFlightMutex.Unlock()
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	pending := make(map[uint64]bool, len(nodes))
+	for _, info := range nodes {
+		pending[info.ID] = true
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph promises {")
+	for _, info := range nodes {
+		label := fmt.Sprintf("#%d\npending %v", info.ID, now.Sub(info.CreatedAt).Round(time.Millisecond))
+		if info.Name != "" {
+			label = info.Name + "\n" + label
+		}
+
+		fmt.Fprintf(b, "\t%d [label=%s];\n", info.ID, strconv.Quote(label))
+	}
+
+	drawn := make(map[uint64]bool)
+	for _, info := range nodes {
+		for _, parent := range edges[info.ID] {
+			if !pending[parent] && !drawn[parent] {
+				drawn[parent] = true
+				fmt.Fprintf(b, "\t%d [label=%s, style=dashed];\n", parent, strconv.Quote(fmt.Sprintf("#%d\nnot pending", parent)))
+			}
+
+			fmt.Fprintf(b, "\t%d -> %d;\n", parent, info.ID)
+		}
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
@@ -0,0 +1,60 @@
+package promises_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestDumpGraph(t *testing.T) {
+	restore := Configure(Config{TrackInFlight: true})
+	defer restore()
+
+	block := make(chan struct{})
+	a := blocked(block, WithName("a"))
+	b := blocked(block, WithName("b"))
+	all := All(context.Background(), a, b)
+	infos := InFlight()
+
+	var sb strings.Builder
+	if err := DumpGraph(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	graph := sb.String()
+	if !strings.HasPrefix(graph, "digraph promises {") {
+		t.Errorf("expected a DOT graph, got %q", graph)
+	}
+
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 promises in flight, got %v", infos)
+	}
+
+	for _, want := range []string{
+		`"a\n#`,
+		`"b\n#`,
+		fmt.Sprintf("%d -> %d;", infos[0].ID, infos[2].ID),
+		fmt.Sprintf("%d -> %d;", infos[1].ID, infos[2].ID),
+	} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("expected the graph to contain %q, got %q", want, graph)
+		}
+	}
+
+	close(block)
+	if _, err := all.Await(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	sb.Reset()
+	if err := DumpGraph(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(sb.String(), "->") {
+		t.Errorf("expected no edges once settled, got %q", sb.String())
+	}
+}
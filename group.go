@@ -0,0 +1,172 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrGroupSealed is returned by Group.Add once the group is sealed, and is the reason the promises of Group.Go are rejected with then.
+	ErrGroupSealed = errors.New("group is sealed")
+)
+
+// Group collects promises over time, such as one per incoming request, and aggregates whatever has been added so far.
+// Like errgroup.Group, it can also start the functions behind the promises with Go and wait for them all with Wait.
+// The zero value is an empty group ready to use, whose functions run with context.Background.
+type Group[T any] struct {
+	promises []*Promise[T]
+	sealed   bool
+	mutex    sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	opts   GroupOptions
+	err    error
+}
+
+// GroupOptions configures a Group created with NewGroup.
+type GroupOptions struct {
+	// KeepGoing leaves the shared context alone when a promise started with Go is rejected,
+	// instead of canceling it so that the other functions stop early.
+	KeepGoing bool
+}
+
+// NewGroup creates a group whose functions started with Go share a context derived from ctx.
+// Unless opts.KeepGoing is set, the context is canceled with the reason of the first promise started with Go to be rejected.
+func NewGroup[T any](ctx context.Context, opts GroupOptions) *Group[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group[T]{ctx: ctx, cancel: cancel, opts: opts}
+}
+
+// Context returns the context shared by the functions started with Go.
+func (g *Group[T]) Context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+
+	return g.ctx
+}
+
+// Go starts fn with the shared context and adds the promise of its result to the group.
+// The options configure the promise as in New. Once the group is sealed, fn is not called
+// and the returned promise is rejected with ErrGroupSealed.
+func (g *Group[T]) Go(fn func(ctx context.Context) (T, error), opts ...Option) *Promise[T] {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.sealed {
+		return NewRejected[T](ErrGroupSealed)
+	}
+
+	p := NewWithContext(g.Context(), func(ctx context.Context, resolve Resolve[T], reject Reject) {
+		settleWith(resolve, reject)(fn(ctx))
+	}, opts...)
+
+	p.OnSettle(func(r SettledResult[T]) {
+		if r.Status == Rejected {
+			g.fail(r.Reason)
+		}
+	})
+
+	g.promises = append(g.promises, p)
+	return p
+}
+
+// fail records the first reason a promise started with Go is rejected with, and cancels the shared context.
+func (g *Group[T]) fail(reason error) {
+	g.mutex.Lock()
+	first := g.err == nil
+	if first {
+		g.err = reason
+	}
+	g.mutex.Unlock()
+
+	if first && g.cancel != nil && !g.opts.KeepGoing {
+		g.cancel(reason)
+	}
+}
+
+// Wait seals the group and returns a promise that settles once every promise of the group is settled.
+// It is fulfilled with their values in order, or rejected with the reason of the first promise started with Go
+// to be rejected, or else of the first rejected promise in order. Once they are settled, the shared context is canceled.
+// If ctx is done first, the promise is rejected with the context error.
+func (g *Group[T]) Wait(ctx context.Context) *Promise[[]T] {
+	g.Seal()
+	promises := g.Promises()
+	return New(func(resolve Resolve[[]T], reject Reject) {
+		results, err := AllSettled(ctx, promises...).Await(ctx)
+		if g.cancel != nil {
+			defer g.cancel(context.Canceled)
+		}
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		g.mutex.Lock()
+		err = g.err
+		g.mutex.Unlock()
+
+		values := make([]T, len(results))
+		for i, r := range results {
+			if r.Status == Rejected && err == nil {
+				err = r.Reason
+			}
+
+			values[i] = r.Value
+		}
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(values)
+	})
+}
+
+// Add adds the promises to the group. It returns ErrGroupSealed and adds nothing once the group is sealed.
+func (g *Group[T]) Add(promises ...*Promise[T]) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.sealed {
+		return ErrGroupSealed
+	}
+
+	g.promises = append(g.promises, promises...)
+	return nil
+}
+
+// Seal stops the group from accepting promises, so that its aggregates are final.
+func (g *Group[T]) Seal() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.sealed = true
+}
+
+// Promises returns the promises added so far, in the order they were added.
+func (g *Group[T]) Promises() []*Promise[T] {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return append([]*Promise[T](nil), g.promises...)
+}
+
+// All is All of the promises added so far.
+func (g *Group[T]) All(ctx context.Context) *Promise[[]T] {
+	return All(ctx, g.Promises()...)
+}
+
+// AllSettled is AllSettled of the promises added so far.
+func (g *Group[T]) AllSettled(ctx context.Context) *Promise[[]SettledResult[T]] {
+	return AllSettled(ctx, g.Promises()...)
+}
+
+// Race is Race of the promises added so far.
+func (g *Group[T]) Race(ctx context.Context) *Promise[T] {
+	return Race(ctx, g.Promises()...)
+}
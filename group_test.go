@@ -0,0 +1,102 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+	var g Group[int]
+	if err := g.Add(newResolved(1), newResolved(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := g.All(ctx).Await(ctx); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v", v)
+	}
+
+	g.Add(newResolved(3))
+	g.Seal()
+	if err := g.Add(newResolved(4)); !errors.Is(err, ErrGroupSealed) {
+		t.Errorf("expected ErrGroupSealed, got %v", err)
+	}
+
+	results, _ := g.AllSettled(ctx).Await(ctx)
+	if len(results) != 3 {
+		t.Errorf("expected 3 results, got %v", results)
+	}
+
+	if v, err := g.Race(ctx).Await(ctx); err != nil || v < 1 || v > 3 {
+		t.Errorf("expected one of the values, got %v, %v", v, err)
+	}
+}
+
+func TestGroupGo(t *testing.T) {
+	ctx := context.Background()
+	g := NewGroup[int](ctx, GroupOptions{})
+	for i := range 3 {
+		g.Go(func(ctx context.Context) (int, error) {
+			return i, nil
+		})
+	}
+
+	if v, err := g.Wait(ctx).Await(ctx); err != nil || !reflect.DeepEqual(v, []int{0, 1, 2}) {
+		t.Errorf("expected [0 1 2], got %v, %v", v, err)
+	}
+
+	if g.Context().Err() == nil {
+		t.Error("expected the shared context to be released by Wait")
+	}
+
+	if _, err := g.Go(func(ctx context.Context) (int, error) { return 0, nil }).Await(ctx); err != ErrGroupSealed {
+		t.Errorf("expected ErrGroupSealed, got %v", err)
+	}
+}
+
+func TestGroupGoCancel(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	g := NewGroup[int](ctx, GroupOptions{})
+	stopped := g.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	})
+
+	g.Go(func(ctx context.Context) (int, error) {
+		return 0, cause
+	})
+
+	if _, err := g.Wait(ctx).Await(ctx); err != cause {
+		t.Errorf("expected the first reason, got %v", err)
+	}
+
+	if err := stopped.Err(); err != cause {
+		t.Errorf("expected the other function to be stopped with the reason, got %v", err)
+	}
+}
+
+func TestGroupGoKeepGoing(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	g := NewGroup[int](ctx, GroupOptions{KeepGoing: true})
+	g.Go(func(ctx context.Context) (int, error) {
+		return 0, cause
+	}).Await(ctx)
+
+	other := g.Go(func(ctx context.Context) (int, error) {
+		return 1, ctx.Err()
+	})
+
+	if _, err := g.Wait(ctx).Await(ctx); err != cause {
+		t.Errorf("expected the first reason, got %v", err)
+	}
+
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the other function to keep going, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,12 @@
+package promises
+
+// hooks are called at points where the relative order of concurrent operations matters,
+// so that regression tests can pin an exact interleaving instead of relying on sleeps.
+// They are only set by tests, through export_test.go, while no promises are in use.
+var hooks struct {
+	// awaitSelect is called by Await right before it waits for the promise or the context.
+	awaitSelect func()
+
+	// settle is called once the outcome of a promise is stored, before its waiters are woken up.
+	settle func()
+}
@@ -0,0 +1,64 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAwaitSettledBeforeCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-release
+		resolve(1)
+	})
+
+	// Settle the promise and cancel the context before Await waits, so both are ready at once.
+	defer SetAwaitSelectHook(func() {
+		close(release)
+		<-p.Done()
+		cancel()
+	})()
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+}
+
+func TestSettleRacingWithSettle(t *testing.T) {
+	ctx := context.Background()
+	settled := make(chan struct{})
+	var p *Promise[int]
+	var resolveAgain Resolve[int]
+
+	// Resolve again after the first outcome is stored but before waiters are woken up.
+	defer SetSettleHook(func() {
+		if !p.IsSettled() {
+			t.Errorf("expected promise to report settled once its outcome is stored")
+		}
+
+		resolveAgain(2)
+		close(settled)
+	})()
+
+	start := make(chan struct{})
+	p = New(func(resolve Resolve[int], reject Reject) {
+		<-start
+		resolveAgain = resolve
+		resolve(1)
+	})
+
+	close(start)
+	<-settled
+	v, _ := p.Await(ctx)
+	if v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+}
@@ -0,0 +1,167 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrIdempotencyConflict is the reason a promise is rejected with when its idempotency key is in use by a promise of another type.
+	ErrIdempotencyConflict = errors.New("idempotency key used with another type")
+)
+
+// IdempotencyStore persists the outcomes of promises created with WithIdempotencyKey,
+// so that the work is not repeated within the window, even across processes.
+type IdempotencyStore interface {
+	// Load returns the outcome saved for key, or nil if there is none or it is older than its window.
+	Load(key string) ([]byte, error)
+
+	// Save saves the outcome of key, encoded with SettledResult.MarshalBinary, for window. Zero means forever.
+	Save(key string, outcome []byte, window time.Duration) error
+}
+
+// WithIdempotencyKey makes the creation of the promise idempotent: while a promise with the same key is pending,
+// it is returned instead, and once one is fulfilled, new promises are fulfilled with its value without running the executor.
+// Outcomes are saved in Config.IdempotencyStore for Config.IdempotencyWindow, or in memory if no store is configured.
+// Rejected promises are not saved, so that creating the promise again retries the work.
+// Values must be gob-encodable to be saved; errors of the store are logged with the logger of the promise, if any.
+func WithIdempotencyKey(key string) Option {
+	return func(o *options) {
+		o.idempotencyKey = key
+	}
+}
+
+// idempotentCall is the promise created for an idempotency key, shared while it is pending.
+type idempotentCall struct {
+	key     string
+	store   IdempotencyStore
+	window  time.Duration
+	logger  *slog.Logger
+	promise any
+	ready   chan struct{}
+	outcome []byte
+}
+
+var (
+	idempotentCalls      = make(map[string]*idempotentCall)
+	idempotentCallsMutex sync.Mutex
+	defaultIdempotency   = NewMemoryIdempotencyStore()
+)
+
+// joinIdempotent returns a handle of the pending promise of the idempotency key of o, if any.
+// Otherwise it claims the key for the promise about to be created and loads the saved outcome, if any, into o.
+func joinIdempotent[T any](o *options) (*Promise[T], bool) {
+	idempotentCallsMutex.Lock()
+	if call, ok := idempotentCalls[o.idempotencyKey]; ok {
+		idempotentCallsMutex.Unlock()
+
+		<-call.ready
+		if p, ok := call.promise.(*Promise[T]); ok {
+			return p.handle(), true
+		}
+
+		p, _, reject := newPending[T](nil)
+		reject(fmt.Errorf("%w: %s", ErrIdempotencyConflict, o.idempotencyKey))
+		return p, true
+	}
+
+	call := &idempotentCall{
+		key:    o.idempotencyKey,
+		store:  o.idempotencyStore,
+		window: o.idempotencyWindow,
+		logger: o.logger,
+		ready:  make(chan struct{}),
+	}
+	if call.store == nil {
+		call.store = defaultIdempotency
+	}
+
+	idempotentCalls[call.key] = call
+	idempotentCallsMutex.Unlock()
+
+	outcome, err := call.store.Load(call.key)
+	if err != nil {
+		call.logError("load", err)
+	}
+
+	call.outcome = outcome
+	o.idempotent = call
+	return nil, false
+}
+
+// start shares the promise created for the call with the creations waiting for it.
+func (c *idempotentCall) start(p any) {
+	c.promise = p
+	close(c.ready)
+}
+
+// finish saves the outcome of the promise, unless it was rejected or loaded from the store, and releases the key.
+func (c *idempotentCall) finish(outcome []byte, err error) {
+	if c.outcome == nil && outcome != nil {
+		err = c.store.Save(c.key, outcome, c.window)
+	}
+
+	if err != nil {
+		c.logError("save", err)
+	}
+
+	idempotentCallsMutex.Lock()
+	delete(idempotentCalls, c.key)
+	idempotentCallsMutex.Unlock()
+}
+
+func (c *idempotentCall) logError(op string, err error) {
+	if c.logger != nil {
+		c.logger.LogAttrs(context.Background(), slog.LevelWarn, "idempotency store failed",
+			slog.String("key", c.key), slog.String("op", op), slog.Any("error", err))
+	}
+}
+
+type memoryIdempotencyStore struct {
+	outcomes map[string]memoryOutcome
+	mutex    sync.Mutex
+}
+
+type memoryOutcome struct {
+	outcome   []byte
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore that keeps outcomes in memory, expiring them on the package clock.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{outcomes: make(map[string]memoryOutcome)}
+}
+
+func (s *memoryIdempotencyStore) Load(key string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	o, ok := s.outcomes[key]
+	if !ok {
+		return nil, nil
+	}
+
+	if !o.expiresAt.IsZero() && !CurrentClock().Now().Before(o.expiresAt) {
+		delete(s.outcomes, key)
+		return nil, nil
+	}
+
+	return o.outcome, nil
+}
+
+func (s *memoryIdempotencyStore) Save(key string, outcome []byte, window time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	o := memoryOutcome{outcome: outcome}
+	if window > 0 {
+		o.expiresAt = CurrentClock().Now().Add(window)
+	}
+
+	s.outcomes[key] = o
+	return nil
+}
@@ -0,0 +1,105 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type countingExecutor struct {
+	runs  atomic.Int32
+	block chan struct{}
+	err   error
+}
+
+func (e *countingExecutor) run(resolve Resolve[int], reject Reject) {
+	n := int(e.runs.Add(1))
+	if e.block != nil {
+		<-e.block
+	}
+
+	if e.err != nil {
+		reject(e.err)
+		return
+	}
+
+	resolve(n)
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	defer Configure(Config{IdempotencyStore: NewMemoryIdempotencyStore()})()
+	e := &countingExecutor{block: make(chan struct{})}
+
+	first := New(e.run, WithIdempotencyKey("TestIdempotencyKey"))
+	second := New(e.run, WithIdempotencyKey("TestIdempotencyKey"))
+	close(e.block)
+	for _, p := range []*Promise[int]{first, second} {
+		if v, err := p.Await(ctx); err != nil || v != 1 {
+			t.Fatalf("expected the pending promise to be joined, got %v, %v", v, err)
+		}
+	}
+
+	third := New(e.run, WithIdempotencyKey("TestIdempotencyKey"))
+	if v, err := third.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the saved value, got %v, %v", v, err)
+	}
+
+	if runs := e.runs.Load(); runs != 1 {
+		t.Errorf("expected the executor to run once, ran %d times", runs)
+	}
+
+}
+
+func TestIdempotencyKeyRejectedIsNotSaved(t *testing.T) {
+	ctx := context.Background()
+	defer Configure(Config{IdempotencyStore: NewMemoryIdempotencyStore()})()
+	e := &countingExecutor{err: errors.New("reason")}
+
+	for i := 0; i < 2; i++ {
+		if _, err := New(e.run, WithIdempotencyKey("TestIdempotencyKeyRejectedIsNotSaved")).Await(ctx); !errors.Is(err, e.err) {
+			t.Errorf("expected reason, got %v", err)
+		}
+	}
+
+	if runs := e.runs.Load(); runs != 2 {
+		t.Errorf("expected the executor to run again, ran %d times", runs)
+	}
+}
+
+func TestIdempotencyWindow(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Unix(0, 0)}
+	defer SetClock(clock)()
+	defer Configure(Config{IdempotencyStore: NewMemoryIdempotencyStore(), IdempotencyWindow: time.Minute})()
+
+	e := &countingExecutor{}
+	New(e.run, WithIdempotencyKey("window")).Await(ctx)
+	clock.Add(30 * time.Second)
+	if v, _ := New(e.run, WithIdempotencyKey("window")).Await(ctx); v != 1 {
+		t.Errorf("expected the saved value within the window, got %v", v)
+	}
+
+	clock.Add(time.Minute)
+	if v, _ := New(e.run, WithIdempotencyKey("window")).Await(ctx); v != 2 {
+		t.Errorf("expected the executor to run again after the window, got %v", v)
+	}
+}
+
+func TestIdempotencyConflict(t *testing.T) {
+	defer Configure(Config{IdempotencyStore: NewMemoryIdempotencyStore()})()
+	block := make(chan struct{})
+	defer close(block)
+	blocked(block, WithIdempotencyKey("TestIdempotencyConflict"))
+
+	p := New(func(resolve Resolve[string], reject Reject) {
+		resolve("")
+	}, WithIdempotencyKey("TestIdempotencyConflict"))
+	if _, err := p.Await(context.Background()); !errors.Is(err, ErrIdempotencyConflict) {
+		t.Errorf("expected ErrIdempotencyConflict, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package promises
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ReadAllAsync reads from r until EOF in the background and returns a promise that is fulfilled with the data read.
+// Cancellation is best-effort: when ctx is done, r is interrupted by setting a past read deadline if it supports one,
+// or by closing it if it is an io.Closer, and the promise is rejected with the context error unless the read completed.
+func ReadAllAsync(ctx context.Context, r io.Reader, opts ...Option) *Promise[[]byte] {
+	return New(func(resolve Resolve[[]byte], reject Reject) {
+		stop := interruptOnDone(ctx, r)
+		b, err := io.ReadAll(r)
+		// A read that completed wins over a cancellation that raced with it.
+		if !stop() && err != nil {
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(b)
+	}, opts...)
+}
+
+// CopyAsync copies from src to dst until EOF in the background and returns a promise that is fulfilled with the number of bytes copied.
+// Cancellation is best-effort in the same way as ReadAllAsync, applied to src.
+func CopyAsync(ctx context.Context, dst io.Writer, src io.Reader, opts ...Option) *Promise[int64] {
+	return New(func(resolve Resolve[int64], reject Reject) {
+		stop := interruptOnDone(ctx, src)
+		n, err := io.Copy(dst, src)
+		if !stop() && err != nil {
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(n)
+	}, opts...)
+}
+
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// interruptOnDone arranges for r to be interrupted when ctx is done.
+// The returned function reports false if r has been interrupted.
+func interruptOnDone(ctx context.Context, r io.Reader) func() bool {
+	return context.AfterFunc(ctx, func() {
+		switch r := r.(type) {
+		case readDeadliner:
+			r.SetReadDeadline(time.Unix(1, 0))
+		case io.Closer:
+			r.Close()
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package promises_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestReadAllAsync(t *testing.T) {
+	ctx := context.Background()
+	p := ReadAllAsync(ctx, strings.NewReader("hello"))
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if string(v) != "hello" {
+		t.Errorf("expected value to be hello, got %s", v)
+	}
+}
+
+func TestReadAllAsyncCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p := ReadAllAsync(ctx, r)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	_, err := p.Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+}
+
+// cancelingReader cancels a context once it has been read to the end, as if the context ended right after the read.
+type cancelingReader struct {
+	io.Reader
+	cancel context.CancelFunc
+}
+
+func (r cancelingReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	if err == io.EOF {
+		r.cancel()
+		// Give the interruption a chance to run before the read returns.
+		time.Sleep(time.Millisecond)
+	}
+
+	return n, err
+}
+
+func TestReadAllAsyncCompletedBeforeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := ReadAllAsync(ctx, cancelingReader{Reader: strings.NewReader("hello"), cancel: cancel})
+
+	if v, err := p.Await(context.Background()); err != nil || string(v) != "hello" {
+		t.Errorf("expected a completed read to be fulfilled with hello, got %s, %v", v, err)
+	}
+}
+
+func TestCopyAsync(t *testing.T) {
+	ctx := context.Background()
+	var dst bytes.Buffer
+	p := CopyAsync(ctx, &dst, strings.NewReader("hello"))
+
+	n, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if n != 5 {
+		t.Errorf("expected n to be 5, got %d", n)
+	}
+
+	if dst.String() != "hello" {
+		t.Errorf("expected dst to be hello, got %s", dst.String())
+	}
+}
+
+func TestCopyAsyncWithAll(t *testing.T) {
+	ctx := context.Background()
+	var dst1, dst2 bytes.Buffer
+	p := All(ctx,
+		CopyAsync(ctx, &dst1, strings.NewReader("hello")),
+		CopyAsync(ctx, &dst2, strings.NewReader("world!")),
+	)
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v[0] != 5 || v[1] != 6 {
+		t.Errorf("expected values to be [5 6], got %v", v)
+	}
+}
@@ -0,0 +1,78 @@
+package promises
+
+import (
+	"context"
+	"sync"
+)
+
+// KeyedQueue runs tasks submitted under the same key strictly one after another, in submission order,
+// while tasks of different keys run concurrently. It gives per-user or per-aggregate ordering without
+// serializing everything else. The zero value is an empty queue ready to use.
+type KeyedQueue[K comparable] struct {
+	tails map[K]chan struct{}
+	mutex sync.Mutex
+}
+
+// Enqueue queues fn under the key and returns a promise that settles with its outcome.
+// fn starts once every task queued before it under the same key has finished, whether it succeeded or not.
+// If ctx is done before fn starts, fn is skipped and the promise is rejected with the context error;
+// the tasks queued after it still wait for the ones before it. The options configure the returned promise as in New.
+func Enqueue[K comparable, T any](q *KeyedQueue[K], ctx context.Context, key K, fn func(ctx context.Context) (T, error), opts ...Option) *Promise[T] {
+	turn := make(chan struct{})
+
+	q.mutex.Lock()
+	if q.tails == nil {
+		q.tails = make(map[K]chan struct{})
+	}
+
+	prev := q.tails[key]
+	q.tails[key] = turn
+	q.mutex.Unlock()
+
+	return New(func(resolve Resolve[T], reject Reject) {
+		defer q.finish(key, turn)
+
+		if prev != nil {
+			select {
+			case <-prev:
+			case <-ctx.Done():
+				reject(ctx.Err())
+				<-prev
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			reject(err)
+			return
+		}
+
+		v, err := fn(ctx)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	}, opts...)
+}
+
+// Len returns the number of keys with queued or running tasks.
+func (q *KeyedQueue[K]) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.tails)
+}
+
+// finish hands the key's turn to the next task and forgets the key once its last task is done.
+func (q *KeyedQueue[K]) finish(key K, turn chan struct{}) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.tails[key] == turn {
+		delete(q.tails, key)
+	}
+
+	close(turn)
+}
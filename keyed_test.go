@@ -0,0 +1,117 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestKeyedQueueOrder(t *testing.T) {
+	ctx := context.Background()
+	var q KeyedQueue[string]
+	var mutex sync.Mutex
+	order := make(map[string][]int)
+
+	var results []*Promise[int]
+	for i := 0; i < 20; i++ {
+		i := i
+		key := "even"
+		if i%2 == 1 {
+			key = "odd"
+		}
+
+		results = append(results, Enqueue(&q, ctx, key, func(ctx context.Context) (int, error) {
+			// Later tasks finishing sooner would expose any reordering.
+			time.Sleep(time.Duration(20-i) * 100 * time.Microsecond)
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			order[key] = append(order[key], i)
+			return i, nil
+		}))
+	}
+
+	if _, err := All(ctx, results...).Await(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if want := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}; !reflect.DeepEqual(order["even"], want) {
+		t.Errorf("expected %v, got %v", want, order["even"])
+	}
+
+	if want := []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}; !reflect.DeepEqual(order["odd"], want) {
+		t.Errorf("expected %v, got %v", want, order["odd"])
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("expected no keys left, got %d", q.Len())
+	}
+}
+
+func TestKeyedQueueConcurrentKeys(t *testing.T) {
+	ctx := context.Background()
+	var q KeyedQueue[int]
+	block := make(chan struct{})
+	blocked := Enqueue(&q, ctx, 1, func(ctx context.Context) (int, error) {
+		<-block
+		return 1, nil
+	})
+
+	other := Enqueue(&q, ctx, 2, func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+
+	if v, err := other.Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected another key to run while the first is blocked, got %v, %v", v, err)
+	}
+
+	close(block)
+	_, _ = blocked.Await(ctx)
+}
+
+func TestKeyedQueueSkipsCanceled(t *testing.T) {
+	ctx := context.Background()
+	var q KeyedQueue[string]
+	block := make(chan struct{})
+	running := make(chan struct{})
+	first := Enqueue(&q, ctx, "k", func(ctx context.Context) (int, error) {
+		close(running)
+		<-block
+		return 0, errors.New("failed")
+	})
+	<-running
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	ran := false
+	canceled := Enqueue(&q, canceledCtx, "k", func(ctx context.Context) (int, error) {
+		ran = true
+		return 0, nil
+	})
+
+	last := Enqueue(&q, ctx, "k", func(ctx context.Context) (int, error) {
+		if !first.IsSettled() {
+			t.Error("expected the last task to wait for the first")
+		}
+
+		return 3, nil
+	})
+
+	cancel()
+	if _, err := canceled.Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(block)
+	if v, err := last.Await(ctx); err != nil || v != 3 {
+		t.Errorf("expected 3, got %v, %v", v, err)
+	}
+
+	if ran {
+		t.Error("expected the canceled task to be skipped")
+	}
+}
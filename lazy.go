@@ -0,0 +1,32 @@
+package promises
+
+import (
+	"context"
+	"time"
+)
+
+// Lazy returns a promise of fn that does not call fn until the promise is first waited for, with Await, Done,
+// OnSettle, or a combinator built on them such as Then or All. fn is called with a context that is canceled once
+// the promise settles, as with NewWithContext. The options configure the promise as in New; a timeout starts with fn.
+func Lazy[T any](fn func(ctx context.Context) (T, error), opts ...Option) *Promise[T] {
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.lazy = true
+	})
+
+	return NewWithContext(context.Background(), func(ctx context.Context, resolve Resolve[T], reject Reject) {
+		settleWith(resolve, reject)(fn(ctx))
+	}, opts...)
+}
+
+// Memoize returns a function that returns the promise of fn, shared by concurrent calls like singleflight,
+// and reused once fulfilled for ttl on the package clock. Zero means forever. A rejected promise is not reused,
+// so the call after it runs fn again. It is a Cache of a single value.
+func Memoize[T any](fn func(ctx context.Context) (T, error), ttl time.Duration) func() *Promise[T] {
+	c := NewCache(func(ctx context.Context, _ struct{}) (T, error) {
+		return fn(ctx)
+	}, CacheOptions[struct{}]{TTL: ttl})
+
+	return func() *Promise[T] {
+		return c.Get(struct{}{})
+	}
+}
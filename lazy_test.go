@@ -0,0 +1,84 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestLazy(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	p := Lazy(func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		return 1, nil
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	if calls.Load() != 0 || p.Status() != Pending {
+		t.Fatal("expected fn not to be called before the promise is awaited")
+	}
+
+	if v, err := p.Then(ctx, func(v int) (int, error) { return v + 1, nil }).Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected 2, got %v, %v", v, err)
+	}
+
+	p.Await(ctx)
+	if calls.Load() != 1 {
+		t.Errorf("expected fn to be called once, got %d", calls.Load())
+	}
+}
+
+func TestLazyCanceled(t *testing.T) {
+	ctx := context.Background()
+	called := false
+	p := Lazy(func(ctx context.Context) (int, error) {
+		called = true
+		return 1, nil
+	})
+
+	p.Cancel(nil)
+	if _, err := p.Await(ctx); !errors.Is(err, context.Canceled) || called {
+		t.Errorf("expected a canceled lazy promise not to call fn, got %v, %v", err, called)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Unix(0, 0)}
+	defer SetClock(clock)()
+
+	var calls atomic.Int32
+	fail := errors.New("failed")
+	get := Memoize(func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, fail
+		}
+
+		return int(calls.Load()), nil
+	}, time.Minute)
+
+	if _, err := get().Await(ctx); err != fail {
+		t.Fatalf("expected the first call to fail, got %v", err)
+	}
+
+	a, b := get(), get()
+	for _, p := range []*Promise[int]{a, b} {
+		if v, err := p.Await(ctx); err != nil || v != 2 {
+			t.Fatalf("expected concurrent calls to share the retry of the rejection, got %v, %v", v, err)
+		}
+	}
+
+	if v, _ := get().Await(ctx); v != 2 {
+		t.Errorf("expected the value to be reused, got %v", v)
+	}
+
+	clock.Add(2 * time.Minute)
+	if v, _ := get().Await(ctx); v != 3 {
+		t.Errorf("expected the expired value to be computed again, got %v", v)
+	}
+}
@@ -0,0 +1,108 @@
+package promises
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter bounds how many operations run concurrently.
+// Release reports how an operation went, so adaptive limiters can adjust the limit.
+type Limiter interface {
+	// Acquire blocks until an operation may start or ctx is done.
+	Acquire(ctx context.Context) error
+
+	// Release ends an operation started with Acquire.
+	Release(latency time.Duration, err error)
+}
+
+// AIMDOptions configures an AIMD limiter.
+type AIMDOptions struct {
+	// Initial is the starting limit. It defaults to Min.
+	Initial int
+
+	// Min and Max bound the limit. Min defaults to 1 and Max to no bound.
+	Min, Max int
+
+	// LatencyTarget is the latency above which an operation counts as a sign of overload. Zero ignores latency.
+	LatencyTarget time.Duration
+
+	// Backoff is the factor the limit is multiplied by on overload. It defaults to 0.5.
+	Backoff float64
+}
+
+// AIMDLimiter is a Limiter with additive-increase/multiplicative-decrease control:
+// the limit grows by one for every limit successful operations and shrinks by the backoff factor
+// whenever an operation fails or exceeds the latency target.
+type AIMDLimiter struct {
+	opts     AIMDOptions
+	limit    float64
+	inFlight int
+	changed  chan struct{}
+	mutex    sync.Mutex
+}
+
+// NewAIMDLimiter creates an AIMD limiter.
+func NewAIMDLimiter(opts AIMDOptions) *AIMDLimiter {
+	if opts.Min <= 0 {
+		opts.Min = 1
+	}
+
+	if opts.Max <= 0 {
+		opts.Max = math.MaxInt
+	}
+
+	if opts.Initial < opts.Min {
+		opts.Initial = opts.Min
+	}
+
+	if opts.Backoff <= 0 || opts.Backoff >= 1 {
+		opts.Backoff = 0.5
+	}
+
+	return &AIMDLimiter{opts: opts, limit: float64(opts.Initial), changed: make(chan struct{})}
+}
+
+// Limit returns the current limit.
+func (l *AIMDLimiter) Limit() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return int(l.limit)
+}
+
+func (l *AIMDLimiter) Acquire(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		if l.inFlight < int(l.limit) {
+			l.inFlight++
+			l.mutex.Unlock()
+			return nil
+		}
+
+		changed := l.changed
+		l.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *AIMDLimiter) Release(latency time.Duration, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inFlight--
+	if err != nil || (l.opts.LatencyTarget > 0 && latency > l.opts.LatencyTarget) {
+		l.limit = math.Max(float64(l.opts.Min), math.Floor(l.limit*l.opts.Backoff))
+	} else {
+		l.limit = math.Min(float64(l.opts.Max), l.limit+1/l.limit)
+	}
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
@@ -0,0 +1,93 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAIMDLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewAIMDLimiter(AIMDOptions{Initial: 2, Max: 4, LatencyTarget: time.Second})
+
+	for i := 0; i < 20; i++ {
+		if err := l.Acquire(ctx); err != nil {
+			t.Fatalf("expected error to be nil, got %v", err)
+		}
+
+		l.Release(time.Millisecond, nil)
+	}
+
+	if l.Limit() != 4 {
+		t.Errorf("expected the limit to grow to the max, got %d", l.Limit())
+	}
+
+	l.Acquire(ctx)
+	l.Release(2*time.Second, nil)
+	if l.Limit() != 2 {
+		t.Errorf("expected the limit to halve on slow operations, got %d", l.Limit())
+	}
+
+	l.Acquire(ctx)
+	l.Release(time.Millisecond, errors.New("overloaded"))
+	l.Acquire(ctx)
+	l.Release(time.Millisecond, errors.New("overloaded"))
+	if l.Limit() != 1 {
+		t.Errorf("expected the limit not to drop below the min, got %d", l.Limit())
+	}
+}
+
+func TestAIMDLimiterBlocks(t *testing.T) {
+	l := NewAIMDLimiter(AIMDOptions{})
+	l.Acquire(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the second acquire to block, got %v", err)
+	}
+
+	l.Release(0, nil)
+	if err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+}
+
+func TestMapOrderedLimited(t *testing.T) {
+	ctx := context.Background()
+	l := NewAIMDLimiter(AIMDOptions{Initial: 2, Max: 3})
+	var inFlight, peak atomic.Int32
+	var sum int
+
+	p := MapOrderedLimited(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8}, l, func(ctx context.Context, item int) (int, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return item, nil
+	}, func(i int, v int) {
+		sum += v
+	})
+
+	if _, err := p.Await(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if sum != 36 {
+		t.Errorf("expected a sum of 36, got %d", sum)
+	}
+
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 calls in flight, got %d", peak.Load())
+	}
+}
@@ -0,0 +1,130 @@
+package promises
+
+import "context"
+
+// MapOrdered applies fn to the items concurrently and calls emit with each result strictly in input order,
+// as soon as it and all results before it are available. At most limit items are in flight at a time,
+// counting results that wait in the reorder buffer; a limit of zero or less means no limit.
+// emit is called from a single goroutine. The returned promise is fulfilled once every result is emitted.
+// If fn fails or ctx is done, the remaining work is canceled and the promise is rejected with the error.
+func MapOrdered[T, U any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) (U, error), emit func(i int, v U)) *Promise[struct{}] {
+	return New(func(resolve Resolve[struct{}], reject Reject) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		if limit <= 0 || limit > len(items) {
+			limit = len(items)
+		}
+
+		slots := make(chan struct{}, limit)
+		started := make(chan *Promise[U], limit)
+		go func() {
+			for _, item := range items {
+				item := item
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				started <- New(func(resolve Resolve[U], reject Reject) {
+					v, err := fn(ctx, item)
+					if err != nil {
+						reject(err)
+						return
+					}
+
+					resolve(v)
+				})
+			}
+		}()
+
+		for i := range items {
+			var p *Promise[U]
+			select {
+			case p = <-started:
+			case <-ctx.Done():
+				reject(context.Cause(ctx))
+				return
+			}
+
+			v, err := p.Await(ctx)
+			if err != nil {
+				cancel(err)
+				reject(err)
+				return
+			}
+
+			emit(i, v)
+			<-slots
+		}
+
+		resolve(struct{}{})
+	})
+}
+
+// MapOrderedLimited is MapOrdered with the number of concurrent calls of fn bounded by limiter, such as an AIMDLimiter,
+// which is told the latency and error of every call. Unlike with MapOrdered, the reorder buffer is not bounded.
+func MapOrderedLimited[T, U any](ctx context.Context, items []T, limiter Limiter, fn func(ctx context.Context, item T) (U, error), emit func(i int, v U)) *Promise[struct{}] {
+	return MapOrdered(ctx, items, 0, func(ctx context.Context, item T) (U, error) {
+		if err := limiter.Acquire(ctx); err != nil {
+			var zero U
+			return zero, err
+		}
+
+		start := CurrentClock().Now()
+		v, err := fn(ctx, item)
+		limiter.Release(CurrentClock().Now().Sub(start), err)
+		return v, err
+	}, emit)
+}
+
+// MapSlice applies fn to the items with at most limit calls in flight, as MapOrdered does,
+// and returns a promise of the results in input order.
+func MapSlice[T, U any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) (U, error)) *Promise[[]U] {
+	results := make([]U, len(items))
+	mapped := MapOrdered(ctx, items, limit, fn, func(i int, v U) {
+		results[i] = v
+	})
+
+	return Then(ctx, mapped, func(struct{}) ([]U, error) {
+		return results, nil
+	})
+}
+
+// FilterSlice calls pred on the items with at most limit calls in flight, as MapOrdered does,
+// and returns a promise of the items it returned true for, in input order.
+func FilterSlice[T any](ctx context.Context, items []T, limit int, pred func(ctx context.Context, item T) (bool, error)) *Promise[[]T] {
+	return Then(ctx, MapSlice(ctx, items, limit, pred), func(keep []bool) ([]T, error) {
+		var kept []T
+		for i, ok := range keep {
+			if ok {
+				kept = append(kept, items[i])
+			}
+		}
+
+		return kept, nil
+	})
+}
+
+// ReduceSlice returns a promise of the items folded into initial with fn, one item at a time in input order.
+// It is rejected with the first error fn returns, or with the context error if ctx is done between items.
+func ReduceSlice[T, U any](ctx context.Context, items []T, initial U, fn func(ctx context.Context, acc U, item T) (U, error)) *Promise[U] {
+	return New(func(resolve Resolve[U], reject Reject) {
+		acc := initial
+		for _, item := range items {
+			if err := ctx.Err(); err != nil {
+				reject(err)
+				return
+			}
+
+			var err error
+			if acc, err = fn(ctx, acc, item); err != nil {
+				reject(err)
+				return
+			}
+		}
+
+		resolve(acc)
+	})
+}
@@ -0,0 +1,132 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestMapOrdered(t *testing.T) {
+	ctx := context.Background()
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var inFlight, peak atomic.Int32
+	var emitted []int
+	p := MapOrdered(ctx, items, 4, func(ctx context.Context, item int) (int, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+		return item * 2, nil
+	}, func(i int, v int) {
+		if v != i*2 {
+			t.Errorf("expected %d at %d, got %d", i*2, i, v)
+		}
+
+		emitted = append(emitted, v)
+	})
+
+	if _, err := p.Await(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if len(emitted) != len(items) {
+		t.Errorf("expected %d results, got %d", len(items), len(emitted))
+	}
+
+	if peak.Load() > 4 {
+		t.Errorf("expected at most 4 items in flight, got %d", peak.Load())
+	}
+}
+
+func TestMapOrderedRejected(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	var emitted []int
+	p := MapOrdered(ctx, []int{0, 1, 2, 3}, 2, func(ctx context.Context, item int) (int, error) {
+		if item == 2 {
+			return 0, reason
+		}
+
+		return item, nil
+	}, func(i int, v int) {
+		emitted = append(emitted, v)
+	})
+
+	if _, err := p.Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+
+	if len(emitted) != 2 {
+		t.Errorf("expected the results before the failure to be emitted, got %v", emitted)
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	ctx := context.Background()
+	v, err := MapSlice(ctx, []int{3, 1, 2}, 2, func(ctx context.Context, item int) (string, error) {
+		time.Sleep(time.Duration(item) * time.Millisecond)
+		return strings.Repeat("x", item), nil
+	}).Await(ctx)
+	if err != nil || !slices.Equal(v, []string{"xxx", "x", "xx"}) {
+		t.Errorf("expected [xxx x xx], got %v, %v", v, err)
+	}
+}
+
+func TestFilterSlice(t *testing.T) {
+	ctx := context.Background()
+	v, err := FilterSlice(ctx, []int{1, 2, 3, 4, 5}, 0, func(ctx context.Context, item int) (bool, error) {
+		return item%2 == 1, nil
+	}).Await(ctx)
+	if err != nil || !slices.Equal(v, []int{1, 3, 5}) {
+		t.Errorf("expected [1 3 5], got %v, %v", v, err)
+	}
+
+	boom := errors.New("boom")
+	_, err = FilterSlice(ctx, []int{1, 2}, 1, func(ctx context.Context, item int) (bool, error) {
+		return false, boom
+	}).Await(ctx)
+	if !errors.Is(err, boom) {
+		t.Errorf("expected boom, got %v", err)
+	}
+}
+
+func TestReduceSlice(t *testing.T) {
+	ctx := context.Background()
+	v, err := ReduceSlice(ctx, []string{"a", "b", "c"}, "", func(ctx context.Context, acc string, item string) (string, error) {
+		return acc + item, nil
+	}).Await(ctx)
+	if err != nil || v != "abc" {
+		t.Errorf("expected abc, got %v, %v", v, err)
+	}
+
+	boom := errors.New("boom")
+	calls := 0
+	_, err = ReduceSlice(ctx, []int{1, 2, 3}, 0, func(ctx context.Context, acc int, item int) (int, error) {
+		calls++
+		if item == 2 {
+			return 0, boom
+		}
+
+		return acc + item, nil
+	}).Await(ctx)
+	if !errors.Is(err, boom) || calls != 2 {
+		t.Errorf("expected boom after 2 calls, got %v after %d", err, calls)
+	}
+}
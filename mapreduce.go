@@ -0,0 +1,80 @@
+package promises
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// WithWorkers sets the number of workers of MapReduce. It is ignored by other constructors.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// MapReduce partitions items into contiguous chunks, one per worker, maps every item of a chunk with mapFn
+// and folds the results of the chunk with reduceFn locally, then folds the partial results in order.
+// reduceFn must be associative, but need not be commutative. The promise is fulfilled with the zero value if there are no items.
+// The number of workers is set with WithWorkers and defaults to GOMAXPROCS; other options apply to the returned promise.
+// If mapFn fails or ctx is done, the workers stop at the next item and the promise is rejected with the error.
+func MapReduce[T, U any](ctx context.Context, items []T, mapFn func(ctx context.Context, item T) (U, error), reduceFn func(a, b U) U, opts ...Option) *Promise[U] {
+	workers := runtime.GOMAXPROCS(0)
+	if o := newOptions(opts); o != nil && o.workers > 0 {
+		workers = o.workers
+	}
+
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	return New(func(resolve Resolve[U], reject Reject) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		partials := make([]U, workers)
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			chunk := items[w*len(items)/workers : (w+1)*len(items)/workers]
+			go func(w int, chunk []T) {
+				defer wg.Done()
+
+				for i, item := range chunk {
+					if ctx.Err() != nil {
+						return
+					}
+
+					v, err := mapFn(ctx, item)
+					if err != nil {
+						cancel(err)
+						return
+					}
+
+					if i == 0 {
+						partials[w] = v
+					} else {
+						partials[w] = reduceFn(partials[w], v)
+					}
+				}
+			}(w, chunk)
+		}
+
+		wg.Wait()
+		if ctx.Err() != nil {
+			reject(context.Cause(ctx))
+			return
+		}
+
+		var result U
+		for i, partial := range partials {
+			if i == 0 {
+				result = partial
+			} else {
+				result = reduceFn(result, partial)
+			}
+		}
+
+		resolve(result)
+	}, opts...)
+}
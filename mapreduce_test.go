@@ -0,0 +1,67 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestMapReduce(t *testing.T) {
+	ctx := context.Background()
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i + 1
+	}
+
+	square := func(ctx context.Context, v int) (int, error) {
+		return v * v, nil
+	}
+	sum := func(a, b int) int {
+		return a + b
+	}
+
+	for _, workers := range []int{0, 1, 3, 2000} {
+		v, err := MapReduce(ctx, items, square, sum, WithWorkers(workers)).Await(ctx)
+		if err != nil || v != 333833500 {
+			t.Errorf("expected 333833500 with %d workers, got %v, %v", workers, v, err)
+		}
+	}
+
+	if v, err := MapReduce(ctx, nil, square, sum).Await(ctx); err != nil || v != 0 {
+		t.Errorf("expected 0 for no items, got %v, %v", v, err)
+	}
+}
+
+func TestMapReduceOrder(t *testing.T) {
+	ctx := context.Background()
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	format := func(ctx context.Context, v int) (string, error) {
+		return strconv.Itoa(v), nil
+	}
+	concat := func(a, b string) string {
+		return a + b
+	}
+
+	if v, _ := MapReduce(ctx, items, format, concat, WithWorkers(3)).Await(ctx); v != "1234567" {
+		t.Errorf("expected the items to be reduced in order, got %v", v)
+	}
+}
+
+func TestMapReduceError(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	fail := func(ctx context.Context, v int) (int, error) {
+		if v == 3 {
+			return 0, reason
+		}
+
+		return v, nil
+	}
+
+	if _, err := MapReduce(ctx, []int{1, 2, 3, 4}, fail, func(a, b int) int { return a + b }, WithWorkers(2)).Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package promises
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Middleware wraps the invocation of an executor.
+// It returns a function that runs next, and may do work before and after it, run it elsewhere, or delay it.
+// next settles the promise through the executor and must be called at most once.
+type Middleware func(next func()) func()
+
+var (
+	middlewares      atomic.Pointer[[]Middleware]
+	middlewaresMutex sync.Mutex
+)
+
+// Use appends middleware that wraps the executors of promises created from now on and returns a function that restores the previous middleware.
+// Middleware added first runs outermost. It wraps the middleware given with WithMiddleware.
+func Use(mw ...Middleware) (restore func()) {
+	middlewaresMutex.Lock()
+	defer middlewaresMutex.Unlock()
+
+	previous := middlewares.Load()
+	current := loadMiddlewares()
+	next := make([]Middleware, 0, len(current)+len(mw))
+	next = append(append(next, current...), mw...)
+	middlewares.Store(&next)
+
+	return func() {
+		middlewaresMutex.Lock()
+		defer middlewaresMutex.Unlock()
+
+		middlewares.Store(previous)
+	}
+}
+
+func loadMiddlewares() []Middleware {
+	mws := middlewares.Load()
+	if mws == nil {
+		return nil
+	}
+
+	return *mws
+}
+
+// chain wraps run with mws so that the first middleware runs outermost.
+func chain(run func(), mws []Middleware) func() {
+	for i := len(mws) - 1; i >= 0; i-- {
+		run = mws[i](run)
+	}
+
+	return run
+}
@@ -0,0 +1,91 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type callLog struct {
+	calls []string
+	mutex sync.Mutex
+}
+
+func (l *callLog) middleware(name string) Middleware {
+	return func(next func()) func() {
+		return func() {
+			l.add(name + " before")
+			next()
+			l.add(name + " after")
+		}
+	}
+}
+
+func (l *callLog) add(call string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.calls = append(l.calls, call)
+}
+
+func (l *callLog) get() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]string(nil), l.calls...)
+}
+
+func TestUse(t *testing.T) {
+	ctx := context.Background()
+	log := &callLog{}
+	restore := Use(log.middleware("outer"), log.middleware("inner"))
+
+	done := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		log.add("executor")
+		resolve(1)
+	}, WithMiddleware(log.middleware("local"), func(next func()) func() {
+		return func() {
+			next()
+			close(done)
+		}
+	}))
+	p.Await(ctx)
+	<-done
+	restore()
+
+	want := []string{"outer before", "inner before", "local before", "executor"}
+	if got := log.get(); !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}).Await(ctx)
+	if got := log.get(); len(got) > 7 {
+		t.Errorf("expected the middleware to be restored, got %v", got)
+	}
+}
+
+func TestUseRecover(t *testing.T) {
+	ctx := context.Background()
+	restore := Use(func(next func()) func() {
+		return func() {
+			panic("chaos")
+		}
+	})
+	defer restore()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithRecover())
+
+	var panicErr *PanicError
+	if _, err := p.Await(ctx); !errors.As(err, &panicErr) {
+		t.Errorf("expected *PanicError, got %v", err)
+	}
+}
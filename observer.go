@@ -0,0 +1,185 @@
+package promises
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Info identifies a promise to observers.
+type Info struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+
+	// Site is the file and line where the promise was created. It is only recorded while reporters
+	// are registered with OnPanic, OnUnhandledRejection or OnLeak.
+	Site string
+
+	// Stack is the stack of the goroutine that created the promise, for diagnosing unhandled rejections.
+	// It is only recorded while functions are registered with OnUnhandledRejection.
+	Stack []byte
+}
+
+// Settlement describes how an observed promise was settled.
+type Settlement struct {
+	Info
+	Status    Status
+	Value     any
+	Reason    error
+	SettledAt time.Time
+}
+
+// Latency returns how long the promise took to settle since it was created.
+func (s Settlement) Latency() time.Duration {
+	return s.SettledAt.Sub(s.CreatedAt)
+}
+
+// Observer is notified of the lifecycle of promises.
+// Methods are called synchronously from the goroutine that creates or settles a promise,
+// so they must be fast and safe for concurrent use.
+type Observer interface {
+	OnCreate(info Info)
+	OnSettle(s Settlement)
+}
+
+// AwaitObserver is implemented by observers that are also notified when a goroutine starts and stops waiting
+// for an observed promise with Await, for example to measure how long callers are blocked.
+type AwaitObserver interface {
+	OnAwaitStart(info Info)
+	OnAwaitEnd(info Info, waited time.Duration, err error)
+}
+
+// WithObserver notifies o of the lifecycle of the promise, in addition to the observers registered with AddObserver.
+func WithObserver(o Observer) Option {
+	return func(opts *options) {
+		opts.observers = append(opts.observers, o)
+	}
+}
+
+var (
+	observers      atomic.Pointer[[]Observer]
+	observersMutex sync.Mutex
+	nextID         atomic.Uint64
+)
+
+// AddObserver registers an observer for promises created from now on and returns a function that removes it.
+// Promises are only observed by the observers registered when they are created, so that an observer
+// removed while a promise is pending is still notified of its settlement, and one added is not.
+func AddObserver(o Observer) (remove func()) {
+	observersMutex.Lock()
+	defer observersMutex.Unlock()
+
+	os := append(loadObservers(), o)
+	observers.Store(&os)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			observersMutex.Lock()
+			defer observersMutex.Unlock()
+
+			current := loadObservers()
+			next := make([]Observer, 0, len(current))
+			removed := false
+			for _, other := range current {
+				if !removed && other == o {
+					removed = true
+					continue
+				}
+
+				next = append(next, other)
+			}
+
+			observers.Store(&next)
+		})
+	}
+}
+
+func loadObservers() []Observer {
+	os := observers.Load()
+	if os == nil {
+		return nil
+	}
+
+	return *os
+}
+
+// observeCreate returns the info of a new promise if it is observed, tracked or reported, or nil otherwise,
+// and the observers it notified, which are the ones to notify of the settlement.
+func observeCreate(opts *options) (*Info, []Observer) {
+	os := observersOf(opts)
+	tracking := opts != nil && opts.tracking
+	reporting := reportersActive()
+	if len(os) == 0 && !tracking && !reporting {
+		return nil, nil
+	}
+
+	info := &Info{ID: nextID.Add(1), CreatedAt: CurrentClock().Now()}
+	if opts != nil {
+		info.Name = opts.name
+	}
+
+	if reporting {
+		info.Site = creationSite()
+	}
+
+	if rejectionReporters.active() {
+		info.Stack = debug.Stack()
+	}
+
+	for _, o := range os {
+		o.OnCreate(*info)
+	}
+
+	if tracking {
+		track(*info)
+	}
+
+	return info, os
+}
+
+func observeSettle(info Info, os []Observer, status Status, value any, reason error) {
+	untrack(info.ID)
+
+	s := Settlement{Info: info, Status: status, Value: value, Reason: reason, SettledAt: CurrentClock().Now()}
+	for _, o := range os {
+		o.OnSettle(s)
+	}
+}
+
+// observersOf returns the registered observers followed by those of the promise with opts, which may be nil.
+func observersOf(opts *options) []Observer {
+	os := loadObservers()
+	if opts != nil && len(opts.observers) > 0 {
+		os = append(os[:len(os):len(os)], opts.observers...)
+	}
+
+	return os
+}
+
+// awaitObservers returns the observers os of a promise that implement AwaitObserver.
+func awaitObservers(os []Observer) []AwaitObserver {
+	var aos []AwaitObserver
+	for _, o := range os {
+		if ao, ok := o.(AwaitObserver); ok {
+			aos = append(aos, ao)
+		}
+	}
+
+	return aos
+}
+
+func observeAwaitStart(os []AwaitObserver, info Info) {
+	for _, o := range os {
+		o.OnAwaitStart(info)
+	}
+}
+
+func observeAwaitEnd(os []AwaitObserver, info Info, start time.Time, err error) {
+	waited := CurrentClock().Now().Sub(start)
+	for _, o := range os {
+		o.OnAwaitEnd(info, waited, err)
+	}
+}
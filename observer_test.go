@@ -0,0 +1,147 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type countingObserver struct {
+	created     int
+	settlements []Settlement
+	mutex       sync.Mutex
+}
+
+func (o *countingObserver) OnCreate(info Info) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.created++
+}
+
+func (o *countingObserver) OnSettle(s Settlement) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.settlements = append(o.settlements, s)
+}
+
+func TestAddObserver(t *testing.T) {
+	ctx := context.Background()
+	o := &countingObserver{}
+	remove := AddObserver(o)
+
+	p1 := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+	p2 := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("something went wrong"))
+	})
+	p1.Await(ctx)
+	p2.Await(ctx)
+	remove()
+
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(3)
+	}).Await(ctx)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.created != 2 {
+		t.Errorf("expected 2 created promises, got %d", o.created)
+	}
+
+	if len(o.settlements) != 2 {
+		t.Fatalf("expected 2 settlements, got %d", len(o.settlements))
+	}
+
+	for _, s := range o.settlements {
+		if s.Status == Fulfilled && s.Value != 1 {
+			t.Errorf("expected value to be 1, got %v", s.Value)
+		}
+
+		if s.Status == Rejected && s.Reason == nil {
+			t.Errorf("expected reason to be non-nil")
+		}
+
+		if s.ID == 0 {
+			t.Errorf("expected id to be assigned")
+		}
+	}
+}
+
+func TestAddObserverWhilePending(t *testing.T) {
+	ctx := context.Background()
+	before, after := &countingObserver{}, &countingObserver{}
+	removeBefore := AddObserver(before)
+	p, resolve, _ := WithResolvers[int]()
+	removeBefore()
+
+	removeAfter := AddObserver(after)
+	defer removeAfter()
+	resolve(1)
+	p.Await(ctx)
+
+	before.mutex.Lock()
+	defer before.mutex.Unlock()
+	after.mutex.Lock()
+	defer after.mutex.Unlock()
+
+	if before.created != 1 || len(before.settlements) != 1 {
+		t.Errorf("expected the observer of the creation to be notified of the settlement, got %d, %d", before.created, len(before.settlements))
+	}
+
+	if len(after.settlements) != 0 {
+		t.Errorf("expected an observer added while the promise was pending not to be notified, got %d", len(after.settlements))
+	}
+}
+
+type awaitingObserver struct {
+	countingObserver
+	started, ended int
+}
+
+func (o *awaitingObserver) OnAwaitStart(info Info) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.started++
+}
+
+func (o *awaitingObserver) OnAwaitEnd(info Info, waited time.Duration, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.ended++
+}
+
+func TestWithObserver(t *testing.T) {
+	ctx := context.Background()
+	o := &awaitingObserver{}
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithObserver(o))
+
+	p.Await(ctx)
+	newResolved(2).Await(ctx)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.created != 1 || len(o.settlements) != 1 {
+		t.Fatalf("expected only the promise to be observed, got %d created and %d settled", o.created, len(o.settlements))
+	}
+
+	if o.settlements[0].Latency() < 0 {
+		t.Errorf("expected a settle latency, got %v", o.settlements[0].Latency())
+	}
+
+	if o.started != 1 || o.ended != 1 {
+		t.Errorf("expected one observed await, got %d started and %d ended", o.started, o.ended)
+	}
+}
@@ -0,0 +1,200 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var (
+	// ErrTimeout is the reason a promise created with WithTimeout is rejected with when it does not settle in time.
+	ErrTimeout = errors.New("promise timed out")
+)
+
+// Option configures a promise.
+type Option func(*options)
+
+type options struct {
+	name       string
+	timeout    time.Duration
+	logger     *slog.Logger
+	failFast   bool
+	scheduler  Scheduler
+	sync       bool
+	tracking   bool
+	middleware []Middleware
+
+	priority    int
+	prioritized bool
+
+	panicHandler func(*PanicError)
+	progress     *progressState
+
+	limit    *PendingLimit
+	admitted bool
+	chaos    *Chaos
+
+	record     *Recording
+	recordSlot int
+	recording  bool
+	replay     *Recording
+
+	orderedCallbacks bool
+
+	idempotencyKey    string
+	idempotencyStore  IdempotencyStore
+	idempotencyWindow time.Duration
+	idempotent        *idempotentCall
+
+	workers int
+
+	disposer any
+	spill    *spillPolicy
+
+	orphanCancel bool
+	loop         *EventLoop
+	osThread     bool
+
+	cancelExecutor context.CancelCauseFunc
+	consumerCancel bool
+	lazy           bool
+
+	observers []Observer
+}
+
+// newOptions applies the package configuration and then opts.
+// It returns nil if there is neither, so that plain promises carry no configuration.
+func newOptions(opts []Option) *options {
+	c := config.Load()
+	if len(opts) == 0 && c == nil {
+		return nil
+	}
+
+	o := &options{}
+	if c != nil {
+		c.apply(o)
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// admit counts the promise against the configured PendingLimit, if any.
+func (o *options) admit() error {
+	if o == nil || o.limit == nil {
+		return nil
+	}
+
+	if err := o.limit.admit(o.name); err != nil {
+		return err
+	}
+
+	o.admitted = true
+	return nil
+}
+
+// direct reports whether the executor can run as is, with no wrapping and no scheduling besides WithSync.
+func (o *options) direct() bool {
+	if o == nil {
+		return true
+	}
+
+	if c := config.Load(); c != nil && c.FailFast {
+		return false
+	}
+
+	return !o.failFast && o.panicHandler == nil && o.timeout == 0 && o.scheduler == nil && !o.tracking &&
+		len(o.middleware) == 0 && o.chaos == nil && !o.orphanCancel && !o.osThread && !o.lazy
+}
+
+func (o *options) release() {
+	if o.admitted {
+		o.limit.release(o.name)
+	}
+}
+
+// WithName names the promise for observers, tracking and logs.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithTimeout rejects the promise with ErrTimeout if it is not settled within d on the package clock.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithLogger logs the settlement of the promise: fulfillments at debug level and rejections at warn level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRecover rejects the promise with a *PanicError if its executor panics, instead of crashing the process.
+// The configured PanicHandler, if any, is called with the error first.
+// Recovering is the default; WithRecover overrides Config.FailFast for a single promise.
+func WithRecover() Option {
+	return func(o *options) {
+		o.failFast = false
+	}
+}
+
+// WithFailFast lets a panic in the executor of the promise crash the process instead of rejecting the promise.
+func WithFailFast() Option {
+	return func(o *options) {
+		o.failFast = true
+	}
+}
+
+// WithScheduler runs the executor of the promise with the scheduler instead of on a new goroutine.
+func WithScheduler(s Scheduler) Option {
+	return func(o *options) {
+		o.scheduler = s
+	}
+}
+
+// WithSync runs the executor of the promise on the calling goroutine before New returns,
+// instead of on a new goroutine. It suits executors that settle immediately or hand off to their own goroutines;
+// an executor that blocks blocks New with it. WithSync takes precedence over WithScheduler.
+func WithSync() Option {
+	return func(o *options) {
+		o.sync = true
+	}
+}
+
+// WithTracking lists the promise in InFlight while it is pending.
+func WithTracking() Option {
+	return func(o *options) {
+		o.tracking = true
+	}
+}
+
+// WithMiddleware wraps the executor of the promise with mw, inside the middleware added with Use.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, mw...)
+	}
+}
+
+func (o *options) logSettle(status Status, reason error) {
+	if o.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("name", o.name)}
+	if status == Rejected {
+		attrs = append(attrs, slog.Any("reason", reason))
+		o.logger.LogAttrs(context.Background(), slog.LevelWarn, "promise rejected", attrs...)
+		return
+	}
+
+	o.logger.LogAttrs(context.Background(), slog.LevelDebug, "promise fulfilled", attrs...)
+}
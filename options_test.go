@@ -0,0 +1,240 @@
+package promises_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestWithName(t *testing.T) {
+	ctx := context.Background()
+	o := &countingObserver{}
+	remove := AddObserver(o)
+	defer remove()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithName("answer"))
+	p.Await(ctx)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if len(o.settlements) != 1 {
+		t.Fatalf("expected 1 settlement, got %d", len(o.settlements))
+	}
+
+	if o.settlements[0].Name != "answer" {
+		t.Errorf("expected name answer, got %q", o.settlements[0].Name)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	}, WithTimeout(10*time.Millisecond))
+
+	_, err := p.Await(ctx)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestWithTimeoutSettledInTime(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithTimeout(time.Hour))
+
+	v, err := p.Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithName("ok"), WithLogger(logger)).Await(ctx)
+	New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("boom"))
+	}, WithName("failed"), WithLogger(logger)).Await(ctx)
+
+	out := buf.String()
+	for _, want := range []string{"level=DEBUG msg=\"promise fulfilled\" name=ok", "level=WARN msg=\"promise rejected\" name=failed reason=boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestWithRecover(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	}, WithRecover())
+
+	_, err := p.Await(ctx)
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected *PanicError, got %v", err)
+	}
+
+	if panicErr.Value != "boom" {
+		t.Errorf("expected panic value boom, got %v", panicErr.Value)
+	}
+
+	if len(panicErr.Stack) == 0 {
+		t.Error("expected a stack trace")
+	}
+}
+
+func TestWithRecoverError(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("cause")
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic(cause)
+	}, WithRecover())
+
+	_, err := p.Await(ctx)
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the panic error to unwrap to cause, got %v", err)
+	}
+}
+
+func TestRecoverByDefault(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	})
+
+	_, err := p.Await(ctx)
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("expected *PanicError with value boom, got %v", err)
+	}
+}
+
+func TestWithFailFast(t *testing.T) {
+	var run func()
+	s := SchedulerFunc(func(r func()) {
+		run = r
+	})
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	}, WithScheduler(s), WithFailFast())
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected the panic to go on, got %v", r)
+			}
+		}()
+
+		run()
+	}()
+
+	if p.State() != Pending {
+		t.Errorf("expected the promise to stay pending, got %v", p.State())
+	}
+}
+
+func TestWithScheduler(t *testing.T) {
+	ctx := context.Background()
+	var runs []func()
+	s := SchedulerFunc(func(run func()) {
+		runs = append(runs, run)
+	})
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithScheduler(s))
+
+	if p.IsSettled() {
+		t.Fatal("expected the executor not to run before the scheduler runs it")
+	}
+
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 scheduled run, got %d", len(runs))
+	}
+
+	runs[0]()
+	v, err := p.Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestWithSync(t *testing.T) {
+	ran := false
+	p := New(func(resolve Resolve[int], reject Reject) {
+		ran = true
+		resolve(1)
+	}, WithSync())
+
+	if !ran || !p.IsFulfilled() {
+		t.Fatal("expected the executor to run before New returns")
+	}
+
+	p = New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	}, WithSync())
+
+	var panicErr *PanicError
+	if !errors.As(p.Reason(), &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("expected *PanicError with value boom, got %v", p.Reason())
+	}
+}
+
+func TestWithSyncMiddleware(t *testing.T) {
+	ran := false
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithSync(), WithMiddleware(func(next func()) func() {
+		return func() {
+			ran = true
+			next()
+		}
+	}))
+
+	if !ran || !p.IsFulfilled() {
+		t.Fatal("expected the wrapped executor to run before New returns")
+	}
+}
+
+func TestWithTracking(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	}, WithName("tracked"), WithTracking())
+
+	infos := InFlight()
+	if len(infos) != 1 || infos[0].Name != "tracked" {
+		t.Fatalf("expected the tracked promise in flight, got %v", infos)
+	}
+
+	close(block)
+	p.Await(ctx)
+
+	if infos := InFlight(); len(infos) != 0 {
+		t.Errorf("expected no promises in flight, got %v", infos)
+	}
+}
@@ -0,0 +1,72 @@
+package promises
+
+import (
+	"errors"
+	"runtime"
+)
+
+var (
+	// ErrOrphaned is the cause a promise created with WithOrphanCancel is canceled with
+	// when it becomes unreachable before it is settled.
+	ErrOrphaned = errors.New("promise orphaned")
+)
+
+var leakReporters reporters
+
+// WithOrphanCancel cancels the promise with ErrOrphaned as the cause if it is garbage collected while still pending,
+// because nobody awaits it or holds a reference to it any more, and reports it to the functions registered with OnLeak.
+// The executor does not keep the promise reachable, so abandoned work is noticed instead of running silently.
+func WithOrphanCancel() Option {
+	return func(o *options) {
+		o.orphanCancel = true
+	}
+}
+
+// OnLeak registers fn to be called with a report whenever a promise created with WithOrphanCancel
+// is canceled because it was orphaned. Report.Reason is the *CancelError. It returns a function that removes fn.
+func OnLeak(fn func(Report)) (remove func()) {
+	return leakReporters.add(fn)
+}
+
+// orphanable returns a handle to the promise q whose methods read the state of q, so that only the handle is held
+// by callers while the executor holds q. Once the handle is garbage collected, q is canceled if it is still pending.
+func orphanable[T any](q *Promise[T]) *Promise[T] {
+	p := &Promise[T]{info: q.info, progress: q.progress, opts: q.opts, source: q}
+	runtime.SetFinalizer(p, func(*Promise[T]) {
+		if !q.Cancel(ErrOrphaned) {
+			return
+		}
+
+		report := Report{Reason: q.Reason()}
+		if q.info != nil {
+			report.Info = *q.info
+		}
+
+		leakReporters.report(report)
+	})
+
+	return p
+}
+
+// pin keeps p reachable until its source is settled if p is a handle made by orphanable, so that a handle whose
+// callbacks or done channel are still waited on is not taken for an orphan. The source drops the handle once it finishes.
+func (p *Promise[T]) pin() {
+	q := p.source
+	if q == nil || q.finished.Load() {
+		return
+	}
+
+	q.holder.Store(p)
+	if q.finished.Load() {
+		q.holder.Store(nil)
+	}
+}
+
+// target returns the promise that holds the state of p, which is p itself unless p is a handle made by orphanable.
+func (p *Promise[T]) target() *Promise[T] {
+	if p.source != nil {
+		return p.source
+	}
+
+	return p
+}
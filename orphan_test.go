@@ -0,0 +1,121 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+// orphan creates a pending promise with WithOrphanCancel and drops it.
+func orphan(name string, block chan struct{}) {
+	New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	}, WithName(name), WithOrphanCancel())
+}
+
+func TestWithOrphanCancel(t *testing.T) {
+	reports := make(chan Report, 16)
+	defer OnLeak(func(r Report) {
+		reports <- r
+	})()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	orphan("orphan", block)
+	r, ok := awaitReport(reports, "orphan", 5*time.Second)
+	if !ok {
+		t.Fatal("expected the orphaned promise to be reported")
+	}
+
+	if !errors.Is(r.Reason, ErrOrphaned) || !errors.Is(r.Reason, context.Canceled) {
+		t.Errorf("expected a cancellation caused by ErrOrphaned, got %v", r.Reason)
+	}
+
+	if !strings.Contains(r.Site, "orphan_test.go:") {
+		t.Errorf("expected the creation site in this file, got %q", r.Site)
+	}
+}
+
+func TestWithOrphanCancelReferenced(t *testing.T) {
+	ctx := context.Background()
+	reports := make(chan Report, 16)
+	defer OnLeak(func(r Report) {
+		reports <- r
+	})()
+
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(2)
+	}, WithName("referenced"), WithOrphanCancel())
+
+	if r, ok := awaitReport(reports, "referenced", 100*time.Millisecond); ok {
+		t.Errorf("expected a referenced promise not to be reported, got %+v", r)
+	}
+
+	if p.State() != Pending {
+		t.Errorf("expected the promise to be pending, got %v", p.State())
+	}
+
+	close(block)
+	if v, err := p.Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected 2, got %v, %v", v, err)
+	}
+
+	if !p.IsFulfilled() || p.Value() != 2 {
+		t.Error("expected the handle to read the settled state")
+	}
+}
+
+// collecting runs the garbage collector repeatedly until the returned function is called.
+func collecting() (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(5 * time.Millisecond):
+				runtime.GC()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func TestWithOrphanCancelAwaited(t *testing.T) {
+	ctx := context.Background()
+	defer collecting()()
+
+	work := func() *Promise[int] {
+		return New(func(resolve Resolve[int], reject Reject) {
+			time.Sleep(100 * time.Millisecond)
+			resolve(1)
+		}, WithOrphanCancel())
+	}
+
+	if v, err := work().Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected an awaited promise to be fulfilled with 1, got %v, %v", v, err)
+	}
+
+	settled := make(chan SettledResult[int], 1)
+	work().OnSettle(func(r SettledResult[int]) { settled <- r })
+	if r := <-settled; r.Status != Fulfilled {
+		t.Errorf("expected a promise with a callback to be fulfilled, got %v", r.Reason)
+	}
+
+	<-work().Done()
+}
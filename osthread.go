@@ -0,0 +1,23 @@
+package promises
+
+import "runtime"
+
+// WithOSThread runs the executor of the promise on a goroutine locked to its OS thread, as required by cgo libraries
+// and graphics contexts that must stay on one thread. The thread is locked only while the executor function runs.
+// If the promise has a scheduler that is an OSThreadScheduler, such as a promisepool.Pool, the executor runs on
+// the scheduler's thread-affine worker instead, so that every such executor shares the same thread.
+func WithOSThread() Option {
+	return func(o *options) {
+		o.osThread = true
+	}
+}
+
+// lockOSThread wraps run so that it runs locked to its OS thread.
+func lockOSThread(run func()) func() {
+	return func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		run()
+	}
+}
@@ -0,0 +1,48 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestWithOSThread(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithOSThread())
+
+	if v, err := p.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+type osThreadScheduler struct {
+	scheduled   int
+	onOSThreads int
+}
+
+func (s *osThreadScheduler) Schedule(run func()) {
+	s.scheduled++
+	run()
+}
+
+func (s *osThreadScheduler) ScheduleOnOSThread(run func()) {
+	s.onOSThreads++
+	run()
+}
+
+func TestWithOSThreadScheduler(t *testing.T) {
+	s := &osThreadScheduler{}
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithScheduler(s), WithOSThread())
+	New(func(resolve Resolve[int], reject Reject) {
+		resolve(2)
+	}, WithScheduler(s))
+
+	if s.onOSThreads != 1 || s.scheduled != 1 {
+		t.Errorf("expected one executor on the OS thread and one scheduled, got %d and %d", s.onOSThreads, s.scheduled)
+	}
+}
@@ -0,0 +1,52 @@
+package promises
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the reason a promise is rejected with when its executor panics and the panic is recovered.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func newPanicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// runRecovered runs executor on a promise without options, rejecting the promise if it panics.
+func runRecovered[T any](info *Info, executor Executor[T], resolve Resolve[T], reject Reject) {
+	defer recoverTo(info, reject)
+	executor(resolve, reject)
+}
+
+// recoverTo, deferred, rejects with a *PanicError if the function it is deferred in panics,
+// unless Config.FailFast is set, in which case the panic goes on after being reported.
+func recoverTo(info *Info, reject Reject) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err := newPanicError(r)
+	if panicReporters.active() {
+		reportPanic(info, err)
+	}
+
+	if c := config.Load(); c != nil && c.FailFast {
+		panic(r)
+	}
+
+	reject(err)
+}
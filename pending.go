@@ -0,0 +1,99 @@
+package promises
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrTooManyPending is the reason a promise is rejected with when it would exceed the configured PendingLimit.
+	ErrTooManyPending = errors.New("too many pending promises")
+)
+
+// OverflowPolicy decides what happens to a promise created beyond a PendingLimit.
+type OverflowPolicy int
+
+const (
+	// OverflowReject rejects the new promise with ErrTooManyPending without running its executor.
+	OverflowReject OverflowPolicy = iota
+	// OverflowBlock blocks New until enough pending promises settle.
+	OverflowBlock
+	// OverflowNotify creates the promise anyway; only OnExceeded is called.
+	OverflowNotify
+)
+
+// PendingLimit caps the number of promises pending at the same time, as a safety net against runaway fan-out.
+// Only promises created with New while the limit is configured are counted.
+type PendingLimit struct {
+	// Max is the number of promises allowed to be pending. Zero means no global limit.
+	Max int
+
+	// PerName caps the pending promises with a given WithName name, in addition to Max.
+	PerName map[string]int
+
+	// Policy decides what happens to a promise created beyond the limit.
+	Policy OverflowPolicy
+
+	// OnExceeded, if set, is called with the name of every promise created beyond the limit, before Policy applies.
+	OnExceeded func(name string)
+}
+
+var (
+	pendingMutex  sync.Mutex
+	pendingCond   = sync.NewCond(&pendingMutex)
+	pendingTotal  int
+	pendingByName = make(map[string]int)
+)
+
+func (l *PendingLimit) exceeded(name string) bool {
+	if l.Max > 0 && pendingTotal >= l.Max {
+		return true
+	}
+
+	max := l.PerName[name]
+	return max > 0 && pendingByName[name] >= max
+}
+
+// admit counts a new promise named name, applying the policy if it is over the limit.
+func (l *PendingLimit) admit(name string) error {
+	pendingMutex.Lock()
+	defer pendingMutex.Unlock()
+
+	if l.exceeded(name) {
+		if l.OnExceeded != nil {
+			pendingMutex.Unlock()
+			l.OnExceeded(name)
+			pendingMutex.Lock()
+		}
+
+		switch l.Policy {
+		case OverflowReject:
+			return ErrTooManyPending
+		case OverflowBlock:
+			for l.exceeded(name) {
+				pendingCond.Wait()
+			}
+		}
+	}
+
+	pendingTotal++
+	if name != "" {
+		pendingByName[name]++
+	}
+
+	return nil
+}
+
+func (l *PendingLimit) release(name string) {
+	pendingMutex.Lock()
+	defer pendingMutex.Unlock()
+
+	pendingTotal--
+	if name != "" {
+		if pendingByName[name]--; pendingByName[name] == 0 {
+			delete(pendingByName, name)
+		}
+	}
+
+	pendingCond.Broadcast()
+}
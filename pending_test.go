@@ -0,0 +1,127 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func blocked(block chan struct{}, opts ...Option) *Promise[int] {
+	return New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	}, opts...)
+}
+
+func TestPendingLimitReject(t *testing.T) {
+	ctx := context.Background()
+	var exceeded []string
+	restore := Configure(Config{PendingLimit: &PendingLimit{
+		Max: 1,
+		OnExceeded: func(name string) {
+			exceeded = append(exceeded, name)
+		},
+	}})
+	defer restore()
+
+	block := make(chan struct{})
+	first := blocked(block)
+
+	if _, err := blocked(block, WithName("second")).Await(ctx); !errors.Is(err, ErrTooManyPending) {
+		t.Errorf("expected ErrTooManyPending, got %v", err)
+	}
+
+	if len(exceeded) != 1 || exceeded[0] != "second" {
+		t.Errorf("expected OnExceeded to be called with second, got %v", exceeded)
+	}
+
+	close(block)
+	if _, err := first.Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := blocked(block).Await(ctx); err != nil {
+		t.Errorf("expected a promise to be allowed once the first settled, got %v", err)
+	}
+}
+
+func TestPendingLimitPerName(t *testing.T) {
+	ctx := context.Background()
+	restore := Configure(Config{PendingLimit: &PendingLimit{
+		PerName: map[string]int{"limited": 1},
+	}})
+	defer restore()
+
+	block := make(chan struct{})
+	defer close(block)
+	blocked(block, WithName("limited"))
+
+	if _, err := blocked(block, WithName("limited")).Await(ctx); !errors.Is(err, ErrTooManyPending) {
+		t.Errorf("expected ErrTooManyPending, got %v", err)
+	}
+
+	other := blocked(block, WithName("other"))
+	if _, ok := other.GetNow(); ok {
+		t.Errorf("expected other names not to be limited")
+	}
+}
+
+func TestPendingLimitBlock(t *testing.T) {
+	ctx := context.Background()
+	restore := Configure(Config{PendingLimit: &PendingLimit{Max: 1, Policy: OverflowBlock}})
+	defer restore()
+
+	block := make(chan struct{})
+	first := blocked(block)
+
+	created := make(chan *Promise[int])
+	go func() {
+		created <- blocked(block)
+	}()
+
+	select {
+	case <-created:
+		t.Fatal("expected New to block while the limit is reached")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(block)
+	if _, err := first.Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (<-created).Await(ctx); err != nil {
+		t.Errorf("expected the blocked promise to be created, got %v", err)
+	}
+}
+
+func TestPendingLimitNotify(t *testing.T) {
+	ctx := context.Background()
+	exceeded := 0
+	restore := Configure(Config{PendingLimit: &PendingLimit{
+		Max:    1,
+		Policy: OverflowNotify,
+		OnExceeded: func(name string) {
+			exceeded++
+		},
+	}})
+	defer restore()
+
+	block := make(chan struct{})
+	first := blocked(block)
+	second := blocked(block)
+	close(block)
+
+	for _, p := range []*Promise[int]{first, second} {
+		if _, err := p.Await(ctx); err != nil {
+			t.Errorf("expected both promises to be created, got %v", err)
+		}
+	}
+
+	if exceeded != 1 {
+		t.Errorf("expected OnExceeded to be called once, got %d", exceeded)
+	}
+}
@@ -0,0 +1,262 @@
+package promises
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Stage is a step of a Pipeline that transforms the output of the previous step.
+type Stage[T any] func(ctx context.Context, v T) (T, error)
+
+// StageOption configures a stage of a Pipeline.
+type StageOption func(*stageOptions)
+
+type stageOptions struct {
+	timeout  time.Duration
+	attempts int
+	backoff  time.Duration
+}
+
+// StageTimeout rejects an attempt of the stage with ErrTimeout if it takes longer than d on the package clock,
+// and cancels the context given to the stage.
+func StageTimeout(d time.Duration) StageOption {
+	return func(o *stageOptions) {
+		o.timeout = d
+	}
+}
+
+// StageRetry runs the stage up to attempts times until it succeeds, waiting backoff on the package clock between attempts.
+func StageRetry(attempts int, backoff time.Duration) StageOption {
+	return func(o *stageOptions) {
+		o.attempts = attempts
+		o.backoff = backoff
+	}
+}
+
+// StageError is the reason a Pipeline is rejected with when one of its stages fails.
+type StageError struct {
+	Pipeline string
+	Stage    string
+	Attempts int
+	Err      error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("pipeline %s: stage %s failed after %d attempts: %v", e.Pipeline, e.Stage, e.Attempts, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline runs stages in sequence, each with its own timeout and retries.
+// Every attempt of a stage runs as a promise named "pipeline/stage", so observers registered with AddObserver
+// receive the duration and the error of each stage and can tell which one is slow or failing.
+type Pipeline[T any] struct {
+	name   string
+	stages []pipelineStage[T]
+}
+
+type pipelineStage[T any] struct {
+	name string
+	fn   Stage[T]
+	opts stageOptions
+	stop func(v T) bool
+}
+
+// NewPipeline creates an empty pipeline named name.
+func NewPipeline[T any](name string) *Pipeline[T] {
+	return &Pipeline[T]{name: name}
+}
+
+// Stage appends a stage named name and returns the pipeline.
+func (p *Pipeline[T]) Stage(name string, fn Stage[T], opts ...StageOption) *Pipeline[T] {
+	s := pipelineStage[T]{name: name, fn: fn, opts: stageOptions{attempts: 1}}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+
+	p.stages = append(p.stages, s)
+	return p
+}
+
+// Branch appends a stage named name that runs then if pred returns true for its input, or otherwise els.
+// els may be nil to pass the input through unchanged. The options apply to whichever stage runs.
+func (p *Pipeline[T]) Branch(name string, pred func(v T) bool, then, els Stage[T], opts ...StageOption) *Pipeline[T] {
+	return p.Stage(name, func(ctx context.Context, v T) (T, error) {
+		if pred(v) {
+			return then(ctx, v)
+		}
+
+		if els == nil {
+			return v, nil
+		}
+
+		return els(ctx, v)
+	}, opts...)
+}
+
+// StopIf ends the pipeline early, fulfilled with the output of the previous stage, if pred returns true for it.
+func (p *Pipeline[T]) StopIf(pred func(v T) bool) *Pipeline[T] {
+	p.stages = append(p.stages, pipelineStage[T]{stop: pred})
+	return p
+}
+
+// Run returns a promise named after the pipeline that runs the stages in order, starting with input,
+// and is fulfilled with the output of the last stage. It is rejected with a *StageError at the first stage that fails.
+func (p *Pipeline[T]) Run(ctx context.Context, input T) *Promise[T] {
+	stages := append([]pipelineStage[T](nil), p.stages...)
+	return New(func(resolve Resolve[T], reject Reject) {
+		v := input
+		for _, s := range stages {
+			if s.stop != nil {
+				if s.stop(v) {
+					break
+				}
+
+				continue
+			}
+
+			out, attempts, err := runStage(ctx, p.name+"/"+s.name, s.opts, s.fn, v)
+			if err != nil {
+				reject(&StageError{Pipeline: p.name, Stage: s.name, Attempts: attempts, Err: err})
+				return
+			}
+
+			v = out
+		}
+
+		resolve(v)
+	}, WithName(p.name))
+}
+
+// Step is a stage of Pipe2, Pipe3 or Pipe4, which unlike a Stage may change the type of the value.
+type Step[In, Out any] struct {
+	name string
+	fn   func(ctx context.Context, v In) (Out, error)
+	opts stageOptions
+}
+
+// NewStep creates a step named name that runs fn with the stage options.
+func NewStep[In, Out any](name string, fn func(ctx context.Context, v In) (Out, error), opts ...StageOption) Step[In, Out] {
+	s := Step[In, Out]{name: name, fn: fn, opts: stageOptions{attempts: 1}}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+
+	return s
+}
+
+func (s Step[In, Out]) run(ctx context.Context, pipeline string, v In) (Out, error) {
+	out, attempts, err := runStage(ctx, pipeline+"/"+s.name, s.opts, s.fn, v)
+	if err != nil {
+		return out, &StageError{Pipeline: pipeline, Stage: s.name, Attempts: attempts, Err: err}
+	}
+
+	return out, nil
+}
+
+// Pipe2 returns a promise named name that runs the steps in order, starting with input,
+// and is fulfilled with the output of the last step. It is rejected with a *StageError at the first step that fails,
+// and the steps after it do not run.
+func Pipe2[A, B, C any](ctx context.Context, name string, input A, s1 Step[A, B], s2 Step[B, C]) *Promise[C] {
+	return New(func(resolve Resolve[C], reject Reject) {
+		b, err := s1.run(ctx, name, input)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		settleWith(resolve, reject)(s2.run(ctx, name, b))
+	}, WithName(name))
+}
+
+// Pipe3 is Pipe2 with three steps.
+func Pipe3[A, B, C, D any](ctx context.Context, name string, input A, s1 Step[A, B], s2 Step[B, C], s3 Step[C, D]) *Promise[D] {
+	return New(func(resolve Resolve[D], reject Reject) {
+		b, err := s1.run(ctx, name, input)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		c, err := s2.run(ctx, name, b)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		settleWith(resolve, reject)(s3.run(ctx, name, c))
+	}, WithName(name))
+}
+
+// Pipe4 is Pipe2 with four steps.
+func Pipe4[A, B, C, D, E any](ctx context.Context, name string, input A, s1 Step[A, B], s2 Step[B, C], s3 Step[C, D], s4 Step[D, E]) *Promise[E] {
+	return New(func(resolve Resolve[E], reject Reject) {
+		b, err := s1.run(ctx, name, input)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		c, err := s2.run(ctx, name, b)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		d, err := s3.run(ctx, name, c)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		settleWith(resolve, reject)(s4.run(ctx, name, d))
+	}, WithName(name))
+}
+
+// runStage runs fn until an attempt succeeds, the attempts run out or ctx is done.
+// It returns the output and the number of attempts made.
+func runStage[In, Out any](ctx context.Context, name string, opts stageOptions, fn func(context.Context, In) (Out, error), v In) (Out, int, error) {
+	for attempt := 1; ; attempt++ {
+		out, err := stageAttempt(ctx, name, opts, fn, v).Await(ctx)
+		if err == nil {
+			return out, attempt, nil
+		}
+
+		if attempt >= opts.attempts || ctx.Err() != nil {
+			return out, attempt, err
+		}
+
+		if opts.backoff > 0 {
+			if _, err := Delay(opts.backoff).Await(ctx); err != nil {
+				return out, attempt, err
+			}
+		}
+	}
+}
+
+func stageAttempt[In, Out any](ctx context.Context, name string, so stageOptions, fn func(context.Context, In) (Out, error), v In) *Promise[Out] {
+	ctx, cancel := context.WithCancel(ctx)
+	opts := []Option{WithName(name)}
+	if so.timeout > 0 {
+		opts = append(opts, WithTimeout(so.timeout))
+	}
+
+	p := New(func(resolve Resolve[Out], reject Reject) {
+		out, err := fn(ctx, v)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(out)
+	}, opts...)
+
+	p.OnSettle(func(SettledResult[Out]) {
+		cancel()
+	})
+
+	return p
+}
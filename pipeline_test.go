@@ -0,0 +1,163 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func double(ctx context.Context, v int) (int, error) {
+	return v * 2, nil
+}
+
+func TestPipeline(t *testing.T) {
+	o := &countingObserver{}
+	defer AddObserver(o)()
+
+	v, err := NewPipeline[int]("math").
+		Stage("double", double).
+		Stage("increment", func(ctx context.Context, v int) (int, error) {
+			return v + 1, nil
+		}).
+		Run(context.Background(), 3).
+		Await(context.Background())
+	if err != nil || v != 7 {
+		t.Fatalf("expected 7, got %v, %v", v, err)
+	}
+
+	names := map[string]bool{}
+	o.mutex.Lock()
+	for _, s := range o.settlements {
+		names[s.Name] = true
+	}
+	o.mutex.Unlock()
+
+	for _, name := range []string{"math", "math/double", "math/increment"} {
+		if !names[name] {
+			t.Errorf("expected a settlement of %s, got %v", name, names)
+		}
+	}
+}
+
+func TestPipelineStageRetry(t *testing.T) {
+	attempts := 0
+	flaky := func(ctx context.Context, v int) (int, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, errors.New("flaky")
+		}
+
+		return v, nil
+	}
+
+	v, err := NewPipeline[int]("retry").
+		Stage("flaky", flaky, StageRetry(3, time.Millisecond)).
+		Run(context.Background(), 1).
+		Await(context.Background())
+	if err != nil || v != 1 || attempts != 3 {
+		t.Errorf("expected 1 after 3 attempts, got %v, %v after %d", v, err, attempts)
+	}
+}
+
+func TestPipelineStageTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	slow := func(ctx context.Context, v int) (int, error) {
+		<-ctx.Done()
+		close(canceled)
+		return 0, ctx.Err()
+	}
+
+	_, err := NewPipeline[int]("timeout").
+		Stage("double", double).
+		Stage("slow", slow, StageTimeout(10*time.Millisecond)).
+		Stage("never", func(ctx context.Context, v int) (int, error) {
+			t.Error("expected stages after a failed one not to run")
+			return v, nil
+		}).
+		Run(context.Background(), 1).
+		Await(context.Background())
+
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != "slow" || stageErr.Attempts != 1 || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected the slow stage to time out, got %v", err)
+	}
+
+	<-canceled
+}
+
+func TestPipelineBranch(t *testing.T) {
+	negate := func(ctx context.Context, v int) (int, error) {
+		return -v, nil
+	}
+
+	p := NewPipeline[int]("branch").
+		Branch("sign", func(v int) bool { return v < 0 }, negate, nil).
+		Branch("parity", func(v int) bool { return v%2 == 0 }, double, negate)
+
+	for input, want := range map[int]int{-2: 4, 2: 4, 3: -3, -3: -3} {
+		if v, err := p.Run(context.Background(), input).Await(context.Background()); err != nil || v != want {
+			t.Errorf("expected %d for %d, got %v, %v", want, input, v, err)
+		}
+	}
+}
+
+func TestPipelineStopIf(t *testing.T) {
+	p := NewPipeline[int]("stop").
+		Stage("double", double).
+		StopIf(func(v int) bool { return v > 10 }).
+		Stage("double", double)
+
+	if v, _ := p.Run(context.Background(), 2).Await(context.Background()); v != 8 {
+		t.Errorf("expected the pipeline to run to the end, got %v", v)
+	}
+
+	if v, _ := p.Run(context.Background(), 6).Await(context.Background()); v != 12 {
+		t.Errorf("expected the pipeline to stop early, got %v", v)
+	}
+}
+
+func TestPipe3(t *testing.T) {
+	ctx := context.Background()
+	v, err := Pipe3(ctx, "convert", 21,
+		NewStep("double", double),
+		NewStep("format", func(ctx context.Context, v int) (string, error) {
+			return strconv.Itoa(v), nil
+		}),
+		NewStep("length", func(ctx context.Context, s string) (int, error) {
+			return len(s), nil
+		}),
+	).Await(ctx)
+	if err != nil || v != 2 {
+		t.Errorf("expected 2, got %v, %v", v, err)
+	}
+}
+
+func TestPipe2ShortCircuit(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+	attempts := 0
+	ran := false
+	_, err := Pipe2(ctx, "short", 1,
+		NewStep("fail", func(ctx context.Context, v int) (string, error) {
+			attempts++
+			return "", boom
+		}, StageRetry(2, time.Millisecond)),
+		NewStep("never", func(ctx context.Context, s string) (int, error) {
+			ran = true
+			return 0, nil
+		}),
+	).Await(ctx)
+
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != "fail" || stageErr.Attempts != 2 || !errors.Is(err, boom) {
+		t.Errorf("expected a *StageError of fail after 2 attempts wrapping boom, got %v", err)
+	}
+
+	if attempts != 2 || ran {
+		t.Errorf("expected 2 attempts and the next step not to run, got %d, %v", attempts, ran)
+	}
+}
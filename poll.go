@@ -0,0 +1,29 @@
+package promises
+
+import (
+	"context"
+	"time"
+)
+
+// Poll returns a promise that is fulfilled once cond reports true.
+// cond is checked immediately and then every interval on the package clock.
+// If ctx is done first, the promise is rejected with the context error.
+func Poll(ctx context.Context, interval time.Duration, cond func() bool, opts ...Option) *Promise[struct{}] {
+	return New(func(resolve Resolve[struct{}], reject Reject) {
+		for {
+			if cond() {
+				resolve(struct{}{})
+				return
+			}
+
+			timer := CurrentClock().NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				reject(ctx.Err())
+				return
+			case <-timer.C():
+			}
+		}
+	}, opts...)
+}
@@ -0,0 +1,39 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestPoll(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	_, err := Poll(ctx, time.Millisecond, func() bool {
+		return calls.Add(1) == 3
+	}).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestPollCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := Poll(ctx, time.Millisecond, func() bool {
+		return false
+	}).Await(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to be context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Prefetch warms up promises by calling factory for every key in the background, such as Cache.Get,
+// so that later lookups of the keys find them already settled or in flight.
+// At most limit of the created promises are pending at a time; a limit of zero or less means no limit.
+// The returned promise is fulfilled once every created promise is settled, or rejected with the joined reasons
+// of those that were rejected, so that startup can optionally await the warm-up.
+func Prefetch[K, V any](keys []K, factory func(key K) *Promise[V], limit int) *Promise[struct{}] {
+	return New(func(resolve Resolve[struct{}], reject Reject) {
+		if limit <= 0 || limit > len(keys) {
+			limit = len(keys)
+		}
+
+		slots := make(chan struct{}, limit)
+		errs := make([]error, len(keys))
+		var wg sync.WaitGroup
+		wg.Add(len(keys))
+		for i, key := range keys {
+			slots <- struct{}{}
+			p := factory(key)
+			go func(i int) {
+				defer wg.Done()
+
+				_, errs[i] = p.Await(context.Background())
+				<-slots
+			}(i)
+		}
+
+		wg.Wait()
+		if err := errors.Join(errs...); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(struct{}{})
+	})
+}
+
+// Prefetch warms up the cache by getting the keys in the background, with at most limit fetches in flight at a time.
+// See the Prefetch function.
+func (c *Cache[K, V]) Prefetch(keys []K, limit int) *Promise[struct{}] {
+	return Prefetch(keys, c.Get, limit)
+}
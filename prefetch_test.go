@@ -0,0 +1,60 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+	var inFlight, maxInFlight atomic.Int32
+	c := NewCache(func(ctx context.Context, key int) (int, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+
+		return key * 2, nil
+	}, CacheOptions[int]{})
+
+	keys := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	if _, err := c.Prefetch(keys, 2).Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if max := maxInFlight.Load(); max > 2 {
+		t.Errorf("expected at most 2 fetches in flight, got %d", max)
+	}
+
+	for _, key := range keys {
+		if v, ok := c.Get(key).GetNow(); !ok || v != key*2 {
+			t.Errorf("expected %d to be warm, got %v, %v", key, v, ok)
+		}
+	}
+}
+
+func TestPrefetchRejected(t *testing.T) {
+	reason := errors.New("reason")
+	factory := func(key int) *Promise[int] {
+		return New(func(resolve Resolve[int], reject Reject) {
+			if key == 2 {
+				reject(reason)
+				return
+			}
+
+			resolve(key)
+		})
+	}
+
+	if _, err := Prefetch([]int{1, 2, 3}, factory, 0).Await(context.Background()); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+}
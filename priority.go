@@ -0,0 +1,143 @@
+package promises
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// WithPriority gives the executor of the promise a priority, higher running first, if its scheduler is a
+// PrioritizedScheduler such as a PriorityScheduler. Other schedulers ignore it. Executors without one have priority 0.
+func WithPriority(priority int) Option {
+	return func(o *options) {
+		o.priority = priority
+		o.prioritized = true
+	}
+}
+
+// PrioritizedScheduler is a Scheduler that also takes the priority of executors into account,
+// for promises created with WithPriority.
+type PrioritizedScheduler interface {
+	Scheduler
+	SchedulePriority(run func(), priority int)
+}
+
+// PriorityScheduler runs executors on a fixed number of workers, taking the queued executor with the highest
+// priority first and executors of the same priority in the order they were scheduled.
+// Executors that block hold a worker, so give background work a lower priority rather than more workers.
+type PriorityScheduler struct {
+	queue   priorityQueue
+	seq     uint64
+	stopped bool
+	workers sync.WaitGroup
+	mutex   sync.Mutex
+	cond    *sync.Cond
+}
+
+// NewPriorityScheduler starts a PriorityScheduler with workers workers, or one if workers is less than one.
+func NewPriorityScheduler(workers int) *PriorityScheduler {
+	s := &PriorityScheduler{}
+	s.cond = sync.NewCond(&s.mutex)
+	workers = max(workers, 1)
+	s.workers.Add(workers)
+	for range workers {
+		go s.work()
+	}
+
+	return s
+}
+
+// Schedule queues run with priority 0.
+func (s *PriorityScheduler) Schedule(run func()) {
+	s.SchedulePriority(run, 0)
+}
+
+// SchedulePriority queues run with priority. After Stop, run starts on a new goroutine instead.
+func (s *PriorityScheduler) SchedulePriority(run func(), priority int) {
+	s.mutex.Lock()
+	if s.stopped {
+		s.mutex.Unlock()
+		go run()
+		return
+	}
+
+	s.seq++
+	heap.Push(&s.queue, priorityItem{run: run, priority: priority, seq: s.seq})
+	s.mutex.Unlock()
+	s.cond.Signal()
+}
+
+// Len returns the number of executors waiting for a worker.
+func (s *PriorityScheduler) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.queue.Len()
+}
+
+// Stop lets the workers run the executors already queued and waits for them to exit.
+func (s *PriorityScheduler) Stop() {
+	s.mutex.Lock()
+	s.stopped = true
+	s.mutex.Unlock()
+	s.cond.Broadcast()
+
+	s.workers.Wait()
+}
+
+func (s *PriorityScheduler) work() {
+	defer s.workers.Done()
+
+	for {
+		s.mutex.Lock()
+		for s.queue.Len() == 0 && !s.stopped {
+			s.cond.Wait()
+		}
+
+		if s.queue.Len() == 0 {
+			s.mutex.Unlock()
+			return
+		}
+
+		item := heap.Pop(&s.queue).(priorityItem)
+		s.mutex.Unlock()
+
+		item.run()
+	}
+}
+
+type priorityItem struct {
+	run      func()
+	priority int
+	seq      uint64
+}
+
+// priorityQueue is a heap of executors ordered by priority, then by the order they were scheduled.
+type priorityQueue []priorityItem
+
+func (q priorityQueue) Len() int {
+	return len(q)
+}
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q priorityQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *priorityQueue) Push(x any) {
+	*q = append(*q, x.(priorityItem))
+}
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	old[len(old)-1] = priorityItem{}
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,65 @@
+package promises_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestPriorityScheduler(t *testing.T) {
+	ctx := context.Background()
+	s := NewPriorityScheduler(1)
+	defer s.Stop()
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+	first := New(func(resolve Resolve[int], reject Reject) {
+		close(started)
+		<-block
+		resolve(0)
+	}, WithScheduler(s))
+	<-started
+
+	var order []string
+	newPromise := func(name string, opts ...Option) *Promise[int] {
+		return New(func(resolve Resolve[int], reject Reject) {
+			order = append(order, name)
+			resolve(0)
+		}, append(opts, WithScheduler(s))...)
+	}
+
+	promises := []*Promise[int]{
+		newPromise("background", WithPriority(-1)),
+		newPromise("default"),
+		newPromise("user", WithPriority(10)),
+		newPromise("user2", WithPriority(10)),
+	}
+
+	if s.Len() != 4 {
+		t.Fatalf("expected 4 queued executors, got %d", s.Len())
+	}
+
+	close(block)
+	if _, err := All(ctx, append(promises, first)...).Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"user", "user2", "default", "background"}; !slices.Equal(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestPrioritySchedulerStop(t *testing.T) {
+	ctx := context.Background()
+	s := NewPriorityScheduler(2)
+	s.Stop()
+
+	v, err := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithScheduler(s), WithPriority(1)).Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected an executor scheduled after Stop to run, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,204 @@
+package promises
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Progress describes how far an operation has come, such as bytes uploaded out of a total.
+type Progress struct {
+	Completed float64
+	Total     float64
+}
+
+// Fraction returns the completed fraction between 0 and 1, or 0 if the total is unknown.
+func (p Progress) Fraction() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+
+	f := p.Completed / p.Total
+	if f > 1 {
+		return 1
+	}
+
+	return f
+}
+
+// ProgressExecutor is an Executor that can also report progress.
+type ProgressExecutor[T any] func(resolve Resolve[T], reject Reject, report func(Progress))
+
+type progressState struct {
+	finished    *atomic.Bool
+	last        Progress
+	reported    bool
+	subscribers []*func(Progress)
+	mutex       sync.Mutex
+}
+
+// NewWithProgress creates a promise whose executor reports progress to the subscribers of the promise.
+func NewWithProgress[T any](executor ProgressExecutor[T], opts ...Option) *Promise[T] {
+	state := &progressState{}
+	return New(func(resolve Resolve[T], reject Reject) {
+		executor(resolve, reject, state.report)
+	}, append(opts, withProgress(state))...)
+}
+
+func withProgress(state *progressState) Option {
+	return func(o *options) {
+		o.progress = state
+	}
+}
+
+// OnProgress calls fn with every progress report of the promise, starting with the latest one if any,
+// until the returned function is called, for example once a progress bar is hidden.
+// It does nothing for promises that do not report progress.
+// fn is called synchronously from the reporting goroutine, so it must be fast.
+func (p *Promise[T]) OnProgress(fn func(Progress)) (remove func()) {
+	if p.progress == nil {
+		return func() {}
+	}
+
+	entry := &fn
+	p.progress.mutex.Lock()
+	p.progress.subscribers = append(p.progress.subscribers, entry)
+	last, reported := p.progress.last, p.progress.reported
+	p.progress.mutex.Unlock()
+
+	if reported {
+		fn(last)
+	}
+
+	return func() {
+		p.progress.remove(entry)
+	}
+}
+
+// Progress returns a channel that receives progress reports of the promise and is closed when the promise is settled.
+// Reports are not queued: a slow receiver only sees the latest one.
+func (p *Promise[T]) Progress() <-chan Progress {
+	ch := make(chan Progress, 1)
+	var mutex sync.Mutex
+	closed := false
+
+	p.OnProgress(func(pr Progress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- pr
+	})
+
+	go func() {
+		<-p.doneChan()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		closed = true
+		close(ch)
+	}()
+
+	return ch
+}
+
+// report notifies the subscribers unless the promise is already settled.
+func (s *progressState) report(pr Progress) {
+	if s.finished != nil && s.finished.Load() {
+		return
+	}
+
+	s.mutex.Lock()
+	s.last = pr
+	s.reported = true
+	subscribers := s.subscribers
+	s.mutex.Unlock()
+
+	for _, fn := range subscribers {
+		(*fn)(pr)
+	}
+}
+
+// remove unsubscribes fn. Reports being delivered may still reach it.
+func (s *progressState) remove(fn *func(Progress)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	next := make([]*func(Progress), 0, len(s.subscribers))
+	for _, other := range s.subscribers {
+		if other != fn {
+			next = append(next, other)
+		}
+	}
+
+	s.subscribers = next
+}
+
+// progressAggregate combines the progress of several promises into one fraction, counting completed promises as whole.
+type progressAggregate struct {
+	state     *progressState
+	fractions []float64
+	mutex     sync.Mutex
+}
+
+// aggregateProgress subscribes to the progress of promises.
+// It returns nil if none of them reports progress, so that plain aggregates carry no progress state.
+func aggregateProgress[T any](promises []*Promise[T]) *progressAggregate {
+	tracked := false
+	for _, p := range promises {
+		if p.progress != nil {
+			tracked = true
+			break
+		}
+	}
+
+	if !tracked {
+		return nil
+	}
+
+	a := &progressAggregate{state: &progressState{}, fractions: make([]float64, len(promises))}
+	for i, p := range promises {
+		i := i
+		p.OnProgress(func(pr Progress) {
+			a.update(i, pr.Fraction())
+		})
+	}
+
+	return a
+}
+
+// options returns the options that attach the aggregate progress to a promise.
+func (a *progressAggregate) options() []Option {
+	if a == nil {
+		return nil
+	}
+
+	return []Option{withProgress(a.state)}
+}
+
+// complete marks the promise at index i as completed.
+func (a *progressAggregate) complete(i int) {
+	if a != nil {
+		a.update(i, 1)
+	}
+}
+
+func (a *progressAggregate) update(i int, f float64) {
+	a.mutex.Lock()
+	a.fractions[i] = f
+	var completed float64
+	for _, f := range a.fractions {
+		completed += f
+	}
+	a.mutex.Unlock()
+
+	a.state.report(Progress{Completed: completed, Total: float64(len(a.fractions))})
+}
@@ -0,0 +1,136 @@
+package promises_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type progressLog struct {
+	reports []Progress
+	mutex   sync.Mutex
+}
+
+func (l *progressLog) add(pr Progress) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.reports = append(l.reports, pr)
+}
+
+func (l *progressLog) get() []Progress {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]Progress(nil), l.reports...)
+}
+
+func TestNewWithProgress(t *testing.T) {
+	ctx := context.Background()
+	step := make(chan struct{})
+	p := NewWithProgress(func(resolve Resolve[int], reject Reject, report func(Progress)) {
+		<-step
+		report(Progress{Completed: 1, Total: 2})
+		<-step
+		report(Progress{Completed: 2, Total: 2})
+		resolve(1)
+		report(Progress{Completed: 3, Total: 2})
+	})
+
+	log := &progressLog{}
+	p.OnProgress(log.add)
+	ch := p.Progress()
+
+	step <- struct{}{}
+	if pr := <-ch; pr.Fraction() != 0.5 {
+		t.Errorf("expected half progress, got %v", pr)
+	}
+
+	step <- struct{}{}
+	p.Await(ctx)
+
+	var last Progress
+	for pr := range ch {
+		last = pr
+	}
+
+	if last.Fraction() != 1 {
+		t.Errorf("expected the channel to end with full progress, got %v", last)
+	}
+
+	if got := log.get(); len(got) != 2 {
+		t.Errorf("expected 2 reports before settling, got %v", got)
+	}
+}
+
+func TestProgressWithoutReports(t *testing.T) {
+	ch := newResolved(1).Progress()
+	if _, ok := <-ch; ok {
+		t.Error("expected the channel to be closed without reports")
+	}
+}
+
+func TestAllProgress(t *testing.T) {
+	ctx := context.Background()
+	step := make(chan struct{})
+	reporting := NewWithProgress(func(resolve Resolve[int], reject Reject, report func(Progress)) {
+		<-step
+		report(Progress{Completed: 1, Total: 2})
+		<-step
+		resolve(1)
+	})
+	plainStep := make(chan struct{})
+	plain := New(func(resolve Resolve[int], reject Reject) {
+		<-plainStep
+		resolve(2)
+	})
+
+	p := All(ctx, reporting, plain)
+	log := &progressLog{}
+	p.OnProgress(log.add)
+
+	step <- struct{}{}
+	step <- struct{}{}
+	close(plainStep)
+	p.Await(ctx)
+
+	reports := log.get()
+	if len(reports) == 0 {
+		t.Fatal("expected progress reports")
+	}
+
+	if f := reports[0].Fraction(); f != 0.25 {
+		t.Errorf("expected the first report to be a quarter, got %v", f)
+	}
+
+	if f := reports[len(reports)-1].Fraction(); f != 1 {
+		t.Errorf("expected the last report to be complete, got %v", f)
+	}
+}
+
+func TestOnProgressRemove(t *testing.T) {
+	ctx := context.Background()
+	step := make(chan struct{})
+	p := NewWithProgress(func(resolve Resolve[int], reject Reject, report func(Progress)) {
+		<-step
+		report(Progress{Completed: 1, Total: 1})
+		resolve(1)
+	})
+
+	kept := &progressLog{}
+	removed := &progressLog{}
+	p.OnProgress(kept.add)
+	p.OnProgress(removed.add)()
+
+	step <- struct{}{}
+	p.Await(ctx)
+	if len(kept.get()) != 1 {
+		t.Errorf("expected one report, got %v", kept.get())
+	}
+
+	if reports := removed.get(); len(reports) != 0 {
+		t.Errorf("expected no reports after removal, got %v", reports)
+	}
+}
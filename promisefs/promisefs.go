@@ -0,0 +1,96 @@
+// Package promisefs provides promise-based helpers for watching the filesystem.
+package promisefs
+
+import (
+	"context"
+	"iter"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oneofthezombies/promises"
+)
+
+// Event is a filesystem change notification.
+type Event = fsnotify.Event
+
+// WatchOnce watches the path and returns a promise that is fulfilled with the first event on it.
+// If ctx is done first, the promise is rejected with the context error.
+// The watcher is closed once the promise is settled, including when it is canceled with Cancel.
+func WatchOnce(ctx context.Context, path string) *promises.Promise[Event] {
+	w, err := newWatcher(path)
+
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[Event], reject promises.Reject) {
+		if err != nil {
+			reject(err)
+			return
+		}
+		defer w.Close()
+
+		select {
+		case <-ctx.Done():
+			reject(ctx.Err())
+		case e, ok := <-w.Events:
+			if !ok {
+				reject(fsnotify.ErrClosed)
+				return
+			}
+
+			resolve(e)
+		case err := <-w.Errors:
+			reject(err)
+		}
+	})
+}
+
+// Watch watches the path and returns an iterator over its events, each with its index, as promises.Stream does.
+// The watcher is created right away, so that no event is missed before the iteration starts, and closed when
+// the iteration stops or ctx is done; range over the iterator once, or cancel ctx, to release it.
+// A failure of the watcher, including when the path cannot be watched, is yielded as the Err of the last result.
+func Watch(ctx context.Context, path string) iter.Seq2[int, promises.Result[Event]] {
+	w, err := newWatcher(path)
+	if err != nil {
+		return func(yield func(int, promises.Result[Event]) bool) {
+			yield(0, promises.Result[Event]{Err: err})
+		}
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		w.Close()
+	})
+
+	return func(yield func(int, promises.Result[Event]) bool) {
+		defer w.Close()
+		defer stop()
+
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-w.Errors:
+				if ok && err != nil {
+					yield(i, promises.Result[Event]{Err: err})
+				}
+
+				return
+			case e, ok := <-w.Events:
+				if !ok || !yield(i, promises.Result[Event]{Value: e}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// newWatcher is called before the executor runs so that no event is missed between creation and watching.
+func newWatcher(path string) (*fsnotify.Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.Add(path); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
@@ -0,0 +1,89 @@
+package promisefs_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises/promisefs"
+)
+
+func TestWatchOnce(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := WatchOnce(ctx, dir)
+
+	name := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(name, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	e, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if e.Name != name {
+		t.Errorf("expected name to be %s, got %s", name, e.Name)
+	}
+}
+
+func TestWatchOnceMissingPath(t *testing.T) {
+	ctx := context.Background()
+	p := WatchOnce(ctx, filepath.Join(t.TempDir(), "missing"))
+
+	_, err := p.Await(ctx)
+	if err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
+
+func TestWatchOnceCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := WatchOnce(ctx, t.TempDir())
+	cancel()
+
+	_, err := p.Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	events := Watch(ctx, dir)
+	name := filepath.Join(dir, "a")
+	if err := os.WriteFile(name, nil, 0o644); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	for i, r := range events {
+		if i != 0 || r.Err != nil || r.Value.Name != name {
+			t.Errorf("expected the first event to be on %s, got %d, %v", name, i, r)
+		}
+
+		break
+	}
+
+	if ctx.Err() != nil {
+		t.Error("expected an event")
+	}
+}
+
+func TestWatchMissingPath(t *testing.T) {
+	ctx := context.Background()
+	var errs []error
+	for _, r := range Watch(ctx, filepath.Join(t.TempDir(), "missing")) {
+		errs = append(errs, r.Err)
+	}
+
+	if len(errs) != 1 || errs[0] == nil {
+		t.Errorf("expected a single error, got %v", errs)
+	}
+}
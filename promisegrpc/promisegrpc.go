@@ -0,0 +1,107 @@
+// Package promisegrpc adapts gRPC client calls to promises.
+//
+// The helpers are written against the shapes of generated gRPC client code rather than the grpc package itself,
+// so they work with any version of google.golang.org/grpc without adding it as a dependency.
+package promisegrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Unary calls a unary method and returns a promise that is fulfilled with its response.
+// call is typically a method value of a generated client, such as client.GetUser.
+// Canceling the promise with Cancel cancels the context of the call.
+func Unary[Req, Resp, Opt any](ctx context.Context, call func(context.Context, Req, ...Opt) (Resp, error), req Req, opts ...Opt) *promises.Promise[Resp] {
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[Resp], reject promises.Reject) {
+		res, err := call(ctx, req, opts...)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(res)
+	})
+}
+
+// UnaryInterceptor returns a unary client interceptor that runs every call as a promise named after its method,
+// so that the observers registered with promises.AddObserver, or passed in opts with promises.WithObserver,
+// are notified of the lifecycle of every call. Conn, Opt and Invoker are the grpc types, as in
+//
+//	grpc.WithUnaryInterceptor(promisegrpc.UnaryInterceptor[*grpc.ClientConn, grpc.CallOption, grpc.UnaryInvoker]())
+//
+// The call runs on the goroutine of the caller, and the options configure its promise as in promises.New.
+func UnaryInterceptor[Conn, Opt any, Invoker ~func(context.Context, string, any, any, Conn, ...Opt) error](opts ...promises.Option) func(ctx context.Context, method string, req, reply any, cc Conn, invoker Invoker, callOpts ...Opt) error {
+	return func(ctx context.Context, method string, req, reply any, cc Conn, invoker Invoker, callOpts ...Opt) error {
+		promiseOpts := append([]promises.Option{promises.WithName(method), promises.WithSync()}, opts...)
+		p := promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+			if err := invoker(ctx, method, req, reply, cc, callOpts...); err != nil {
+				reject(err)
+				return
+			}
+
+			resolve(struct{}{})
+		}, promiseOpts...)
+
+		_, err := p.Await(ctx)
+		return err
+	}
+}
+
+// Receiver is implemented by generated server-streaming client streams.
+type Receiver[Resp any] interface {
+	Recv() (Resp, error)
+}
+
+// Stream receives every message from a server-streaming call and relays it on the returned channel.
+// The channel is closed when the stream ends. The returned promise is fulfilled when the stream ends with io.EOF
+// and rejected with the stream error or the context error otherwise.
+func Stream[Resp any](ctx context.Context, r Receiver[Resp]) (<-chan Resp, *promises.Promise[struct{}]) {
+	messages := make(chan Resp)
+	p := promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+		defer close(messages)
+
+		for {
+			res, err := r.Recv()
+			if errors.Is(err, io.EOF) {
+				resolve(struct{}{})
+				return
+			}
+
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				reject(ctx.Err())
+				return
+			case messages <- res:
+			}
+		}
+	})
+
+	return messages, p
+}
+
+// Collect receives every message from a server-streaming call and returns a promise that is fulfilled with all of them.
+func Collect[Resp any](ctx context.Context, r Receiver[Resp]) *promises.Promise[[]Resp] {
+	return promises.New(func(resolve promises.Resolve[[]Resp], reject promises.Reject) {
+		var results []Resp
+		messages, done := Stream(ctx, r)
+		for res := range messages {
+			results = append(results, res)
+		}
+
+		if _, err := done.Await(ctx); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(results)
+	})
+}
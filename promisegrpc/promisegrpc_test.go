@@ -0,0 +1,173 @@
+package promisegrpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisegrpc"
+)
+
+type callOption struct{}
+
+type getUserRequest struct {
+	ID int
+}
+
+type user struct {
+	Name string
+}
+
+type fakeClient struct{}
+
+func (fakeClient) GetUser(ctx context.Context, in *getUserRequest, opts ...callOption) (*user, error) {
+	if in.ID != 1 {
+		return nil, errors.New("not found")
+	}
+
+	return &user{Name: "alice"}, nil
+}
+
+type fakeStream struct {
+	names []string
+	err   error
+}
+
+func (s *fakeStream) Recv() (*user, error) {
+	if len(s.names) == 0 {
+		return nil, s.err
+	}
+
+	name := s.names[0]
+	s.names = s.names[1:]
+	return &user{Name: name}, nil
+}
+
+func TestUnary(t *testing.T) {
+	ctx := context.Background()
+	var client fakeClient
+
+	u, err := Unary(ctx, client.GetUser, &getUserRequest{ID: 1}).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if u.Name != "alice" {
+		t.Errorf("expected name to be alice, got %s", u.Name)
+	}
+}
+
+func TestUnaryError(t *testing.T) {
+	ctx := context.Background()
+	var client fakeClient
+
+	_, err := Unary(ctx, client.GetUser, &getUserRequest{ID: 2}, callOption{}).Await(ctx)
+	if err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
+
+func TestUnaryCancel(t *testing.T) {
+	called := make(chan struct{})
+	canceled := make(chan error, 1)
+	call := func(ctx context.Context, in *getUserRequest, opts ...callOption) (*user, error) {
+		close(called)
+		<-ctx.Done()
+		canceled <- context.Cause(ctx)
+		return nil, ctx.Err()
+	}
+
+	p := Unary(context.Background(), call, &getUserRequest{ID: 1})
+	<-called
+	cause := errors.New("gave up")
+	p.Cancel(cause)
+	if err := <-canceled; err != cause {
+		t.Errorf("expected the call to be canceled with the cause, got %v", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	messages, done := Stream[*user](ctx, &fakeStream{names: []string{"alice", "bob"}, err: io.EOF})
+
+	var names []string
+	for u := range messages {
+		names = append(names, u.Name)
+	}
+
+	if _, err := done.Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(names) != 2 || names[1] != "bob" {
+		t.Errorf("expected names to be [alice bob], got %v", names)
+	}
+}
+
+func TestCollectError(t *testing.T) {
+	ctx := context.Background()
+	streamErr := errors.New("unavailable")
+
+	_, err := Collect[*user](ctx, &fakeStream{names: []string{"alice"}, err: streamErr}).Await(ctx)
+	if !errors.Is(err, streamErr) {
+		t.Errorf("expected error to be %v, got %v", streamErr, err)
+	}
+}
+
+type clientConn struct{}
+
+type unaryInvoker func(ctx context.Context, method string, req, reply any, cc *clientConn, opts ...callOption) error
+
+type settlements struct {
+	mutex sync.Mutex
+	names []string
+	errs  []error
+}
+
+func (s *settlements) OnCreate(info promises.Info) {}
+
+func (s *settlements) OnSettle(settlement promises.Settlement) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.names = append(s.names, settlement.Name)
+	s.errs = append(s.errs, settlement.Reason)
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	ctx := context.Background()
+	s := &settlements{}
+	intercept := UnaryInterceptor[*clientConn, callOption, unaryInvoker](promises.WithObserver(s))
+
+	failure := errors.New("unavailable")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *clientConn, opts ...callOption) error {
+		if method == "/users.Users/Delete" {
+			return failure
+		}
+
+		reply.(*user).Name = "alice"
+		return nil
+	}
+
+	var u user
+	if err := intercept(ctx, "/users.Users/Get", &getUserRequest{ID: 1}, &u, &clientConn{}, invoker); err != nil || u.Name != "alice" {
+		t.Errorf("expected the call to fill in alice, got %v, %v", u, err)
+	}
+
+	if err := intercept(ctx, "/users.Users/Delete", &getUserRequest{ID: 1}, &u, &clientConn{}, invoker); err != failure {
+		t.Errorf("expected the error of the call, got %v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.names) != 2 || s.names[0] != "/users.Users/Get" || s.names[1] != "/users.Users/Delete" {
+		t.Errorf("expected a settlement named after each method, got %v", s.names)
+	}
+
+	if s.errs[0] != nil || s.errs[1] != failure {
+		t.Errorf("expected the settlements to carry the outcomes, got %v", s.errs)
+	}
+}
@@ -0,0 +1,150 @@
+package promisehttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Response is the value a handler promise is fulfilled with.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// HandlerOptions configures Handler and StreamHandler.
+type HandlerOptions struct {
+	// Timeout bounds how long the handler waits for its promises. Zero means no timeout.
+	Timeout time.Duration
+
+	// ErrorHandler writes the response when the promise is rejected or the wait is aborted.
+	// If nil, DefaultErrorHandler is used.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// DefaultErrorHandler responds with 504 on timeout, writes nothing if the client went away and responds with 500 otherwise.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+	case errors.Is(err, context.Canceled):
+		return
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// Handler returns an http.Handler that awaits the promise returned by fn and writes its Response.
+// The request passed to fn carries a context that is canceled when the client goes away or the timeout expires.
+func Handler(fn func(r *http.Request) *promises.Promise[Response], opts HandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, cancel := withTimeout(r, opts.Timeout)
+		defer cancel()
+
+		res, err := fn(r).Await(r.Context())
+		if err != nil {
+			opts.errorHandler()(w, r, err)
+			return
+		}
+
+		for k, vs := range res.Header {
+			for _, v := range vs {
+				w.Header().Add(k, v)
+			}
+		}
+
+		if res.StatusCode == 0 {
+			res.StatusCode = http.StatusOK
+		}
+
+		w.WriteHeader(res.StatusCode)
+		w.Write(res.Body)
+	})
+}
+
+// StreamHandler returns an http.Handler that streams the settlement of each promise returned by fn as a Server-Sent Event.
+// Events are written in settlement order. Each event is named after the settlement status
+// and its data is a JSON object with the index of the promise and its value or reason.
+// The stream ends when every promise is settled or the request context is done.
+func StreamHandler[T any](fn func(r *http.Request) []*promises.Promise[T], opts HandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			opts.errorHandler()(w, r, errors.New("streaming unsupported"))
+			return
+		}
+
+		r, cancel := withTimeout(r, opts.Timeout)
+		defer cancel()
+
+		ctx := r.Context()
+		ps := fn(r)
+		settled := make(chan streamEvent[T], len(ps))
+		for i, p := range ps {
+			go func(i int, p *promises.Promise[T]) {
+				v, err := p.Await(ctx)
+				settled <- newStreamEvent(i, v, err)
+			}(i, p)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for range ps {
+			var e streamEvent[T]
+			select {
+			case <-ctx.Done():
+				return
+			case e = <-settled:
+			}
+
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.status, data)
+			flusher.Flush()
+		}
+	})
+}
+
+type streamEvent[T any] struct {
+	status promises.Status
+	Index  int    `json:"index"`
+	Value  *T     `json:"value,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func newStreamEvent[T any](i int, v T, err error) streamEvent[T] {
+	if err != nil {
+		return streamEvent[T]{status: promises.Rejected, Index: i, Reason: err.Error()}
+	}
+
+	return streamEvent[T]{status: promises.Fulfilled, Index: i, Value: &v}
+}
+
+func (o HandlerOptions) errorHandler() func(w http.ResponseWriter, r *http.Request, err error) {
+	if o.ErrorHandler == nil {
+		return DefaultErrorHandler
+	}
+
+	return o.ErrorHandler
+}
+
+func withTimeout(r *http.Request, timeout time.Duration) (*http.Request, context.CancelFunc) {
+	if timeout <= 0 {
+		return r, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return r.WithContext(ctx), cancel
+}
@@ -0,0 +1,92 @@
+package promisehttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisehttp"
+)
+
+func TestHandler(t *testing.T) {
+	h := Handler(func(r *http.Request) *promises.Promise[Response] {
+		return promises.New(func(resolve promises.Resolve[Response], reject promises.Reject) {
+			resolve(Response{StatusCode: http.StatusCreated, Body: []byte("hello")})
+		})
+	}, HandlerOptions{})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status to be 201, got %d", w.Code)
+	}
+
+	if w.Body.String() != "hello" {
+		t.Errorf("expected body to be hello, got %s", w.Body.String())
+	}
+}
+
+func TestHandlerRejected(t *testing.T) {
+	h := Handler(func(r *http.Request) *promises.Promise[Response] {
+		return promises.New(func(resolve promises.Resolve[Response], reject promises.Reject) {
+			reject(errors.New("something went wrong"))
+		})
+	}, HandlerOptions{})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status to be 500, got %d", w.Code)
+	}
+}
+
+func TestHandlerTimeout(t *testing.T) {
+	h := Handler(func(r *http.Request) *promises.Promise[Response] {
+		return promises.New(func(resolve promises.Resolve[Response], reject promises.Reject) {
+			<-r.Context().Done()
+			reject(r.Context().Err())
+		})
+	}, HandlerOptions{Timeout: 10 * time.Millisecond})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("expected status to be 504, got %d", w.Code)
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	h := StreamHandler(func(r *http.Request) []*promises.Promise[int] {
+		return []*promises.Promise[int]{
+			promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+				resolve(1)
+			}),
+			promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+				reject(errors.New("something went wrong"))
+			}),
+		}
+	}, HandlerOptions{})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected content type to be text/event-stream, got %s", ct)
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "event: fulfilled\ndata: {\"index\":0,\"value\":1}\n\n") {
+		t.Errorf("expected fulfilled event, got %q", body)
+	}
+
+	if !strings.Contains(body, "event: rejected\ndata: {\"index\":1,\"reason\":\"something went wrong\"}\n\n") {
+		t.Errorf("expected rejected event, got %q", body)
+	}
+}
@@ -0,0 +1,122 @@
+// Package promisehttp provides promise-based helpers for net/http clients and servers.
+// Do is the promise of a request; use promises.Wrap for other blocking calls.
+package promisehttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// StatusError is the reason a JSON promise is rejected with when the response status is not 2xx.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status: %s", e.Status)
+}
+
+// Do sends the request with the client and returns a promise that is fulfilled with the response.
+// The request is bound to ctx, so canceling ctx or the promise aborts the request.
+// If client is nil, http.DefaultClient is used.
+// The caller is responsible for closing the response body, unless the promise was canceled or timed out first,
+// in which case the body is closed for it.
+func Do(ctx context.Context, client *http.Client, req *http.Request) *promises.Promise[*http.Response] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return promises.NewWithContext(ctx, func(pctx context.Context, resolve promises.Resolve[*http.Response], reject promises.Reject) {
+		// The response outlives the promise, so the request is bound to a context that the promise cancels
+		// only if it is canceled first, and that closing the body releases.
+		reqCtx, cancel := context.WithCancelCause(ctx)
+		stop := context.AfterFunc(pctx, func() {
+			cancel(context.Cause(pctx))
+		})
+
+		res, err := client.Do(req.WithContext(reqCtx))
+		if err != nil {
+			cancel(err)
+			reject(err)
+			return
+		}
+
+		stop()
+		res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+		resolve(res)
+	}, promises.WithDisposer(closeBody))
+}
+
+func closeBody(res *http.Response) {
+	res.Body.Close()
+}
+
+// cancelBody is a response body that cancels the context of its request once it is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelCauseFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel(nil)
+	return err
+}
+
+// Get issues a GET request to the url and returns a promise that is fulfilled with the response.
+func Get(ctx context.Context, client *http.Client, url string) *promises.Promise[*http.Response] {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return promises.NewRejected[*http.Response](err)
+	}
+
+	return Do(ctx, client, req)
+}
+
+// DoJSON sends the request and returns a promise that is fulfilled with the response body decoded as JSON into T.
+// The promise is rejected with a *StatusError if the response status is not 2xx.
+// The response body is always closed.
+func DoJSON[T any](ctx context.Context, client *http.Client, req *http.Request) *promises.Promise[T] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[T], reject promises.Reject) {
+		res, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			reject(err)
+			return
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			reject(&StatusError{StatusCode: res.StatusCode, Status: res.Status})
+			return
+		}
+
+		var v T
+		if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	})
+}
+
+// GetJSON issues a GET request to the url and returns a promise that is fulfilled with the response body decoded as JSON into T.
+func GetJSON[T any](ctx context.Context, client *http.Client, url string) *promises.Promise[T] {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return promises.NewRejected[T](err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	return DoJSON[T](ctx, client, req)
+}
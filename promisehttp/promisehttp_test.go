@@ -0,0 +1,133 @@
+package promisehttp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisehttp"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func newServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"name":"hello"}`)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+	defer s.Close()
+
+	res, err := Get(ctx, s.Client(), s.URL+"/user").Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected status to be 200, got %d", res.StatusCode)
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+	defer s.Close()
+
+	v, err := GetJSON[user](ctx, s.Client(), s.URL+"/user").Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v.Name != "hello" {
+		t.Errorf("expected name to be hello, got %s", v.Name)
+	}
+}
+
+func TestGetJSONStatusError(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+	defer s.Close()
+
+	_, err := GetJSON[user](ctx, s.Client(), s.URL+"/missing").Await(ctx)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected error to be *StatusError, got %v", err)
+	}
+
+	if statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status code to be 404, got %d", statusErr.StatusCode)
+	}
+}
+
+func TestGetJSONFanOut(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+	defer s.Close()
+
+	var ps []*promises.Promise[user]
+	for i := 0; i < 10; i++ {
+		ps = append(ps, GetJSON[user](ctx, s.Client(), s.URL+"/user"))
+	}
+
+	v, err := promises.All(ctx, ps...).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 10 {
+		t.Errorf("expected length to be 10, got %d", len(v))
+	}
+}
+
+func TestDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := newServer()
+	defer s.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, s.URL+"/user", nil)
+	_, err := Do(ctx, s.Client(), req).Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+}
+
+func TestDoCancelAborts(t *testing.T) {
+	arrived := make(chan struct{})
+	aborted := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer s.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, s.URL, nil)
+	p := Do(context.Background(), s.Client(), req)
+	<-arrived
+	p.Cancel(nil)
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("expected canceling the promise to abort the request")
+	}
+}
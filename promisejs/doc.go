@@ -0,0 +1,2 @@
+// Package promisejs converts between promises and JavaScript Promises in js/wasm builds.
+package promisejs
@@ -0,0 +1,130 @@
+//go:build js && wasm
+
+package promisejs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall/js"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Error is the reason a promise is rejected with when the JavaScript Promise it was converted from rejects.
+type Error struct {
+	Value js.Value
+}
+
+func (e *Error) Error() string {
+	if e.Value.Type() == js.TypeObject {
+		if m := e.Value.Get("message"); m.Type() == js.TypeString {
+			return m.String()
+		}
+	}
+
+	return e.Value.String()
+}
+
+// FromJS returns a promise that is settled when the JavaScript Promise (or any thenable) v settles.
+// A rejection is reported as an *Error wrapping the JavaScript reason.
+// If ctx is done first, the promise is rejected with the context error.
+// The callbacks registered on v are released once the promise is settled, including when it is canceled with Cancel.
+func FromJS(ctx context.Context, v js.Value) *promises.Promise[js.Value] {
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[js.Value], reject promises.Reject) {
+		settled := make(chan struct{})
+		var onFulfilled, onRejected js.Func
+		onFulfilled = js.FuncOf(func(this js.Value, args []js.Value) any {
+			resolve(arg(args))
+			close(settled)
+			return nil
+		})
+		onRejected = js.FuncOf(func(this js.Value, args []js.Value) any {
+			reject(&Error{Value: arg(args)})
+			close(settled)
+			return nil
+		})
+
+		// v is raced with a Promise that is rejected once ctx is done, so that the callbacks are called,
+		// and can be released, even if v never settles.
+		var cancel js.Value
+		cancelExecutor := js.FuncOf(func(this js.Value, args []js.Value) any {
+			cancel = args[1]
+			return nil
+		})
+		canceled := js.Global().Get("Promise").New(cancelExecutor)
+		cancelExecutor.Release()
+
+		js.Global().Get("Promise").Call("race", []any{v, canceled}).Call("then", onFulfilled, onRejected)
+
+		select {
+		case <-ctx.Done():
+			reject(ctx.Err())
+			cancel.Invoke()
+			// The callbacks can only be released once JavaScript no longer calls them.
+			<-settled
+		case <-settled:
+		}
+
+		onFulfilled.Release()
+		onRejected.Release()
+	})
+}
+
+// ToJS returns a JavaScript Promise that settles when p settles.
+// The fulfilled value is converted with js.ValueOf and a rejection reason is converted to a JavaScript Error.
+// If the value cannot be converted, or ctx is done first, the JavaScript Promise rejects.
+func ToJS[T any](ctx context.Context, p *promises.Promise[T]) js.Value {
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, args []js.Value) any {
+		resolve, reject := args[0], args[1]
+		go func() {
+			defer executor.Release()
+
+			v, err := p.Await(ctx)
+			if err != nil {
+				reject.Invoke(newError(err))
+				return
+			}
+
+			jv, err := valueOf(v)
+			if err != nil {
+				reject.Invoke(newError(err))
+				return
+			}
+
+			resolve.Invoke(jv)
+		}()
+
+		return nil
+	})
+
+	return js.Global().Get("Promise").New(executor)
+}
+
+func valueOf(v any) (jv js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot convert %T to a JavaScript value: %v", v, r)
+		}
+	}()
+
+	return js.ValueOf(v), nil
+}
+
+func newError(err error) js.Value {
+	var jsErr *Error
+	if errors.As(err, &jsErr) {
+		return jsErr.Value
+	}
+
+	return js.Global().Get("Error").New(err.Error())
+}
+
+func arg(args []js.Value) js.Value {
+	if len(args) == 0 {
+		return js.Undefined()
+	}
+
+	return args[0]
+}
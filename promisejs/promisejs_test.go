@@ -0,0 +1,103 @@
+//go:build js && wasm
+
+package promisejs_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"syscall/js"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisejs"
+)
+
+func TestFromJS(t *testing.T) {
+	ctx := context.Background()
+	v := js.Global().Get("Promise").Call("resolve", 1)
+
+	got, err := FromJS(ctx, v).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if got.Int() != 1 {
+		t.Errorf("expected value to be 1, got %v", got)
+	}
+}
+
+func TestFromJSRejected(t *testing.T) {
+	ctx := context.Background()
+	reason := js.Global().Get("Error").New("something went wrong")
+	v := js.Global().Get("Promise").Call("reject", reason)
+
+	_, err := FromJS(ctx, v).Await(ctx)
+	var jsErr *Error
+	if !errors.As(err, &jsErr) {
+		t.Fatalf("expected error to be *Error, got %v", err)
+	}
+
+	if jsErr.Error() != "something went wrong" {
+		t.Errorf("expected message to be something went wrong, got %s", jsErr.Error())
+	}
+}
+
+func TestFromJSCanceledNeverSettles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pending := js.FuncOf(func(this js.Value, args []js.Value) any { return nil })
+	defer pending.Release()
+	v := js.Global().Get("Promise").New(pending)
+
+	before := runtime.NumGoroutine()
+	p := FromJS(ctx, v)
+	cancel()
+	if _, err := p.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the executor to return although the JavaScript Promise never settles")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFromJSCancel(t *testing.T) {
+	pending := js.FuncOf(func(this js.Value, args []js.Value) any { return nil })
+	defer pending.Release()
+	v := js.Global().Get("Promise").New(pending)
+
+	before := runtime.NumGoroutine()
+	p := FromJS(context.Background(), v)
+	p.Cancel(nil)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatal("expected canceling the promise to release its callbacks")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestToJSRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p := promises.New(func(resolve promises.Resolve[string], reject promises.Reject) {
+		resolve("hello")
+	})
+
+	got, err := FromJS(ctx, ToJS(ctx, p)).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if got.String() != "hello" {
+		t.Errorf("expected value to be hello, got %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package promisepool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// queuedTasks maps the promise of every task queued on a Pool to its task, so that awaiters can boost it.
+var queuedTasks sync.Map
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx that carries the priority of its caller. Await lends it to the tasks it waits for.
+// Tasks run with a context that carries their own effective priority, so the tasks they await inherit it in turn.
+func WithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// priorityOf returns the priority ctx carries, if any.
+func priorityOf(ctx context.Context) (int, bool) {
+	switch v := ctx.Value(priorityKey{}).(type) {
+	case int:
+		return v, true
+	case *task:
+		return v.effectivePriority(), true
+	default:
+		return 0, false
+	}
+}
+
+// Await waits for the promise like its Await method. If ctx carries a priority and the promise belongs to a task
+// still queued on a Pool with a lower priority, the task is boosted first, so that a shared promise does not keep
+// an important caller waiting behind less important work.
+func Await[T any](ctx context.Context, promise *promises.Promise[T]) (T, error) {
+	if priority, ok := priorityOf(ctx); ok {
+		Boost(promise, priority)
+	}
+
+	return promise.Await(ctx)
+}
+
+// Boost raises the effective priority of the task that settles the promise to priority and reports whether it did.
+// It does nothing unless the task was submitted to a Pool, is still queued and has a lower effective priority.
+// A boosted task is dispatched before the tasks that are not, highest priority first, regardless of its key's turn
+// or of the tasks queued before it under the same key. Quotas still apply.
+func Boost(promise promises.AnyPromise, priority int) bool {
+	v, ok := queuedTasks.Load(promise)
+	if !ok {
+		return false
+	}
+
+	t := v.(*task)
+	t.pool.mutex.Lock()
+	defer t.pool.mutex.Unlock()
+
+	if !t.queued || priority <= t.priority || priority <= t.boost {
+		return false
+	}
+
+	t.pool.queue.boost(t, priority)
+	t.pool.cond.Signal()
+	return true
+}
+
+// effectivePriority returns the priority of the task including any boost.
+func (t *task) effectivePriority() int {
+	if t.pool == nil {
+		return t.priority
+	}
+
+	t.pool.mutex.Lock()
+	defer t.pool.mutex.Unlock()
+
+	return max(t.priority, t.boost)
+}
@@ -0,0 +1,334 @@
+// Package promisepool runs tasks on a bounded set of worker goroutines and exposes their results as promises.
+//
+// A Pool is also a promises.Scheduler, so executors of promises created with promises.WithScheduler(pool)
+// run on its workers.
+package promisepool
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var (
+	// ErrPoolClosed is the reason a task is rejected with when it is submitted to a drained pool,
+	// or is still queued when a drain gives up.
+	ErrPoolClosed = errors.New("pool closed")
+)
+
+// Pool runs queued tasks on a number of workers.
+// Tasks are grouped by submission key, such as a tenant. Keys take turns so that one key's flood of tasks cannot starve the others,
+// and tasks of the same key run in submission order.
+type Pool struct {
+	size     int
+	workers  int
+	queue    *queue
+	closed   bool
+	stopped  chan struct{}
+	stats    counters
+	shedding ShedPolicy
+	thread   *threadWorker
+	cond     *sync.Cond
+	mutex    sync.Mutex
+}
+
+type task struct {
+	key      string
+	run      func()
+	reject   promises.Reject
+	queuedAt time.Time
+	priority int
+
+	// promise is the promise the task settles, if any. It is the key of the task in queuedTasks.
+	promise promises.AnyPromise
+	pool    *Pool
+	queued  bool
+	boost   int
+}
+
+// New starts a pool with size workers. size must be positive.
+func New(size int) *Pool {
+	if size <= 0 {
+		panic("promisepool: non-positive size")
+	}
+
+	p := &Pool{size: size, workers: size, queue: newQueue(), stopped: make(chan struct{})}
+	p.cond = sync.NewCond(&p.mutex)
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submitter is a pool that tasks can be submitted to: a *Pool or a *ShardedPool.
+type Submitter interface {
+	enqueue(t *task) bool
+}
+
+// Submit queues fn on the pool and returns a promise that settles with its outcome.
+// fn runs with ctx, so the caller's deadline and values carry over to it. If ctx is done by the time a worker
+// picks the task up, fn is skipped and the promise is rejected with the context error.
+// If the pool is drained, the promise is rejected with ErrPoolClosed.
+func Submit[T any](p Submitter, ctx context.Context, fn func(ctx context.Context) (T, error)) *promises.Promise[T] {
+	promise, t := newTask("", ctx, fn)
+	p.enqueue(t)
+	return promise
+}
+
+// SubmitKey is Submit for a task of the given key, which shares the pool fairly with the tasks of other keys.
+func SubmitKey[T any](p *Pool, ctx context.Context, key string, fn func(ctx context.Context) (T, error)) *promises.Promise[T] {
+	promise, t := newTask(key, ctx, fn)
+	p.enqueue(t)
+	return promise
+}
+
+// newTask returns a task that runs fn and the promise it settles.
+func newTask[T any](key string, ctx context.Context, fn func(ctx context.Context) (T, error)) (*promises.Promise[T], *task) {
+	promise, resolve, reject := pending[T]()
+	t := &task{key: key, reject: reject, promise: promise}
+	ctx = context.WithValue(ctx, priorityKey{}, t)
+	t.run = func() {
+		// Work whose caller has already given up is not worth running.
+		if err := ctx.Err(); err != nil {
+			reject(err)
+			return
+		}
+
+		v, err := fn(ctx)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	}
+
+	return promise, t
+}
+
+// inline is a scheduler that runs executors synchronously.
+var inline = promises.SchedulerFunc(func(run func()) {
+	run()
+})
+
+// pending returns a pending promise and the functions that settle it.
+func pending[T any]() (*promises.Promise[T], promises.Resolve[T], promises.Reject) {
+	var resolve promises.Resolve[T]
+	var reject promises.Reject
+	p := promises.New(func(res promises.Resolve[T], rej promises.Reject) {
+		resolve, reject = res, rej
+	}, promises.WithScheduler(inline))
+
+	return p, resolve, reject
+}
+
+// Schedule queues run on the pool, which makes the pool a promises.Scheduler.
+// If the pool is drained, run is started on its own goroutine instead, so that the promise it settles is not stranded.
+func (p *Pool) Schedule(run func()) {
+	if !p.enqueue(&task{run: run}) {
+		go run()
+	}
+}
+
+// SetQuota limits how many tasks of the key may run at the same time. Zero removes the limit.
+func (p *Pool) SetQuota(key string, n int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if n > 0 {
+		p.queue.quotas[key] = n
+	} else {
+		delete(p.queue.quotas, key)
+	}
+
+	p.cond.Broadcast()
+}
+
+// SetWeight lets the key dispatch up to w tasks per turn instead of one, giving it a larger share of the workers.
+func (p *Pool) SetWeight(key string, w int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if w > 1 {
+		p.queue.weights[key] = w
+	} else {
+		delete(p.queue.weights, key)
+	}
+}
+
+// Resize changes the number of workers to n, which must be positive.
+// New workers start immediately unless the pool is drained; surplus workers exit once they finish their current task.
+func (p *Pool) Resize(n int) {
+	if n <= 0 {
+		panic("promisepool: non-positive size")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.size = n
+	for !p.closed && p.workers < p.size {
+		p.workers++
+		go p.work()
+	}
+
+	p.cond.Broadcast()
+}
+
+// Drain stops accepting tasks and returns a promise that is fulfilled once the queued and running tasks are done.
+// If ctx is done first, the tasks still queued are rejected with ErrPoolClosed and the promise is rejected with the context error.
+func (p *Pool) Drain(ctx context.Context) *promises.Promise[struct{}] {
+	p.mutex.Lock()
+	p.close()
+	p.mutex.Unlock()
+
+	return promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+		select {
+		case <-p.stopped:
+			resolve(struct{}{})
+		case <-ctx.Done():
+			p.mutex.Lock()
+			queue := p.queue.drain()
+			p.stats.rejected += uint64(len(queue))
+			p.cond.Broadcast()
+			p.mutex.Unlock()
+
+			for _, t := range queue {
+				t.abort()
+			}
+
+			reject(ctx.Err())
+		}
+	})
+}
+
+// enqueue queues the task and reports whether the pool accepted it.
+// A rejected task with a reject function is rejected with ErrPoolClosed.
+func (p *Pool) enqueue(t *task) bool {
+	p.mutex.Lock()
+	p.stats.submitted++
+	if p.closed {
+		p.stats.rejected++
+		p.mutex.Unlock()
+		if t.reject != nil {
+			t.reject(ErrPoolClosed)
+		}
+
+		return false
+	}
+
+	t.queuedAt = promises.CurrentClock().Now()
+	if p.shed(t, t.queuedAt) {
+		p.stats.shed++
+		p.mutex.Unlock()
+		t.reject(ErrShed)
+		return false
+	}
+
+	t.pool = p
+	p.queue.push(t)
+	p.mutex.Unlock()
+
+	p.cond.Signal()
+	return true
+}
+
+// close marks the pool closed and wakes up idle workers so that they exit once the queue is empty.
+// p.mutex must be held.
+func (p *Pool) close() {
+	if p.closed {
+		return
+	}
+
+	p.closed = true
+	if p.thread != nil {
+		p.thread.close()
+	}
+
+	if p.workers == 0 {
+		close(p.stopped)
+	}
+
+	p.cond.Broadcast()
+}
+
+func (p *Pool) work() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for {
+		var t *task
+		for {
+			if p.workers > p.size || (p.closed && p.queue.len() == 0) {
+				p.workers--
+				if p.closed && p.workers == 0 {
+					close(p.stopped)
+				}
+
+				return
+			}
+
+			if t = p.queue.pop(); t != nil {
+				break
+			}
+
+			p.cond.Wait()
+		}
+
+		p.stats.busy++
+		p.stats.started++
+		p.stats.totalWait += promises.CurrentClock().Now().Sub(t.queuedAt)
+
+		p.mutex.Unlock()
+		ok := t.safeRun()
+		p.mutex.Lock()
+
+		p.stats.busy--
+		p.stats.completed++
+		p.queue.done(t.key)
+		if p.queue.len() > 0 {
+			p.cond.Signal()
+		}
+
+		if !ok {
+			// Replace the worker, so that whatever state the panic left on this goroutine goes with it.
+			p.stats.panics++
+			go p.work()
+			return
+		}
+	}
+}
+
+// safeRun runs the task and reports false if it panicked. The panic is recovered and the task is rejected
+// with a *promises.PanicError. Tasks queued by Schedule have no reject function, so a promise whose executor
+// panics on the pool stays pending if it is created with promises.WithFailFast.
+func (t *task) safeRun() (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if t.reject != nil {
+				t.reject(&promises.PanicError{Value: r, Stack: debug.Stack()})
+			}
+
+			ok = false
+		}
+	}()
+
+	t.run()
+	return true
+}
+
+// abort settles a task that will never run. Tasks queued by Schedule have no reject function,
+// so they are started on their own goroutine instead.
+func (t *task) abort() {
+	if t.reject != nil {
+		t.reject(ErrPoolClosed)
+		return
+	}
+
+	go t.run()
+}
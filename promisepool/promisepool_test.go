@@ -0,0 +1,558 @@
+package promisepool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	"github.com/oneofthezombies/promises/promisepool"
+)
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(3)
+	defer pool.Drain(ctx)
+
+	var inFlight, peak atomic.Int32
+	results := make([]*promises.Promise[int], 20)
+	for i := range results {
+		i := i
+		results[i] = promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			return i, nil
+		})
+	}
+
+	v, err := promises.All(ctx, results...).Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	for i, n := range v {
+		if n != i {
+			t.Errorf("expected %d, got %d", i, n)
+		}
+	}
+
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 tasks in flight, got %d", peak.Load())
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	p := promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		resolve(1)
+	}, promises.WithScheduler(pool))
+
+	if v, err := p.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	p := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	})
+	queued := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+
+	if _, err := pool.Drain(ctx).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if !p.IsFulfilled() || !queued.IsFulfilled() {
+		t.Error("expected the running and queued tasks to be done")
+	}
+
+	late := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	if _, err := late.Await(ctx); !errors.Is(err, promisepool.ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func TestDrainExpired(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	block := make(chan struct{})
+	defer close(block)
+
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		<-block
+		return 1, nil
+	})
+	queued := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+
+	drainCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Drain(drainCtx).Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if _, err := queued.Await(ctx); !errors.Is(err, promisepool.ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func TestResize(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	var inFlight, peak atomic.Int32
+	submit := func(n int) []*promises.Promise[int] {
+		results := make([]*promises.Promise[int], n)
+		for i := range results {
+			results[i] = promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				return 0, nil
+			})
+		}
+
+		return results
+	}
+
+	pool.Resize(4)
+	promises.All(ctx, submit(16)...).Await(ctx)
+	if peak.Load() != 4 {
+		t.Errorf("expected 4 tasks in flight after growing, got %d", peak.Load())
+	}
+
+	pool.Resize(2)
+	peak.Store(0)
+	promises.All(ctx, submit(16)...).Await(ctx)
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 tasks in flight after shrinking, got %d", peak.Load())
+	}
+}
+
+// orderLog records the order in which tasks run.
+type orderLog struct {
+	order []string
+	mutex sync.Mutex
+}
+
+func (l *orderLog) task(name string) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		l.order = append(l.order, name)
+		return 0, nil
+	}
+}
+
+// runBlocked submits tasks to a single-worker pool while the worker is busy and returns the order they ran in.
+func runBlocked(t *testing.T, pool *promisepool.Pool, submit func(l *orderLog) []*promises.Promise[int]) []string {
+	t.Helper()
+
+	ctx := context.Background()
+	block := make(chan struct{})
+	started := make(chan struct{})
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+
+	l := &orderLog{}
+	results := submit(l)
+	close(block)
+	promises.All(ctx, results...).Await(ctx)
+	return l.order
+}
+
+func TestSubmitKeyRoundRobin(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	order := runBlocked(t, pool, func(l *orderLog) []*promises.Promise[int] {
+		var results []*promises.Promise[int]
+		for i := 0; i < 4; i++ {
+			results = append(results, promisepool.SubmitKey(pool, ctx, "noisy", l.task("noisy")))
+		}
+
+		results = append(results, promisepool.SubmitKey(pool, ctx, "quiet", l.task("quiet")))
+		return results
+	})
+
+	if want := []string{"noisy", "quiet", "noisy", "noisy", "noisy"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestSetWeight(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+	pool.SetWeight("heavy", 2)
+
+	order := runBlocked(t, pool, func(l *orderLog) []*promises.Promise[int] {
+		var results []*promises.Promise[int]
+		for i := 0; i < 4; i++ {
+			results = append(results, promisepool.SubmitKey(pool, ctx, "heavy", l.task("heavy")))
+			results = append(results, promisepool.SubmitKey(pool, ctx, "light", l.task("light")))
+		}
+
+		return results
+	})
+
+	want := []string{"heavy", "heavy", "light", "heavy", "heavy", "light", "light", "light"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestSetQuota(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(4)
+	defer pool.Drain(ctx)
+	pool.SetQuota("limited", 1)
+
+	var inFlight, peak atomic.Int32
+	results := make([]*promises.Promise[int], 8)
+	for i := range results {
+		results[i] = promisepool.SubmitKey(pool, ctx, "limited", func(ctx context.Context) (int, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			return 0, nil
+		})
+	}
+
+	if _, err := promises.All(ctx, results...).Await(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if peak.Load() != 1 {
+		t.Errorf("expected 1 task of the key in flight, got %d", peak.Load())
+	}
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(2)
+	block := make(chan struct{})
+	started := make(chan struct{}, 2)
+	for i := 0; i < 3; i++ {
+		promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			started <- struct{}{}
+			<-block
+			return 0, nil
+		})
+	}
+
+	<-started
+	<-started
+	s := pool.Stats()
+	if s.Workers != 2 || s.Busy != 2 || s.Idle != 0 || s.Queued != 1 || s.Submitted != 3 {
+		t.Errorf("expected 2 busy workers and 1 queued task, got %+v", s)
+	}
+
+	close(block)
+	pool.Drain(ctx).Await(ctx)
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		return 0, nil
+	})
+
+	s = pool.Stats()
+	if s.Completed != 3 || s.Rejected != 1 || s.Submitted != 4 || s.Busy != 0 {
+		t.Errorf("expected 3 completed and 1 rejected task, got %+v", s)
+	}
+}
+
+func TestOnStats(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	snapshots := make(chan promisepool.Stats, 1)
+	stop := pool.OnStats(time.Millisecond, func(s promisepool.Stats) {
+		select {
+		case snapshots <- s:
+		default:
+		}
+	})
+	defer stop()
+
+	if s := <-snapshots; s.Workers != 1 {
+		t.Errorf("expected 1 worker, got %+v", s)
+	}
+}
+
+func BenchmarkSubmit(b *testing.B) {
+	ctx := context.Background()
+	fn := func(ctx context.Context) (int, error) {
+		return 0, nil
+	}
+
+	for _, bm := range []struct {
+		name string
+		pool promisepool.Submitter
+	}{
+		{"Pool", promisepool.New(runtime.GOMAXPROCS(0))},
+		{"ShardedPool", promisepool.NewSharded(runtime.GOMAXPROCS(0))},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					promisepool.Submit(bm.pool, ctx, fn).Await(ctx)
+				}
+			})
+		})
+	}
+}
+
+func TestSubmitExpiredWhileQueued(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	block := make(chan struct{})
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		<-block
+		return 0, nil
+	})
+
+	taskCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	ran := false
+	p := promisepool.Submit(pool, taskCtx, func(ctx context.Context) (int, error) {
+		ran = true
+		return 1, nil
+	})
+
+	<-taskCtx.Done()
+	close(block)
+	if _, err := p.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if ran {
+		t.Error("expected the expired task not to run")
+	}
+}
+
+func TestSubmitPropagatesDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	want, _ := ctx.Deadline()
+	got, err := promisepool.Submit(pool, ctx, func(ctx context.Context) (time.Time, error) {
+		deadline, _ := ctx.Deadline()
+		return deadline, nil
+	}).Await(ctx)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("expected the deadline %v, got %v, %v", want, got, err)
+	}
+}
+
+func TestSubmitPanic(t *testing.T) {
+	ctx := context.Background()
+	for _, pool := range []promisepool.Submitter{promisepool.New(1), promisepool.NewSharded(1)} {
+		p := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			panic("boom")
+		})
+
+		var panicErr *promises.PanicError
+		if _, err := p.Await(ctx); !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+			t.Errorf("expected *promises.PanicError, got %v", err)
+		}
+
+		v, err := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}).Await(ctx)
+		if err != nil || v != 1 {
+			t.Errorf("expected the pool to keep working, got %v, %v", v, err)
+		}
+	}
+}
+
+func TestStatsPanics(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(2)
+	defer pool.Drain(ctx)
+
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		panic("boom")
+	}).Await(ctx)
+
+	s := pool.Stats()
+	if s.Panics != 1 || s.Workers != 2 {
+		t.Errorf("expected 1 panic and 2 workers, got %+v", s)
+	}
+}
+
+func TestShedding(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+	pool.SetShedding(promisepool.ShedPolicy{MaxQueued: 2, Priority: 1})
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+
+	fn := func(ctx context.Context) (int, error) {
+		return 0, nil
+	}
+
+	queued := []*promises.Promise[int]{promisepool.Submit(pool, ctx, fn), promisepool.Submit(pool, ctx, fn)}
+	shed := promisepool.Submit(pool, ctx, fn)
+	important := promisepool.SubmitWith(pool, ctx, promisepool.TaskOptions{Priority: 1}, fn)
+
+	if _, err := shed.Await(ctx); !errors.Is(err, promisepool.ErrShed) {
+		t.Errorf("expected ErrShed, got %v", err)
+	}
+
+	close(block)
+	if _, err := promises.All(ctx, append(queued, important)...).Await(ctx); err != nil {
+		t.Errorf("expected the queued and important tasks to run, got %v", err)
+	}
+
+	if s := pool.Stats(); s.Shed != 1 {
+		t.Errorf("expected 1 shed task, got %+v", s)
+	}
+}
+
+func TestSheddingQueueWait(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+	pool.SetShedding(promisepool.ShedPolicy{MaxQueueWait: time.Millisecond, Priority: 1})
+
+	block := make(chan struct{})
+	defer close(block)
+	fn := func(ctx context.Context) (int, error) {
+		<-block
+		return 0, nil
+	}
+
+	promisepool.Submit(pool, ctx, fn)
+	promisepool.Submit(pool, ctx, fn)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := promisepool.Submit(pool, ctx, fn).Await(ctx); !errors.Is(err, promisepool.ErrShed) {
+		t.Errorf("expected ErrShed, got %v", err)
+	}
+}
+
+func TestBoost(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	order := runBlocked(t, pool, func(l *orderLog) []*promises.Promise[int] {
+		a := promisepool.SubmitKey(pool, ctx, "x", l.task("a"))
+		b := promisepool.SubmitKey(pool, ctx, "x", l.task("b"))
+		c := promisepool.SubmitKey(pool, ctx, "y", l.task("c"))
+		d := promisepool.SubmitKey(pool, ctx, "y", l.task("d"))
+		if !promisepool.Boost(d, 1) || !promisepool.Boost(b, 2) {
+			t.Error("expected queued tasks to be boosted")
+		}
+
+		if promisepool.Boost(b, 1) {
+			t.Error("expected a lower priority not to boost the task")
+		}
+
+		return []*promises.Promise[int]{a, b, c, d}
+	})
+
+	if want := []string{"b", "d", "a", "c"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestAwaitInheritsPriority(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(1)
+	defer pool.Drain(ctx)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+
+	l := &orderLog{}
+	low := promisepool.Submit(pool, ctx, l.task("low"))
+	shared := promisepool.Submit(pool, ctx, l.task("shared"))
+	critical := promisepool.SubmitWith(pool, ctx, promisepool.TaskOptions{Priority: 5}, func(ctx context.Context) (int, error) {
+		l.task("critical")(ctx)
+
+		// The shared task is queued behind low on the only worker, so waiting for it here would deadlock,
+		// but the attempt lends it this task's priority.
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		_, _ = promisepool.Await(ctx, shared)
+		return 0, nil
+	})
+
+	if !promisepool.Boost(critical, 10) {
+		t.Fatal("expected the critical task to be boosted")
+	}
+
+	close(block)
+	if _, err := promisepool.Await(promisepool.WithPriority(ctx, 10), critical); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	_, _ = promises.All(ctx, low, shared).Await(ctx)
+	if want := []string{"critical", "shared", "low"}; !reflect.DeepEqual(l.order, want) {
+		t.Errorf("expected %v, got %v", want, l.order)
+	}
+}
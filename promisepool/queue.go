@@ -0,0 +1,222 @@
+package promisepool
+
+import (
+	"sort"
+	"time"
+)
+
+// queue holds the tasks waiting for a worker, grouped by submission key.
+// Keys with waiting tasks take turns in round-robin order; a key with weight w may dispatch up to w tasks per turn,
+// and a key with a quota is skipped while that many of its tasks are running.
+// Boosted tasks are dispatched before all others, highest boost first.
+type queue struct {
+	keys    map[string]*keyQueue
+	ring    []string
+	cursor  int
+	credit  int
+	size    int
+	quotas  map[string]int
+	weights map[string]int
+	boosted []*task
+}
+
+type keyQueue struct {
+	tasks   []*task
+	running int
+}
+
+func newQueue() *queue {
+	return &queue{keys: make(map[string]*keyQueue), quotas: make(map[string]int), weights: make(map[string]int)}
+}
+
+func (q *queue) len() int {
+	return q.size
+}
+
+func (q *queue) push(t *task) {
+	kq, ok := q.keys[t.key]
+	if !ok {
+		kq = &keyQueue{}
+		q.keys[t.key] = kq
+	}
+
+	if len(kq.tasks) == 0 {
+		q.ring = append(q.ring, t.key)
+	}
+
+	kq.tasks = append(kq.tasks, t)
+	q.size++
+	t.queued = true
+	if t.promise != nil {
+		queuedTasks.Store(t.promise, t)
+	}
+}
+
+// pop returns the next task that may run, or nil if there is none.
+func (q *queue) pop() *task {
+	if t := q.popBoosted(); t != nil {
+		return t
+	}
+
+	for tries := len(q.ring); tries > 0; tries-- {
+		q.cursor %= len(q.ring)
+		key := q.ring[q.cursor]
+		kq := q.keys[key]
+		if quota := q.quotas[key]; quota > 0 && kq.running >= quota {
+			q.cursor++
+			q.credit = 0
+			continue
+		}
+
+		if q.credit == 0 {
+			q.credit = q.weight(key)
+		}
+
+		t := kq.tasks[0]
+		kq.tasks[0] = nil
+		kq.tasks = kq.tasks[1:]
+		kq.running++
+		q.size--
+		q.credit--
+		q.dequeued(t)
+
+		if len(kq.tasks) == 0 {
+			q.ring = append(q.ring[:q.cursor], q.ring[q.cursor+1:]...)
+			q.credit = 0
+		} else if q.credit == 0 {
+			q.cursor++
+		}
+
+		return t
+	}
+
+	return nil
+}
+
+// boost raises the priority of the queued task t, which it dispatches before the tasks that are not boosted.
+func (q *queue) boost(t *task, priority int) {
+	if t.boost == 0 {
+		q.boosted = append(q.boosted, t)
+	}
+
+	t.boost = priority
+	sort.SliceStable(q.boosted, func(i, j int) bool {
+		return q.boosted[i].boost > q.boosted[j].boost
+	})
+}
+
+// popBoosted returns the boosted task with the highest boost whose key is under its quota, or nil if there is none.
+func (q *queue) popBoosted() *task {
+	for _, t := range q.boosted {
+		kq := q.keys[t.key]
+		if quota := q.quotas[t.key]; quota > 0 && kq.running >= quota {
+			continue
+		}
+
+		for i, other := range kq.tasks {
+			if other == t {
+				kq.tasks = append(kq.tasks[:i], kq.tasks[i+1:]...)
+				break
+			}
+		}
+
+		if len(kq.tasks) == 0 {
+			q.removeKey(t.key)
+		}
+
+		kq.running++
+		q.size--
+		q.dequeued(t)
+		return t
+	}
+
+	return nil
+}
+
+// removeKey removes the key, whose tasks have all been taken out of order, from the ring.
+func (q *queue) removeKey(key string) {
+	for i, other := range q.ring {
+		if other != key {
+			continue
+		}
+
+		q.ring = append(q.ring[:i], q.ring[i+1:]...)
+		if i < q.cursor {
+			q.cursor--
+		} else if i == q.cursor {
+			q.credit = 0
+		}
+
+		return
+	}
+}
+
+// dequeued records that the task has left the queue.
+func (q *queue) dequeued(t *task) {
+	t.queued = false
+	if t.promise != nil {
+		queuedTasks.Delete(t.promise)
+	}
+
+	if t.boost == 0 {
+		return
+	}
+
+	for i, other := range q.boosted {
+		if other == t {
+			q.boosted = append(q.boosted[:i], q.boosted[i+1:]...)
+			return
+		}
+	}
+}
+
+// done records that a task returned by pop has finished.
+func (q *queue) done(key string) {
+	kq := q.keys[key]
+	kq.running--
+	if kq.running == 0 && len(kq.tasks) == 0 {
+		delete(q.keys, key)
+	}
+}
+
+// oldest returns when the longest-waiting task was queued. Tasks of a key are queued in order,
+// so only the first task of each key needs to be looked at.
+func (q *queue) oldest() (time.Time, bool) {
+	var oldest time.Time
+	for _, key := range q.ring {
+		if t := q.keys[key].tasks[0]; oldest.IsZero() || t.queuedAt.Before(oldest) {
+			oldest = t.queuedAt
+		}
+	}
+
+	return oldest, !oldest.IsZero()
+}
+
+// drain removes and returns every waiting task.
+func (q *queue) drain() []*task {
+	var tasks []*task
+	for _, key := range q.ring {
+		kq := q.keys[key]
+		for _, t := range kq.tasks {
+			q.dequeued(t)
+		}
+
+		tasks = append(tasks, kq.tasks...)
+		kq.tasks = nil
+		if kq.running == 0 {
+			delete(q.keys, key)
+		}
+	}
+
+	q.ring = nil
+	q.size = 0
+	return tasks
+}
+
+func (q *queue) weight(key string) int {
+	if w := q.weights[key]; w > 0 {
+		return w
+	}
+
+	return 1
+}
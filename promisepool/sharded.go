@@ -0,0 +1,222 @@
+package promisepool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// ShardedPool is a pool for very high submission rates. Each worker has its own queue, submissions are spread
+// over the queues without a shared lock, and idle workers steal from the queues of busy ones.
+// It trades the fairness, quotas, resizing and statistics of Pool for less contention, and runs tasks in no particular order.
+type ShardedPool struct {
+	shards  []*shard
+	next    atomic.Uint64
+	closed  atomic.Bool
+	closing chan struct{}
+	workers sync.WaitGroup
+	stopped chan struct{}
+}
+
+type shard struct {
+	tasks []*task
+	mutex sync.Mutex
+	wake  chan struct{}
+	idle  atomic.Bool
+}
+
+// NewSharded starts a sharded pool with size workers. size must be positive.
+func NewSharded(size int) *ShardedPool {
+	if size <= 0 {
+		panic("promisepool: non-positive size")
+	}
+
+	p := &ShardedPool{shards: make([]*shard, size), closing: make(chan struct{}), stopped: make(chan struct{})}
+	for i := range p.shards {
+		p.shards[i] = &shard{wake: make(chan struct{}, 1)}
+	}
+
+	p.workers.Add(size)
+	for i := range p.shards {
+		go p.work(i)
+	}
+
+	go func() {
+		p.workers.Wait()
+		close(p.stopped)
+	}()
+
+	return p
+}
+
+// Schedule queues run on the pool, which makes the pool a promises.Scheduler.
+// If the pool is drained, run is started on its own goroutine instead, so that the promise it settles is not stranded.
+func (p *ShardedPool) Schedule(run func()) {
+	if !p.enqueue(&task{run: run}) {
+		go run()
+	}
+}
+
+// Drain stops accepting tasks and returns a promise that is fulfilled once the queued and running tasks are done.
+// If ctx is done first, the tasks still queued are rejected with ErrPoolClosed and the promise is rejected with the context error.
+func (p *ShardedPool) Drain(ctx context.Context) *promises.Promise[struct{}] {
+	for _, s := range p.shards {
+		s.mutex.Lock()
+	}
+
+	if !p.closed.Swap(true) {
+		close(p.closing)
+	}
+
+	for _, s := range p.shards {
+		s.mutex.Unlock()
+	}
+
+	return promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+		select {
+		case <-p.stopped:
+			resolve(struct{}{})
+		case <-ctx.Done():
+			for _, s := range p.shards {
+				s.mutex.Lock()
+				tasks := s.tasks
+				s.tasks = nil
+				s.mutex.Unlock()
+
+				for _, t := range tasks {
+					t.abort()
+				}
+			}
+
+			reject(ctx.Err())
+		}
+	})
+}
+
+func (p *ShardedPool) enqueue(t *task) bool {
+	i := int(p.next.Add(1) % uint64(len(p.shards)))
+	s := p.shards[i]
+
+	s.mutex.Lock()
+	if p.closed.Load() {
+		s.mutex.Unlock()
+		if t.reject != nil {
+			t.reject(ErrPoolClosed)
+		}
+
+		return false
+	}
+
+	s.tasks = append(s.tasks, t)
+	s.mutex.Unlock()
+
+	s.notify()
+	if !s.idle.Load() {
+		for _, other := range p.shards {
+			if other.idle.Load() {
+				other.notify()
+				break
+			}
+		}
+	}
+
+	return true
+}
+
+func (p *ShardedPool) work(i int) {
+	defer p.workers.Done()
+
+	s := p.shards[i]
+	for {
+		if t := p.take(i); t != nil {
+			if !t.safeRun() {
+				p.replace(i)
+				return
+			}
+
+			continue
+		}
+
+		// Announce idleness before checking again, so that a submission racing with the check wakes this worker.
+		s.idle.Store(true)
+		if t := p.take(i); t != nil {
+			s.idle.Store(false)
+			if !t.safeRun() {
+				p.replace(i)
+				return
+			}
+
+			continue
+		}
+
+		if p.closed.Load() {
+			s.idle.Store(false)
+			return
+		}
+
+		select {
+		case <-s.wake:
+		case <-p.closing:
+		}
+
+		s.idle.Store(false)
+	}
+}
+
+// replace starts a new worker for the shard of a worker that is exiting after a panic.
+func (p *ShardedPool) replace(i int) {
+	p.workers.Add(1)
+	go p.work(i)
+}
+
+// take returns the oldest task of the worker's own queue, or steals the newest task of another queue.
+func (p *ShardedPool) take(i int) *task {
+	if t := p.shards[i].popFront(); t != nil {
+		return t
+	}
+
+	for j := 1; j < len(p.shards); j++ {
+		if t := p.shards[(i+j)%len(p.shards)].popBack(); t != nil {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *shard) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *shard) popFront() *task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.tasks) == 0 {
+		return nil
+	}
+
+	t := s.tasks[0]
+	s.tasks[0] = nil
+	s.tasks = s.tasks[1:]
+	return t
+}
+
+func (s *shard) popBack() *task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.tasks) == 0 {
+		return nil
+	}
+
+	t := s.tasks[len(s.tasks)-1]
+	s.tasks[len(s.tasks)-1] = nil
+	s.tasks = s.tasks[:len(s.tasks)-1]
+	return t
+}
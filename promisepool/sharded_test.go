@@ -0,0 +1,98 @@
+package promisepool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	"github.com/oneofthezombies/promises/promisepool"
+)
+
+func TestShardedSubmit(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.NewSharded(4)
+	defer pool.Drain(ctx)
+
+	var mutex sync.Mutex
+	var results []*promises.Promise[int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				p := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+					return 1, nil
+				})
+
+				mutex.Lock()
+				results = append(results, p)
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	v, err := promises.All(ctx, results...).Await(ctx)
+	if err != nil || len(v) != 800 {
+		t.Errorf("expected 800 results, got %d, %v", len(v), err)
+	}
+}
+
+func TestShardedStealing(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.NewSharded(2)
+	defer pool.Drain(ctx)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+	defer close(block)
+
+	// With one worker blocked, half of these land on its queue and can only run if the other worker steals them.
+	results := make([]*promises.Promise[int], 10)
+	for i := range results {
+		results[i] = promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+			return 0, nil
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, err := promises.All(ctx, results...).Await(ctx); err != nil {
+		t.Errorf("expected the idle worker to steal the queued tasks, got %v", err)
+	}
+}
+
+func TestShardedDrain(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.NewSharded(2)
+	p := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	})
+
+	if _, err := pool.Drain(ctx).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if !p.IsFulfilled() {
+		t.Error("expected the running task to be done")
+	}
+
+	late := promisepool.Submit(pool, ctx, func(ctx context.Context) (int, error) {
+		return 0, nil
+	})
+	if _, err := late.Await(ctx); !errors.Is(err, promisepool.ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
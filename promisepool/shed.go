@@ -0,0 +1,73 @@
+package promisepool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var (
+	// ErrShed is the reason a task is rejected with when the pool is overloaded and the task's priority is too low.
+	ErrShed = errors.New("pool overloaded: task shed")
+)
+
+// ShedPolicy decides when a pool rejects low-priority tasks instead of queuing them.
+// The pool is overloaded when either threshold is exceeded; a zero threshold is not checked.
+type ShedPolicy struct {
+	// MaxQueued is the queue depth above which the pool is overloaded.
+	MaxQueued int
+
+	// MaxQueueWait is how long the oldest queued task may have waited before the pool is overloaded.
+	MaxQueueWait time.Duration
+
+	// Priority is the lowest priority still accepted while the pool is overloaded.
+	Priority int
+}
+
+// TaskOptions configures a submitted task.
+type TaskOptions struct {
+	// Key groups the task for fairness and quotas. See SubmitKey.
+	Key string
+
+	// Priority decides whether the task is shed while the pool is overloaded. Higher is more important.
+	Priority int
+}
+
+// SetShedding sets the shedding policy of the pool. The zero policy never sheds.
+func (p *Pool) SetShedding(policy ShedPolicy) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.shedding = policy
+}
+
+// SubmitWith is Submit with options. While the pool is overloaded under its shedding policy, a task with a priority
+// below the policy's is rejected with ErrShed immediately, so that important tasks keep meeting their deadlines.
+func SubmitWith[T any](p *Pool, ctx context.Context, opts TaskOptions, fn func(ctx context.Context) (T, error)) *promises.Promise[T] {
+	promise, t := newTask(opts.Key, ctx, fn)
+	t.priority = opts.Priority
+	p.enqueue(t)
+	return promise
+}
+
+// shed reports whether the task should be rejected with ErrShed. p.mutex must be held.
+func (p *Pool) shed(t *task, now time.Time) bool {
+	policy := p.shedding
+	if t.reject == nil || t.priority >= policy.Priority {
+		return false
+	}
+
+	if policy.MaxQueued > 0 && p.queue.len() >= policy.MaxQueued {
+		return true
+	}
+
+	if policy.MaxQueueWait > 0 {
+		if oldest, ok := p.queue.oldest(); ok && now.Sub(oldest) > policy.MaxQueueWait {
+			return true
+		}
+	}
+
+	return false
+}
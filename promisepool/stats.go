@@ -0,0 +1,89 @@
+package promisepool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Stats is a snapshot of the state and history of a pool.
+type Stats struct {
+	// Workers is the number of worker goroutines, of which Busy are running a task and Idle are waiting for one.
+	Workers, Busy, Idle int
+
+	// Queued is the number of tasks waiting for a worker.
+	Queued int
+
+	// Submitted counts every task submitted, Completed the tasks that ran to the end,
+	// and Rejected the tasks the pool refused or aborted with ErrPoolClosed.
+	Submitted, Completed, Rejected uint64
+
+	// Shed counts the tasks rejected with ErrShed.
+	Shed uint64
+
+	// Panics counts the tasks that panicked. Their workers were replaced.
+	Panics uint64
+
+	// AverageWait is the average time tasks spent queued before a worker picked them up.
+	AverageWait time.Duration
+}
+
+type counters struct {
+	busy      int
+	submitted uint64
+	started   uint64
+	completed uint64
+	rejected  uint64
+	shed      uint64
+	panics    uint64
+	totalWait time.Duration
+}
+
+// Stats returns a snapshot of the pool.
+func (p *Pool) Stats() Stats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	s := Stats{
+		Workers:   p.workers,
+		Busy:      p.stats.busy,
+		Idle:      p.workers - p.stats.busy,
+		Queued:    p.queue.len(),
+		Submitted: p.stats.submitted,
+		Completed: p.stats.completed,
+		Rejected:  p.stats.rejected,
+		Shed:      p.stats.shed,
+		Panics:    p.stats.panics,
+	}
+
+	if p.stats.started > 0 {
+		s.AverageWait = p.stats.totalWait / time.Duration(p.stats.started)
+	}
+
+	return s
+}
+
+// OnStats calls fn with a snapshot of the pool every interval on the package clock until stop is called.
+func (p *Pool) OnStats(interval time.Duration, fn func(Stats)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			timer := promises.CurrentClock().NewTimer(interval)
+			select {
+			case <-timer.C():
+				fn(p.Stats())
+			case <-done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package promisepool
+
+import (
+	"runtime"
+	"sync"
+)
+
+// threadWorker runs functions one at a time, in order, on a goroutine locked to its OS thread.
+type threadWorker struct {
+	queue  []func()
+	closed bool
+	cond   *sync.Cond
+	mutex  sync.Mutex
+}
+
+func newThreadWorker() *threadWorker {
+	w := &threadWorker{}
+	w.cond = sync.NewCond(&w.mutex)
+	go w.work()
+	return w
+}
+
+// ScheduleOnOSThread queues run on the pool's thread-affine worker, a goroutine locked to one OS thread that is
+// started on first use, which makes the pool a promises.OSThreadScheduler for promises created with promises.WithOSThread.
+// Everything queued this way runs one at a time, in order, on that same thread, apart from the pool's other workers.
+// If the pool is drained, run is started on its own locked goroutine instead.
+func (p *Pool) ScheduleOnOSThread(run func()) {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		go func() {
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+
+			run()
+		}()
+
+		return
+	}
+
+	if p.thread == nil {
+		p.thread = newThreadWorker()
+	}
+
+	w := p.thread
+	p.mutex.Unlock()
+
+	w.push(run)
+}
+
+func (w *threadWorker) push(run func()) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.queue = append(w.queue, run)
+	w.cond.Signal()
+}
+
+// close lets the worker exit once its queue is empty.
+func (w *threadWorker) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	w.cond.Signal()
+}
+
+func (w *threadWorker) work() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for {
+		for len(w.queue) == 0 {
+			if w.closed {
+				return
+			}
+
+			w.cond.Wait()
+		}
+
+		run := w.queue[0]
+		w.queue[0] = nil
+		w.queue = w.queue[1:]
+
+		w.mutex.Unlock()
+		(&task{run: run}).safeRun()
+		w.mutex.Lock()
+	}
+}
@@ -0,0 +1,42 @@
+//go:build linux
+
+package promisepool_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	"github.com/oneofthezombies/promises/promisepool"
+)
+
+func threadID() int {
+	return syscall.Gettid()
+}
+
+func TestScheduleOnOSThread(t *testing.T) {
+	ctx := context.Background()
+	pool := promisepool.New(2)
+	defer pool.Drain(ctx)
+
+	var tids []int
+	var results []*promises.Promise[int]
+	for i := 0; i < 3; i++ {
+		i := i
+		results = append(results, promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+			tids = append(tids, threadID())
+			resolve(i)
+		}, promises.WithScheduler(pool), promises.WithOSThread()))
+	}
+
+	if _, err := promises.All(ctx, results...).Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tid := range tids {
+		if tid != tids[0] {
+			t.Errorf("expected every executor on the same OS thread, got %v", tids)
+		}
+	}
+}
@@ -3,25 +3,63 @@ package promises
 import (
 	"context"
 	"errors"
+	"runtime"
 	"sync"
-
-	"github.com/oneofthezombies/option"
+	"sync/atomic"
 )
 
 var (
 	errNilReason = errors.New("nil reason")
 )
 
+// Resolve fulfills a promise with a value.
+// Any value is a valid fulfillment, including the zero value and nil, and combinators treat it as success.
+// Use Promise[struct{}] for promises that are fulfilled with no value.
 type Resolve[T any] func(T)
 type Reject func(error)
 type Executor[T any] func(Resolve[T], Reject)
 
 // Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise
 type Promise[T any] struct {
-	optionalValue option.Option[T]
-	reason        error
-	done          chan any
-	mutex         sync.RWMutex
+	value    T
+	reason   error
+	mutex    sync.RWMutex
+	info     *Info
+	progress *progressState
+	handled  atomic.Bool
+	opts     *options
+	spilled  *spilledValue
+
+	// observers are the observers notified of the creation of the promise, and so of its awaits and settlement,
+	// even if observers are added or removed in the meantime.
+	observers []Observer
+
+	// status is the Status of the promise. It is stored after the outcome, under mutex, and can be loaded without it.
+	status atomic.Int32
+
+	// done is closed once the promise is finished, that is settled with its observers notified.
+	// It is created on demand by doneChan, under mutex, so that promises nobody blocks on do not allocate it.
+	done     chan struct{}
+	finished atomic.Bool
+
+	// source is the promise that holds the state of a promise created with WithOrphanCancel, see orphanable,
+	// and holder keeps its handle reachable from it while callbacks or channel waiters depend on the handle, see pin.
+	source *Promise[T]
+	holder atomic.Pointer[Promise[T]]
+
+	// upstream releases the source of a derived promise when it is canceled, and consumers counts
+	// the derived promises of this one that were not canceled, see consume, or is -1 once they all were.
+	// A shared promise is handed out through handles, which count as its consumers.
+	upstream  func(cause error)
+	consumers atomic.Int32
+	shared    atomic.Bool
+
+	// lazy starts the executor of a promise created with Lazy, see startLazy.
+	lazy atomic.Pointer[func()]
+
+	callbacks        []*func(SettledResult[T])
+	orderedCallbacks bool
+	dispatching      bool
 }
 
 type Status int32
@@ -29,12 +67,14 @@ type Status int32
 const (
 	Fulfilled Status = iota
 	Rejected
+	// Pending is the state of a promise that is not settled yet. Settled results never have it.
+	Pending
 )
 
-var statusStrings = [...]string{"fulfilled", "rejected"}
+var statusStrings = [...]string{"fulfilled", "rejected", "pending"}
 
 func (s Status) String() string {
-	if s < Fulfilled || s > Rejected {
+	if s < Fulfilled || s > Pending {
 		return "unknown"
 	}
 
@@ -42,105 +82,482 @@ func (s Status) String() string {
 }
 
 // New creates a new promise.
-func New[T any](executor Executor[T]) *Promise[T] {
-	p := &Promise[T]{
-		optionalValue: option.None[T](),
-		reason:        nil,
-		done:          make(chan any),
+func New[T any](executor Executor[T], opts ...Option) *Promise[T] {
+	o := newOptions(opts)
+	checkDisposer[T](o)
+	if o != nil && o.idempotencyKey != "" {
+		if p, ok := joinIdempotent[T](o); ok {
+			return p
+		}
+
+		// The promise is shared with the creations that join it, so each of them gets a handle of its own.
+		return create(executor, o).handle()
 	}
 
-	resolve := func(value T) {
-		p.mutex.Lock()
-		defer p.mutex.Unlock()
+	return create(executor, o)
+}
 
-		if p.isSettled() {
-			return
+// create creates the promise of New with the options o.
+func create[T any](executor Executor[T], o *options) *Promise[T] {
+	err := o.admit()
+	p, resolve, reject := newPending[T](o)
+	if o != nil && o.idempotent != nil {
+		o.idempotent.start(p.share())
+		if o.idempotent.outcome != nil {
+			settleEncoded(o.idempotent.outcome, resolve, reject)
+			return p
 		}
+	}
 
-		defer close(p.done)
-		p.optionalValue = option.Some(value)
+	if err != nil {
+		reject(err)
+		return p
 	}
 
-	reject := func(reason error) {
-		p.mutex.Lock()
-		defer p.mutex.Unlock()
+	if o != nil && o.name != "" {
+		if o.replay != nil {
+			replay(o.replay, o.name, resolve, reject)
+			return p
+		}
 
-		if p.isSettled() {
-			return
+		if o.record != nil {
+			o.recordSlot = o.record.reserve(o.name)
+			o.recording = true
 		}
+	}
 
-		defer close(p.done)
-		if reason == nil {
-			p.reason = errNilReason
-			return
+	mws := loadMiddlewares()
+	reporting := panicReporters.active()
+	if len(mws) == 0 && !reporting && o.direct() {
+		if o != nil && o.sync {
+			runRecovered(p.info, executor, resolve, reject)
+		} else {
+			go runRecovered(p.info, executor, resolve, reject)
 		}
 
-		p.reason = reason
+		return p
+	}
+
+	run := func() {
+		executor(resolve, reject)
+	}
+
+	if o != nil && o.tracking {
+		run = trackExecutor(*p.info, run)
 	}
 
-	go executor(resolve, reject)
+	if o != nil && o.chaos != nil {
+		run = o.chaos.wrap(o.name, reject, run)
+	}
+
+	if o != nil {
+		run = chain(run, o.middleware)
+	}
+
+	run = chain(run, mws)
+	recovering := o == nil || !o.failFast
+	next := run
+	run = func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			err := newPanicError(r)
+			if reporting {
+				reportPanic(p.info, err)
+			}
+
+			if !recovering {
+				panic(r)
+			}
+
+			if o != nil && o.panicHandler != nil {
+				o.panicHandler(err)
+			}
+
+			reject(err)
+		}()
+
+		next()
+	}
+
+	if o == nil {
+		go run()
+		return p
+	}
+
+	start := func() {
+		if o.timeout > 0 {
+			timer := CurrentClock().NewTimer(o.timeout)
+			go func() {
+				select {
+				case <-timer.C():
+					reject(ErrTimeout)
+				case <-p.doneChan():
+					timer.Stop()
+				}
+			}()
+		}
+
+		if o.sync {
+			run()
+		} else if s, ok := o.scheduler.(OSThreadScheduler); ok && o.osThread {
+			s.ScheduleOnOSThread(run)
+		} else {
+			if o.osThread {
+				run = lockOSThread(run)
+			}
+
+			if s, ok := o.scheduler.(PrioritizedScheduler); ok && o.prioritized {
+				s.SchedulePriority(run, o.priority)
+			} else if o.scheduler != nil {
+				o.scheduler.Schedule(run)
+			} else {
+				go run()
+			}
+		}
+	}
+
+	if o.lazy {
+		p.lazy.Store(&start)
+	} else {
+		start()
+	}
+
+	if o.orphanCancel {
+		return orphanable(p)
+	}
 
 	return p
 }
 
+// newPending creates a pending promise and the functions that settle it.
+// o may be nil if no options are given.
+func newPending[T any](o *options) (*Promise[T], Resolve[T], Reject) {
+	p := &Promise[T]{}
+	p.status.Store(int32(Pending))
+
+	p.info, p.observers = observeCreate(o)
+	p.opts = o
+	if o != nil {
+		p.orderedCallbacks = o.orderedCallbacks
+	}
+	if o != nil && o.progress != nil {
+		o.progress.finished = &p.finished
+		p.progress = o.progress
+	}
+
+	resolve := func(value T) {
+		p.fulfill(o, value, nil)
+	}
+
+	reject := func(reason error) {
+		p.reject(o, reason, nil)
+	}
+
+	return p, resolve, reject
+}
+
+// fulfill fulfills the promise with value unless it is already settled.
+// If batch is not nil, the callbacks of the promise are added to it instead of being dispatched.
+func (p *Promise[T]) fulfill(o *options, value T, batch *callbackBatch) {
+	spilled := spillFulfilled(o, value)
+	p.mutex.Lock()
+	if p.isSettled() {
+		p.mutex.Unlock()
+		if spilled != nil {
+			spilled.remove()
+		}
+
+		// Nobody can consume a value that arrives after the promise was canceled or timed out.
+		p.dispose(value)
+		return
+	}
+
+	if spilled != nil {
+		var zero T
+		value = zero
+		p.spilled = spilled
+		keepSpilled(p, spilled)
+	}
+
+	p.value = value
+	p.status.Store(int32(Fulfilled))
+	p.mutex.Unlock()
+
+	p.settled(o, Fulfilled, nil, batch)
+}
+
+// reject is fulfill for rejections. It reports whether it settled the promise.
+func (p *Promise[T]) reject(o *options, reason error, batch *callbackBatch) bool {
+	p.mutex.Lock()
+	if p.isSettled() {
+		p.mutex.Unlock()
+		return false
+	}
+
+	if reason == nil {
+		reason = errNilReason
+	}
+
+	p.reason = reason
+	p.status.Store(int32(Rejected))
+	p.mutex.Unlock()
+
+	p.settled(o, Rejected, reason, batch)
+	watchRejection(p, reason)
+	return true
+}
+
+// settled notifies observers, logs and wakes up waiters once the outcome of the promise is stored.
+func (p *Promise[T]) settled(o *options, status Status, reason error, batch *callbackBatch) {
+	if hooks.settle != nil {
+		hooks.settle()
+	}
+
+	if p.info != nil {
+		var value any
+		if status == Fulfilled {
+			value = p.Value()
+		}
+
+		observeSettle(*p.info, p.observers, status, value, reason)
+	}
+
+	if o != nil {
+		o.logSettle(status, reason)
+		if o.recording {
+			result, _ := p.Snapshot()
+			record(o.record, o.name, o.recordSlot, result)
+		}
+
+		if o.idempotent != nil {
+			var outcome []byte
+			var err error
+			if status == Fulfilled {
+				result, _ := p.Snapshot()
+				outcome, err = result.MarshalBinary()
+			}
+
+			o.idempotent.finish(outcome, err)
+		}
+
+		if o.cancelExecutor != nil {
+			o.cancelExecutor(cancelCause(reason))
+		}
+
+		o.release()
+	}
+
+	p.finish()
+	if batch != nil {
+		batchCallbacks(p, batch)
+	} else {
+		p.dispatch()
+	}
+}
+
+// startLazy starts the executor of a promise created with Lazy the first time it is called, unless the promise
+// has already been settled, for example canceled.
+func (p *Promise[T]) startLazy() {
+	if p.lazy.Load() == nil {
+		return
+	}
+
+	if start := p.lazy.Swap(nil); start != nil && !p.isSettled() {
+		(*start)()
+	}
+}
+
+func (p *Promise[T]) loadStatus() Status {
+	return Status(p.status.Load())
+}
+
 func (p *Promise[T]) isFulfilled() bool {
-	_, ok := p.optionalValue.Value()
-	return ok
+	return p.loadStatus() == Fulfilled
 }
 
 func (p *Promise[T]) isRejected() bool {
-	return p.reason != nil
+	return p.loadStatus() == Rejected
 }
 
 func (p *Promise[T]) isSettled() bool {
-	return p.isFulfilled() || p.isRejected()
+	return p.loadStatus() != Pending
 }
 
 // Await blocks until the promise is settled and returns the value and reason or an error if the context is canceled.
+// The value is the zero value whenever the error is not nil: the context error if ctx is done first,
+// or the rejection reason, which is never nil.
 func (p *Promise[T]) Await(ctx context.Context) (T, error) {
-	select {
-	case <-ctx.Done():
-		o := option.None[T]()
-		v, _ := o.Value()
-		return v, ctx.Err()
-	case <-p.done:
-		break
+	// An orphanable handle must not be collected while it is awaited.
+	defer runtime.KeepAlive(p)
+	p = p.target()
+	p.startLazy()
+	if p.info != nil {
+		if os := awaitObservers(p.observers); len(os) > 0 {
+			start := CurrentClock().Now()
+			observeAwaitStart(os, *p.info)
+			v, err := p.await(ctx)
+			observeAwaitEnd(os, *p.info, start, err)
+			return v, err
+		}
+	}
+
+	return p.await(ctx)
+}
+
+// await is Await of the promise holding the state, without observation.
+func (p *Promise[T]) await(ctx context.Context) (T, error) {
+	// A finished promise is read without creating its done channel.
+	if !p.finished.Load() {
+		if waits.running.Load() > 0 && p.info != nil {
+			end, err := beginWait(*p.info)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+
+			defer end()
+		}
+
+		done := p.doneChan()
+		if hooks.awaitSelect != nil {
+			hooks.awaitSelect()
+		}
+
+		select {
+		case <-ctx.Done():
+			// A settlement that raced with the cancellation wins.
+			select {
+			case <-done:
+				break
+			default:
+				var zero T
+				return zero, ctx.Err()
+			}
+		case <-done:
+			break
+		}
 	}
 
 	p.mutex.RLock()
-	o := p.optionalValue
+	v := p.value
 	r := p.reason
+	s := p.spilled
 	p.mutex.RUnlock()
 
-	v, _ := o.Value()
+	if r != nil {
+		p.handled.Store(true)
+	}
+
+	if s != nil {
+		return p.load(v, s)
+	}
+
 	return v, r
 }
 
 // Returns a channel that is closed when the promise is settled.
-func (p *Promise[T]) Done() <-chan any {
+// No value is ever sent on it; use Await, Value or Reason to read the outcome.
+func (p *Promise[T]) Done() <-chan struct{} {
+	p.pin()
+	p.target().startLazy()
+	return p.doneChan()
+}
+
+// closedDone is the done channel of promises that finished before anyone asked for one.
+var closedDone = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// doneChan returns the channel closed once the promise is finished, creating it on first use.
+func (p *Promise[T]) doneChan() <-chan struct{} {
+	p = p.target()
+	if p.finished.Load() {
+		return closedDone
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.finished.Load() {
+		return closedDone
+	}
+
+	if p.done == nil {
+		p.done = make(chan struct{})
+	}
+
 	return p.done
 }
 
+// finish marks the promise as finished once its outcome is stored and observers are notified,
+// and wakes up the goroutines blocked on its done channel.
+func (p *Promise[T]) finish() {
+	p.mutex.Lock()
+	p.finished.Store(true)
+	done := p.done
+	p.mutex.Unlock()
+	p.holder.Store(nil)
+
+	if done != nil {
+		close(done)
+	}
+}
+
 // Get the value that the promise was fulfilled with.
 // This method does not guarantee that the promise is settled.
 // If you want to ensure that the promise is settled, use the Await() or Done() method before calling this method.
 func (p *Promise[T]) Value() T {
+	p = p.target()
+
 	p.mutex.RLock()
-	o := p.optionalValue
+	v := p.value
+	s := p.spilled
 	p.mutex.RUnlock()
 
-	v, _ := o.Value()
+	v, _ = p.load(v, s)
 	return v
 }
 
+// GetNow returns the value and true if the promise is already fulfilled, or the zero value and false otherwise.
+// It does not block.
+func (p *Promise[T]) GetNow() (T, bool) {
+	p = p.target()
+
+	p.mutex.RLock()
+	v := p.value
+	s := p.spilled
+	ok := p.isFulfilled()
+	p.mutex.RUnlock()
+
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	v, err := p.load(v, s)
+	return v, err == nil
+}
+
 // Get the reason that the promise was rejected.
 // This method does not guarantee that the promise is settled.
 // If you want to ensure that the promise is settled, use the Await() or Done() method before calling this method.
 func (p *Promise[T]) Reason() error {
+	p = p.target()
+
 	p.mutex.RLock()
 	r := p.reason
 	p.mutex.RUnlock()
 
+	if r != nil {
+		p.handled.Store(true)
+	}
+
 	return r
 }
 
@@ -156,62 +573,91 @@ func (p *Promise[T]) Err() error {
 // This method does not guarantee that the promise is settled.
 // If you want to ensure that the promise is settled, use the Await() or Done() method before calling this method.
 func (p *Promise[T]) IsFulfilled() bool {
-	p.mutex.RLock()
-	o := p.optionalValue
-	p.mutex.RUnlock()
-
-	_, ok := o.Value()
-	return ok
+	return p.target().isFulfilled()
 }
 
 // Returns true if the promise is rejected.
 // This method does not guarantee that the promise is settled.
 // If you want to ensure that the promise is settled, use the Await() or Done() method before calling this method.
 func (p *Promise[T]) IsRejected() bool {
-	p.mutex.RLock()
-	r := p.reason
-	p.mutex.RUnlock()
-
-	return r != nil
+	return p.target().isRejected()
 }
 
 // Returns true if the promise is fulfilled or rejected.
 // This method does not guarantee that the promise is settled.
 // If you want to ensure that the promise is settled, use the Await() or Done() method before calling this method.
 func (p *Promise[T]) IsSettled() bool {
-	p.mutex.RLock()
-	o := p.optionalValue
-	r := p.reason
-	p.mutex.RUnlock()
+	return p.target().isSettled()
+}
+
+// Status returns the current status of the promise: Pending, Fulfilled or Rejected.
+// It does not block and does not take the lock of the promise.
+func (p *Promise[T]) Status() Status {
+	return p.target().loadStatus()
+}
+
+// State is Status.
+func (p *Promise[T]) State() Status {
+	return p.Status()
+}
+
+// Fork returns an independent promise that settles with the same outcome as p.
+// The fork has its own options, so it can for example time out or be tracked without affecting p or other forks.
+func (p *Promise[T]) Fork(opts ...Option) *Promise[T] {
+	fork := New(func(resolve Resolve[T], reject Reject) {
+		<-p.Done()
+		if r := p.Reason(); r != nil {
+			reject(r)
+			return
+		}
+
+		resolve(p.Value())
+	}, opts...)
 
-	_, ok := o.Value()
-	return ok || r != nil
+	link(fork.info, p.info)
+	return fork
 }
 
 // Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/all
+// The returned promise is rejected with an *IndexError as soon as one of the promises rejects,
+// and stops waiting for the others.
 func All[T any](ctx context.Context, promises ...*Promise[T]) *Promise[[]T] {
+	return all(ctx, nil, promises)
+}
+
+// all is All with opts, which may be nil.
+func all[T any](ctx context.Context, opts *AllOptions, promises []*Promise[T]) *Promise[[]T] {
+	progress := aggregateProgress(promises)
 	p := New(func(resolve Resolve[[]T], reject Reject) {
-		var wg sync.WaitGroup
-		wg.Add(len(promises))
+		if len(promises) == 0 {
+			resolve([]T{})
+			return
+		}
 
-		results := make([]T, len(promises))
+		s := &allState[T]{results: make([]T, len(promises))}
+		s.remaining.Store(int64(len(promises)))
+		s.ctx, s.cancel = context.WithCancelCause(ctx)
 		for i, promise := range promises {
-			go func(i int, promise *Promise[T]) {
-				defer wg.Done()
-
-				v, err := promise.Await(ctx)
+			go func() {
+				v, err := awaitChild(s.ctx, opts, i, promise)
 				if err != nil {
-					reject(err)
+					s.fail(ctx, opts, i, err, reject, promises)
 					return
 				}
 
-				results[i] = v
-			}(i, promise)
+				s.results[i] = v
+				progress.complete(i)
+				if s.remaining.Add(-1) == 0 {
+					s.cancel(nil)
+					resolve(s.results)
+				}
+			}()
 		}
+	}, progress.options()...)
 
-		wg.Wait()
-		resolve(results)
-	})
+	for _, promise := range promises {
+		link(p.info, promise.info)
+	}
 
 	return p
 }
@@ -224,6 +670,12 @@ type SettledResult[T any] struct {
 
 // Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/allSettled
 func AllSettled[T any](ctx context.Context, promises ...*Promise[T]) *Promise[[]SettledResult[T]] {
+	return allSettled(ctx, nil, promises)
+}
+
+// allSettled is AllSettled with opts, which may be nil.
+func allSettled[T any](ctx context.Context, opts *AllOptions, promises []*Promise[T]) *Promise[[]SettledResult[T]] {
+	progress := aggregateProgress(promises)
 	p := New(func(resolve Resolve[[]SettledResult[T]], reject Reject) {
 		var wg sync.WaitGroup
 		wg.Add(len(promises))
@@ -232,8 +684,13 @@ func AllSettled[T any](ctx context.Context, promises ...*Promise[T]) *Promise[[]
 		for i, promise := range promises {
 			go func(i int, promise *Promise[T]) {
 				defer wg.Done()
+				defer progress.complete(i)
+
+				v, err := awaitChild(ctx, opts, i, promise)
+				if err == errChildTimeout {
+					err = indexed(i, err)
+				}
 
-				v, err := promise.Await(ctx)
 				if err != nil {
 					results[i] = SettledResult[T]{Status: Rejected, Reason: err}
 					return
@@ -245,7 +702,11 @@ func AllSettled[T any](ctx context.Context, promises ...*Promise[T]) *Promise[[]
 
 		wg.Wait()
 		resolve(results)
-	})
+	}, progress.options()...)
+
+	for _, promise := range promises {
+		link(p.info, promise.info)
+	}
 
 	return p
 }
@@ -124,6 +124,26 @@ func TestAwaitError(t *testing.T) {
 	}
 }
 
+func TestAwaitZeroValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	v, err := blocked(block).Await(ctx)
+	if v != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected 0, context.Canceled, got %v, %v", v, err)
+	}
+
+	v, err = New(func(resolve Resolve[int], reject Reject) {
+		reject(nil)
+	}).Await(context.Background())
+	if v != 0 || err == nil {
+		t.Errorf("expected 0 and a non-nil reason, got %v, %v", v, err)
+	}
+}
+
 func TestAwaitStruct(t *testing.T) {
 	ctx := context.Background()
 	p := New(func(resolve Resolve[struct{ Name string }], reject Reject) {
@@ -267,3 +287,91 @@ func TestAllSettledWithLoop(t *testing.T) {
 		}
 	}
 }
+
+func TestFork(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+
+	for _, fork := range []*Promise[int]{p.Fork(), p.Fork()} {
+		v, err := fork.Await(ctx)
+		if err != nil || v != 1 {
+			t.Errorf("expected 1, got %v, %v", v, err)
+		}
+	}
+}
+
+func TestForkIsIndependent(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	})
+
+	timedOut := p.Fork(WithTimeout(time.Millisecond))
+	if _, err := timedOut.Await(ctx); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+
+	close(block)
+	v, err := p.Fork().Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected the upstream to be unaffected, got %v, %v", v, err)
+	}
+}
+
+func TestGetNow(t *testing.T) {
+	block := make(chan struct{})
+	p := New(func(resolve Resolve[int], reject Reject) {
+		<-block
+		resolve(1)
+	})
+
+	if v, ok := p.GetNow(); ok {
+		t.Errorf("expected no value while pending, got %v", v)
+	}
+
+	close(block)
+	<-p.Done()
+	if v, ok := p.GetNow(); !ok || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, ok)
+	}
+
+	rejected := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("reason"))
+	})
+	<-rejected.Done()
+	if v, ok := rejected.GetNow(); ok {
+		t.Errorf("expected no value when rejected, got %v", v)
+	}
+}
+
+func TestAllWithZeroValues(t *testing.T) {
+	ctx := context.Background()
+	none := New(func(resolve Resolve[struct{}], reject Reject) {
+		resolve(struct{}{})
+	})
+	nilPointer := New(func(resolve Resolve[*int], reject Reject) {
+		resolve(nil)
+	})
+
+	if _, err := All(ctx, none, none).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	v, err := All(ctx, nilPointer, nilPointer).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 2 || v[0] != nil || v[1] != nil {
+		t.Errorf("expected two nil values, got %v", v)
+	}
+
+	results, err := AllSettled(ctx, nilPointer).Await(ctx)
+	if err != nil || results[0].Status != Fulfilled {
+		t.Errorf("expected the nil value to be fulfilled, got %v, %v", results, err)
+	}
+}
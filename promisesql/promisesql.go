@@ -0,0 +1,127 @@
+// Package promisesql provides promise-based helpers for database/sql.
+// QueryRow is the promise of a single-row query; use promises.Wrap for other blocking calls.
+package promisesql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Querier is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Scanner is implemented by *sql.Row and *sql.Rows.
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanFunc scans the current row into a value of type T.
+type ScanFunc[T any] func(Scanner) (T, error)
+
+// Row is the row of a query run with QueryRow. Scan it once to read its columns and release its connection.
+type Row struct {
+	row    *sql.Row
+	cancel context.CancelCauseFunc
+}
+
+// Scan copies the columns of the row into dest, like (*sql.Row).Scan, and releases the row.
+func (r *Row) Scan(dest ...any) error {
+	defer r.cancel(nil)
+	return r.row.Scan(dest...)
+}
+
+// QueryRow runs a query that is expected to return at most one row and returns a promise that is fulfilled with
+// the row, for the caller to scan. The query is bound to ctx, so canceling ctx or the promise aborts the query.
+// A row that arrives after the promise was canceled or timed out is released for the caller,
+// so that it does not hold on to its connection.
+func QueryRow(ctx context.Context, q Querier, query string, args ...any) *promises.Promise[*Row] {
+	return promises.NewWithContext(ctx, func(pctx context.Context, resolve promises.Resolve[*Row], reject promises.Reject) {
+		// The row outlives the promise, so the query is bound to a context that the promise cancels
+		// only if it is canceled first, and that scanning the row releases.
+		queryCtx, cancel := context.WithCancelCause(ctx)
+		stop := context.AfterFunc(pctx, func() {
+			cancel(context.Cause(pctx))
+		})
+
+		row := q.QueryRowContext(queryCtx, query, args...)
+		if err := row.Err(); err != nil {
+			cancel(err)
+			reject(err)
+			return
+		}
+
+		stop()
+		resolve(&Row{row: row, cancel: cancel})
+	}, promises.WithDisposer(releaseRow))
+}
+
+// releaseRow releases a row nobody will scan. Scanning closes it whatever the arguments.
+func releaseRow(r *Row) {
+	_ = r.Scan()
+}
+
+// QueryRowAsync runs a query that is expected to return at most one row and returns a promise that is fulfilled with the scanned row.
+// The query is bound to ctx, so canceling ctx or the promise aborts the query.
+// If the query selects no rows, the promise is rejected with sql.ErrNoRows.
+func QueryRowAsync[T any](ctx context.Context, q Querier, scan ScanFunc[T], query string, args ...any) *promises.Promise[T] {
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[T], reject promises.Reject) {
+		v, err := scan(q.QueryRowContext(ctx, query, args...))
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	})
+}
+
+// QueryAsync runs a query and returns a promise that is fulfilled with every scanned row.
+// The query is bound to ctx, so canceling ctx or the promise aborts the query, and the rows are always closed.
+func QueryAsync[T any](ctx context.Context, q Querier, scan ScanFunc[T], query string, args ...any) *promises.Promise[[]T] {
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[[]T], reject promises.Reject) {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			reject(err)
+			return
+		}
+		defer rows.Close()
+
+		var results []T
+		for rows.Next() {
+			v, err := scan(rows)
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			results = append(results, v)
+		}
+
+		if err := rows.Err(); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(results)
+	})
+}
+
+// ExecAsync executes a query without returning any rows and returns a promise that is fulfilled with its result.
+// The query is bound to ctx, so canceling ctx or the promise aborts it.
+func ExecAsync(ctx context.Context, q Querier, query string, args ...any) *promises.Promise[sql.Result] {
+	return promises.NewWithContext(ctx, func(ctx context.Context, resolve promises.Resolve[sql.Result], reject promises.Reject) {
+		result, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(result)
+	})
+}
@@ -0,0 +1,200 @@
+package promisesql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisesql"
+)
+
+// fakeDriver serves a fixed users table. Queries other than "users" fail.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("unsupported") }
+
+type fakeStmt struct {
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.query != "users" {
+		return nil, errors.New("no such table")
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query != "users" {
+		return nil, errors.New("no such table")
+	}
+
+	return &fakeRows{values: [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}}}, nil
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+	i      int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id", "name"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.values) {
+		return io.EOF
+	}
+
+	copy(dest, r.values[r.i])
+	r.i++
+	return nil
+}
+
+func init() {
+	sql.Register("promisesql-fake", fakeDriver{})
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func scanUser(s Scanner) (user, error) {
+	var u user
+	err := s.Scan(&u.ID, &u.Name)
+	return u, err
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("promisesql-fake", "")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestQueryRowAsync(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	u, err := QueryRowAsync(ctx, db, scanUser, "users").Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if u.Name != "alice" {
+		t.Errorf("expected name to be alice, got %s", u.Name)
+	}
+}
+
+func TestQueryAsync(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	users, err := QueryAsync(ctx, db, scanUser, "users").Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("expected length to be 2, got %d", len(users))
+	}
+
+	if users[1].ID != 2 {
+		t.Errorf("expected id to be 2, got %d", users[1].ID)
+	}
+}
+
+func TestQueryAsyncError(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	_, err := QueryAsync(ctx, db, scanUser, "orders").Await(ctx)
+	if err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
+
+func TestExecAsync(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	result, err := ExecAsync(ctx, db, "users").Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	n, _ := result.RowsAffected()
+	if n != 1 {
+		t.Errorf("expected rows affected to be 1, got %d", n)
+	}
+}
+
+func TestParallelQueries(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	p1 := QueryRowAsync(ctx, db, scanUser, "users")
+	p2 := QueryRowAsync(ctx, db, scanUser, "users")
+	v, err := promises.All(ctx, p1, p2).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 2 {
+		t.Errorf("expected length to be 2, got %d", len(v))
+	}
+}
+
+func TestQueryRow(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	row, err := QueryRow(ctx, db, "users").Await(ctx)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	u, err := scanUser(row)
+	if err != nil || u.Name != "alice" {
+		t.Errorf("expected alice, got %v, %v", u, err)
+	}
+}
+
+// blockingQuerier blocks every query until its context is done and sends the context error to canceled.
+type blockingQuerier struct {
+	Querier
+	canceled chan error
+}
+
+func (q blockingQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	<-ctx.Done()
+	q.canceled <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestQueryAsyncCancel(t *testing.T) {
+	q := blockingQuerier{canceled: make(chan error, 1)}
+	p := QueryAsync(context.Background(), q, scanUser, "users")
+	p.Cancel(nil)
+
+	if err := <-q.canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceling the promise to cancel the query, got %v", err)
+	}
+}
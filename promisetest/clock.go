@@ -0,0 +1,120 @@
+package promisetest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// FakeClock is a promises.Clock whose time only moves when advanced.
+// Install it with promises.SetClock so that delays, timeouts and backoffs run instantly and deterministically.
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+	mutex  sync.Mutex
+	cond   *sync.Cond
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mutex)
+	return c
+}
+
+// Install sets c as the package clock until the test ends.
+func (c *FakeClock) Install(t interface{ Cleanup(func()) }) *FakeClock {
+	t.Cleanup(promises.SetClock(c))
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) promises.Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d and fires every timer whose deadline has been reached, in deadline order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+
+	remaining := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			remaining = append(remaining, t)
+			continue
+		}
+
+		t.c <- t.deadline
+	}
+
+	c.timers = remaining
+}
+
+// Timers returns the number of timers that have not fired or been stopped.
+func (c *FakeClock) Timers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.timers)
+}
+
+// BlockUntil blocks until at least n timers are waiting to fire.
+// It lets a test wait for code running in other goroutines to start waiting before advancing the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
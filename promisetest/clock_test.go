@@ -0,0 +1,51 @@
+package promisetest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestFakeClock(t *testing.T) {
+	ctx := context.Background()
+	clock := NewFakeClock(time.Unix(0, 0)).Install(t)
+
+	p := promises.Delay(time.Hour)
+	RequirePendingAfter(t, p, 10*time.Millisecond)
+
+	clock.Advance(time.Hour)
+	RequireFulfilled(t, ctx, p)
+
+	if got := clock.Now(); !got.Equal(time.Unix(3600, 0)) {
+		t.Errorf("expected now to be one hour later, got %v", got)
+	}
+}
+
+func TestFakeClockStop(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := clock.NewTimer(time.Second)
+
+	if !timer.Stop() {
+		t.Errorf("expected stop to report an active timer")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Errorf("expected stopped timer not to fire")
+	default:
+	}
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	go clock.NewTimer(time.Second)
+
+	clock.BlockUntil(1)
+	if clock.Timers() != 1 {
+		t.Errorf("expected 1 timer, got %d", clock.Timers())
+	}
+}
@@ -0,0 +1,47 @@
+package promisetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Eventually fails the test immediately unless cond reports true within d, checking it every interval.
+// Time is measured with the package clock, so under a FakeClock the clock has to be advanced from another goroutine.
+func Eventually(t testing.TB, d, interval time.Duration, cond func() bool) {
+	t.Helper()
+
+	if !poll(d, interval, cond) {
+		t.Fatalf("expected condition to be met within %v", d)
+	}
+}
+
+// Never fails the test immediately if cond reports true within d, checking it every interval.
+func Never(t testing.TB, d, interval time.Duration, cond func() bool) {
+	t.Helper()
+
+	if poll(d, interval, cond) {
+		t.Fatalf("expected condition never to be met within %v", d)
+	}
+}
+
+// poll reports whether cond became true before d elapsed on the package clock.
+func poll(d, interval time.Duration, cond func() bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	timer := promises.CurrentClock().NewTimer(d)
+	defer timer.Stop()
+
+	p := promises.Poll(ctx, interval, cond)
+	select {
+	case <-p.Done():
+		return p.IsFulfilled()
+	case <-timer.C():
+		cancel()
+		<-p.Done()
+		return p.IsFulfilled()
+	}
+}
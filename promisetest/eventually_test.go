@@ -0,0 +1,58 @@
+package promisetest_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestEventually(t *testing.T) {
+	var ready atomic.Bool
+	time.AfterFunc(10*time.Millisecond, func() { ready.Store(true) })
+
+	Eventually(t, time.Second, time.Millisecond, ready.Load)
+}
+
+func TestEventuallyFails(t *testing.T) {
+	ft := run(func(tb testing.TB) {
+		Eventually(tb, 10*time.Millisecond, time.Millisecond, func() bool { return false })
+	})
+
+	if !ft.failed {
+		t.Errorf("expected Eventually to fail")
+	}
+}
+
+func TestNever(t *testing.T) {
+	Never(t, 10*time.Millisecond, time.Millisecond, func() bool { return false })
+
+	ft := run(func(tb testing.TB) {
+		Never(tb, time.Second, time.Millisecond, func() bool { return true })
+	})
+
+	if !ft.failed {
+		t.Errorf("expected Never to fail")
+	}
+}
+
+func TestEventuallyFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0)).Install(t)
+	var checks atomic.Int32
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Eventually(t, time.Minute, time.Second, func() bool {
+			return checks.Add(1) == 3
+		})
+	}()
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(2)
+		clock.Advance(time.Second)
+	}
+
+	<-done
+}
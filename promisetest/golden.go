@@ -0,0 +1,181 @@
+package promisetest
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var update = flag.Bool("promisetest.update", false, "update golden files of settled results")
+
+// CompareOptions configures how settled results are compared. Zero values select the defaults.
+type CompareOptions[T any] struct {
+	// Less orders the results before they are compared, for results that arrive in no particular order.
+	// If nil, the results are compared in their given order.
+	Less func(a, b promises.SettledResult[T]) bool
+
+	// EqualValue compares fulfilled values. Defaults to reflect.DeepEqual.
+	EqualValue func(want, got T) bool
+
+	// EqualReason compares rejection reasons. Defaults to comparing their messages.
+	EqualReason func(want, got error) bool
+}
+
+// DiffSettled compares two slices of settled results and returns a description of their differences,
+// or an empty string if they are equal.
+func DiffSettled[T any](want, got []promises.SettledResult[T], opts CompareOptions[T]) string {
+	want, got = normalize(want, opts.Less), normalize(got, opts.Less)
+
+	var diffs []string
+	if len(want) != len(got) {
+		diffs = append(diffs, fmt.Sprintf("length: want %d, got %d", len(want), len(got)))
+	}
+
+	for i := 0; i < len(want) && i < len(got); i++ {
+		w, g := want[i], got[i]
+		switch {
+		case w.Status != g.Status:
+			diffs = append(diffs, fmt.Sprintf("[%d] status: want %v, got %v", i, w.Status, g.Status))
+		case w.Status == promises.Fulfilled && !opts.equalValue(w.Value, g.Value):
+			diffs = append(diffs, fmt.Sprintf("[%d] value: want %+v, got %+v", i, w.Value, g.Value))
+		case w.Status == promises.Rejected && !opts.equalReason(w.Reason, g.Reason):
+			diffs = append(diffs, fmt.Sprintf("[%d] reason: want %v, got %v", i, w.Reason, g.Reason))
+		}
+	}
+
+	return strings.Join(diffs, "\n")
+}
+
+// RequireSettledEqual fails the test immediately if the settled results differ.
+func RequireSettledEqual[T any](t testing.TB, want, got []promises.SettledResult[T], opts CompareOptions[T]) {
+	t.Helper()
+
+	if diff := DiffSettled(want, got, opts); diff != "" {
+		t.Fatalf("settled results differ:\n%s", diff)
+	}
+}
+
+type goldenResult[T any] struct {
+	Status string `json:"status"`
+	Value  *T     `json:"value,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Golden compares the settled results with the golden file at path, stored as JSON.
+// Run the test with -promisetest.update to write the golden file from the current results.
+func Golden[T any](t testing.TB, path string, got []promises.SettledResult[T], opts CompareOptions[T]) {
+	t.Helper()
+
+	if *update {
+		if err := writeGolden(path, normalize(got, opts.Less)); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, err := readGolden[T](path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -promisetest.update to create it): %v", err)
+	}
+
+	if diff := DiffSettled(want, got, opts); diff != "" {
+		t.Fatalf("settled results differ from %s:\n%s", path, diff)
+	}
+}
+
+func writeGolden[T any](path string, results []promises.SettledResult[T]) error {
+	golden := make([]goldenResult[T], len(results))
+	for i, r := range results {
+		golden[i].Status = r.Status.String()
+		if r.Status == promises.Rejected {
+			golden[i].Reason = r.Reason.Error()
+			continue
+		}
+
+		v := r.Value
+		golden[i].Value = &v
+	}
+
+	b, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+func readGolden[T any](path string) ([]promises.SettledResult[T], error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var golden []goldenResult[T]
+	if err := json.Unmarshal(b, &golden); err != nil {
+		return nil, err
+	}
+
+	results := make([]promises.SettledResult[T], len(golden))
+	for i, g := range golden {
+		switch g.Status {
+		case promises.Fulfilled.String():
+			results[i].Status = promises.Fulfilled
+			if g.Value != nil {
+				results[i].Value = *g.Value
+			}
+		case promises.Rejected.String():
+			results[i] = promises.SettledResult[T]{Status: promises.Rejected, Reason: errors.New(g.Reason)}
+		default:
+			return nil, fmt.Errorf("unknown status %q at index %d", g.Status, i)
+		}
+	}
+
+	return results, nil
+}
+
+func normalize[T any](results []promises.SettledResult[T], less func(a, b promises.SettledResult[T]) bool) []promises.SettledResult[T] {
+	if less == nil {
+		return results
+	}
+
+	sorted := append([]promises.SettledResult[T](nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
+
+func (o CompareOptions[T]) equalValue(want, got T) bool {
+	if o.EqualValue != nil {
+		return o.EqualValue(want, got)
+	}
+
+	return reflect.DeepEqual(want, got)
+}
+
+func (o CompareOptions[T]) equalReason(want, got error) bool {
+	if o.EqualReason != nil {
+		return o.EqualReason(want, got)
+	}
+
+	if want == nil || got == nil {
+		return want == got
+	}
+
+	return want.Error() == got.Error()
+}
@@ -0,0 +1,58 @@
+package promisetest_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func settle(t *testing.T) []promises.SettledResult[int] {
+	ctx := context.Background()
+	return RequireFulfilled(t, ctx, promises.AllSettled(ctx, resolved(1), rejected(errors.New("boom")), resolved(3)))
+}
+
+func TestDiffSettled(t *testing.T) {
+	want := []promises.SettledResult[int]{
+		{Status: promises.Fulfilled, Value: 1},
+		{Status: promises.Rejected, Reason: errors.New("boom")},
+		{Status: promises.Fulfilled, Value: 4},
+	}
+
+	diff := DiffSettled(want, settle(t), CompareOptions[int]{})
+	if diff != "[2] value: want 4, got 3" {
+		t.Errorf("expected a single value difference, got %q", diff)
+	}
+}
+
+func TestDiffSettledLess(t *testing.T) {
+	want := []promises.SettledResult[int]{
+		{Status: promises.Fulfilled, Value: 3},
+		{Status: promises.Fulfilled, Value: 1},
+		{Status: promises.Rejected, Reason: errors.New("BOOM")},
+	}
+
+	opts := CompareOptions[int]{
+		Less: func(a, b promises.SettledResult[int]) bool {
+			if a.Status != b.Status {
+				return a.Status < b.Status
+			}
+
+			return a.Value < b.Value
+		},
+		EqualReason: func(want, got error) bool {
+			return strings.EqualFold(want.Error(), got.Error())
+		},
+	}
+
+	RequireSettledEqual(t, want, settle(t), opts)
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join("testdata", "settled.golden.json")
+	Golden(t, path, settle(t), CompareOptions[int]{})
+}
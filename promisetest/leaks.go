@@ -0,0 +1,136 @@
+package promisetest
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+const (
+	leakGracePeriod  = 500 * time.Millisecond
+	leakPollInterval = 10 * time.Millisecond
+	packagePath      = "github.com/oneofthezombies/promises"
+)
+
+// VerifyNoLeaks fails the test if, when it ends, promises created during the test are still pending
+// or goroutines started during the test are still running code of this module.
+// Leftovers get a short grace period to finish. Call it at the start of the test so that it runs after other cleanups.
+// It observes every promise in the process, so tests using it should not run in parallel with other promise-creating tests.
+func VerifyNoLeaks(t testing.TB) {
+	t.Helper()
+
+	before := goroutineIDs()
+	tracker := &pendingTracker{pending: make(map[uint64]promises.Info)}
+	remove := promises.AddObserver(tracker)
+
+	t.Cleanup(func() {
+		defer remove()
+
+		var pending []promises.Info
+		var leaked []string
+		deadline := time.Now().Add(leakGracePeriod)
+		for {
+			pending = tracker.snapshot()
+			leaked = leakedGoroutines(before)
+			if (len(pending) == 0 && len(leaked) == 0) || time.Now().After(deadline) {
+				break
+			}
+
+			time.Sleep(leakPollInterval)
+		}
+
+		for _, info := range pending {
+			t.Errorf("found unsettled promise %d created at %v", info.ID, info.CreatedAt)
+		}
+
+		for _, g := range leaked {
+			t.Errorf("found leaked goroutine:\n%s", g)
+		}
+	})
+}
+
+type pendingTracker struct {
+	pending map[uint64]promises.Info
+	mutex   sync.Mutex
+}
+
+func (t *pendingTracker) OnCreate(info promises.Info) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pending[info.ID] = info
+}
+
+func (t *pendingTracker) OnSettle(s promises.Settlement) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.pending, s.ID)
+}
+
+func (t *pendingTracker) snapshot() []promises.Info {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	infos := make([]promises.Info, 0, len(t.pending))
+	for _, info := range t.pending {
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+func goroutineIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, g := range goroutines() {
+		ids[goroutineID(g)] = true
+	}
+
+	return ids
+}
+
+// leakedGoroutines returns the stacks of goroutines not in before that are running code of this module.
+func leakedGoroutines(before map[string]bool) []string {
+	var leaked []string
+	for _, g := range goroutines() {
+		if before[goroutineID(g)] || !strings.Contains(g, packagePath) {
+			continue
+		}
+
+		leaked = append(leaked, g)
+	}
+
+	return leaked
+}
+
+func goroutines() []string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var stacks []string
+	for _, g := range bytes.Split(buf, []byte("\n\n")) {
+		stacks = append(stacks, string(g))
+	}
+
+	return stacks
+}
+
+func goroutineID(stack string) string {
+	var id string
+	fmt.Sscanf(stack, "goroutine %s", &id)
+	return id
+}
@@ -0,0 +1,39 @@
+package promisetest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestVerifyNoLeaks(t *testing.T) {
+	VerifyNoLeaks(t)
+
+	ctx := context.Background()
+	RequireFulfilled(t, ctx, resolved(1))
+}
+
+func TestVerifyNoLeaksFails(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ft := run(func(tb testing.TB) {
+		VerifyNoLeaks(tb)
+
+		promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+			<-release
+			resolve(1)
+		})
+	})
+
+	if !ft.failed {
+		t.Fatalf("expected VerifyNoLeaks to fail")
+	}
+
+	if !strings.Contains(ft.message, "leaked goroutine") {
+		t.Errorf("expected the last failure to report the stuck executor, got %q", ft.message)
+	}
+}
@@ -0,0 +1,99 @@
+// Package promisetest provides assertion helpers for testing code that uses promises.
+package promisetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// RequireFulfilled awaits p and returns its value.
+// The test fails immediately if p is rejected or ctx is done before p is settled.
+func RequireFulfilled[T any](t testing.TB, ctx context.Context, p *promises.Promise[T]) T {
+	t.Helper()
+
+	v, err := p.Await(ctx)
+	if ctx.Err() != nil {
+		t.Fatalf("expected promise to be fulfilled, but it was still pending: %v", ctx.Err())
+	}
+
+	if err != nil {
+		t.Fatalf("expected promise to be fulfilled, but it was rejected with: %v", err)
+	}
+
+	return v
+}
+
+// RequireRejected awaits p and returns its rejection reason.
+// The test fails immediately if p is fulfilled or ctx is done before p is settled.
+func RequireRejected[T any](t testing.TB, ctx context.Context, p *promises.Promise[T]) error {
+	t.Helper()
+
+	v, err := p.Await(ctx)
+	if ctx.Err() != nil {
+		t.Fatalf("expected promise to be rejected, but it was still pending: %v", ctx.Err())
+	}
+
+	if err == nil {
+		t.Fatalf("expected promise to be rejected, but it was fulfilled with: %v", v)
+	}
+
+	return err
+}
+
+// RequireRejectedAs awaits p and returns its rejection reason as E, as found by errors.As.
+// The test fails immediately if p is fulfilled, ctx is done before p is settled, or the reason does not match E.
+func RequireRejectedAs[E error, T any](t testing.TB, ctx context.Context, p *promises.Promise[T]) E {
+	t.Helper()
+
+	err := RequireRejected(t, ctx, p)
+
+	var target E
+	if !errors.As(err, &target) {
+		t.Fatalf("expected promise to be rejected with %v, but it was rejected with: %v (%T)", reflect.TypeOf(&target).Elem(), err, err)
+	}
+
+	return target
+}
+
+// RequireFulfilledWithin is RequireFulfilled that fails the test if p is not settled within d.
+func RequireFulfilledWithin[T any](t testing.TB, p *promises.Promise[T], d time.Duration) T {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	return RequireFulfilled(t, ctx, p)
+}
+
+// RequireRejectedWith awaits p and fails the test immediately if p is fulfilled, ctx is done before p is settled,
+// or the rejection reason does not match target, as found by errors.Is.
+func RequireRejectedWith[T any](t testing.TB, ctx context.Context, p *promises.Promise[T], target error) {
+	t.Helper()
+
+	if err := RequireRejected(t, ctx, p); !errors.Is(err, target) {
+		t.Fatalf("expected promise to be rejected with %v, but it was rejected with: %v", target, err)
+	}
+}
+
+// RequirePendingAfter waits for d and fails the test immediately if p is settled by then.
+func RequirePendingAfter[T any](t testing.TB, p *promises.Promise[T], d time.Duration) {
+	t.Helper()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-p.Done():
+		if err := p.Reason(); err != nil {
+			t.Fatalf("expected promise to be pending after %v, but it was rejected with: %v", d, err)
+		}
+
+		t.Fatalf("expected promise to be pending after %v, but it was fulfilled with: %v", d, p.Value())
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,180 @@
+package promisetest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+// fakeT records failures instead of failing the enclosing test.
+type fakeT struct {
+	testing.TB
+	failed   bool
+	message  string
+	cleanups []func()
+}
+
+func (t *fakeT) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.failed = true
+	t.message = fmt.Sprintf(format, args...)
+}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func run(fn func(t testing.TB)) *fakeT {
+	t := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			for i := len(t.cleanups) - 1; i >= 0; i-- {
+				t.cleanups[i]()
+			}
+		}()
+
+		fn(t)
+	}()
+
+	<-done
+	return t
+}
+
+type notFoundError struct {
+	Key string
+}
+
+func (e *notFoundError) Error() string {
+	return "not found: " + e.Key
+}
+
+func resolved(v int) *promises.Promise[int] {
+	return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		resolve(v)
+	})
+}
+
+func rejected(err error) *promises.Promise[int] {
+	return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		reject(err)
+	})
+}
+
+func pending(t *testing.T) *promises.Promise[int] {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		<-release
+		resolve(0)
+	})
+}
+
+func TestRequireFulfilled(t *testing.T) {
+	ctx := context.Background()
+	if v := RequireFulfilled(t, ctx, resolved(1)); v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+
+	ft := run(func(tb testing.TB) {
+		RequireFulfilled(tb, ctx, rejected(errors.New("boom")))
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "rejected with: boom") {
+		t.Errorf("expected failure mentioning the reason, got %q", ft.message)
+	}
+}
+
+func TestRequireFulfilledPending(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	ft := run(func(tb testing.TB) {
+		RequireFulfilled(tb, ctx, pending(t))
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "still pending") {
+		t.Errorf("expected failure mentioning pending, got %q", ft.message)
+	}
+}
+
+func TestRequireRejectedAs(t *testing.T) {
+	ctx := context.Background()
+	err := RequireRejectedAs[*notFoundError](t, ctx, rejected(fmt.Errorf("lookup: %w", &notFoundError{Key: "a"})))
+	if err.Key != "a" {
+		t.Errorf("expected key to be a, got %s", err.Key)
+	}
+
+	ft := run(func(tb testing.TB) {
+		RequireRejectedAs[*notFoundError](tb, ctx, rejected(errors.New("boom")))
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "*promisetest_test.notFoundError") {
+		t.Errorf("expected failure mentioning the target type, got %q", ft.message)
+	}
+
+	ft = run(func(tb testing.TB) {
+		RequireRejectedAs[*notFoundError](tb, ctx, resolved(1))
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "fulfilled with: 1") {
+		t.Errorf("expected failure mentioning the value, got %q", ft.message)
+	}
+}
+
+func TestRequirePendingAfter(t *testing.T) {
+	RequirePendingAfter(t, pending(t), 10*time.Millisecond)
+
+	p := resolved(1)
+	<-p.Done()
+	ft := run(func(tb testing.TB) {
+		RequirePendingAfter(tb, p, 10*time.Millisecond)
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "fulfilled with: 1") {
+		t.Errorf("expected failure mentioning the value, got %q", ft.message)
+	}
+}
+
+func TestRequireFulfilledWithin(t *testing.T) {
+	if v := RequireFulfilledWithin(t, resolved(1), time.Second); v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+
+	ft := run(func(tb testing.TB) {
+		RequireFulfilledWithin(tb, pending(t), 10*time.Millisecond)
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "still pending") {
+		t.Errorf("expected failure mentioning pending, got %q", ft.message)
+	}
+}
+
+func TestRequireRejectedWith(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+	RequireRejectedWith(t, ctx, rejected(fmt.Errorf("call: %w", boom)), boom)
+
+	ft := run(func(tb testing.TB) {
+		RequireRejectedWith(tb, ctx, rejected(errors.New("other")), boom)
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "rejected with boom") {
+		t.Errorf("expected failure mentioning the target, got %q", ft.message)
+	}
+}
@@ -0,0 +1,128 @@
+package promisetest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// EventKind is the kind of a recorded event.
+type EventKind int
+
+const (
+	Created EventKind = iota
+	Settled
+)
+
+func (k EventKind) String() string {
+	if k == Created {
+		return "created"
+	}
+
+	return "settled"
+}
+
+// Event is a recorded promise lifecycle event. Only Info is set for Created events.
+type Event struct {
+	Kind EventKind
+	promises.Settlement
+}
+
+// Recorder captures the ordered history of promises created and settled while it is attached.
+// It observes every promise in the process, so tests using it should not run in parallel with other promise-creating tests.
+type Recorder struct {
+	events []Event
+	mutex  sync.Mutex
+}
+
+// NewRecorder creates a Recorder that is attached until the test ends.
+func NewRecorder(t testing.TB) *Recorder {
+	r := &Recorder{}
+	t.Cleanup(promises.AddObserver(r))
+	return r
+}
+
+func (r *Recorder) OnCreate(info promises.Info) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, Event{Kind: Created, Settlement: promises.Settlement{Info: info}})
+}
+
+func (r *Recorder) OnSettle(s promises.Settlement) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, Event{Kind: Settled, Settlement: s})
+}
+
+// Events returns every recorded event in order.
+func (r *Recorder) Events() []Event {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Event(nil), r.events...)
+}
+
+// Settlements returns the recorded settlements in the order they happened.
+func (r *Recorder) Settlements() []promises.Settlement {
+	return r.settlements(func(s promises.Settlement) bool { return true })
+}
+
+// Results returns the outcomes of the recorded settlements in the order they happened.
+func (r *Recorder) Results() []promises.Result[any] {
+	settlements := r.Settlements()
+	results := make([]promises.Result[any], len(settlements))
+	for i, s := range settlements {
+		results[i] = s.Result()
+	}
+
+	return results
+}
+
+// Fulfilled returns the recorded fulfillments in the order they happened.
+func (r *Recorder) Fulfilled() []promises.Settlement {
+	return r.settlements(func(s promises.Settlement) bool { return s.Status == promises.Fulfilled })
+}
+
+// Rejected returns the recorded rejections in the order they happened.
+func (r *Recorder) Rejected() []promises.Settlement {
+	return r.settlements(func(s promises.Settlement) bool { return s.Status == promises.Rejected })
+}
+
+// Pending returns the promises that were created but have not been settled yet.
+func (r *Recorder) Pending() []promises.Info {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	settled := make(map[uint64]bool)
+	for _, e := range r.events {
+		if e.Kind == Settled {
+			settled[e.ID] = true
+		}
+	}
+
+	var pending []promises.Info
+	for _, e := range r.events {
+		if e.Kind == Created && !settled[e.ID] {
+			pending = append(pending, e.Info)
+		}
+	}
+
+	return pending
+}
+
+func (r *Recorder) settlements(match func(promises.Settlement) bool) []promises.Settlement {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var settlements []promises.Settlement
+	for _, e := range r.events {
+		if e.Kind == Settled && match(e.Settlement) {
+			settlements = append(settlements, e.Settlement)
+		}
+	}
+
+	return settlements
+}
@@ -0,0 +1,52 @@
+package promisetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	r := NewRecorder(t)
+
+	first := resolved(1)
+	RequireFulfilled(t, ctx, first)
+	second := rejected(errors.New("boom"))
+	RequireRejected(t, ctx, second)
+	pending(t)
+
+	events := r.Events()
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(events))
+	}
+
+	kinds := []EventKind{Created, Settled, Created, Settled, Created}
+	for i, e := range events {
+		if e.Kind != kinds[i] {
+			t.Errorf("expected event %d to be %v, got %v", i, kinds[i], e.Kind)
+		}
+	}
+
+	if f := r.Fulfilled(); len(f) != 1 || f[0].Value != 1 {
+		t.Errorf("expected one fulfillment with 1, got %v", f)
+	}
+
+	if rj := r.Rejected(); len(rj) != 1 || rj[0].Reason.Error() != "boom" {
+		t.Errorf("expected one rejection with boom, got %v", rj)
+	}
+
+	if res := r.Results(); len(res) != 2 || res[0].Value != 1 || res[1].Err == nil || res[1].Err.Error() != "boom" {
+		t.Errorf("expected the results 1 and boom, got %v", res)
+	}
+
+	if p := r.Pending(); len(p) != 1 || p[0].ID != events[4].ID {
+		t.Errorf("expected the third promise to be pending, got %v", p)
+	}
+
+	if events[1].SettledAt.Before(events[0].CreatedAt) {
+		t.Errorf("expected settlement to happen after creation")
+	}
+}
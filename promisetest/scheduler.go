@@ -0,0 +1,69 @@
+package promisetest
+
+import (
+	"sync"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// ManualScheduler is a promises.Scheduler that queues executors until the test runs them,
+// one at a time and in the order they were scheduled, on the goroutine of the test.
+// Together with a FakeClock it makes the interleaving of promises deterministic.
+type ManualScheduler struct {
+	queue []func()
+	mutex sync.Mutex
+}
+
+// NewManualScheduler creates an empty ManualScheduler.
+func NewManualScheduler() *ManualScheduler {
+	return &ManualScheduler{}
+}
+
+// Install configures the package with s as the scheduler of every promise until the test ends,
+// replacing the rest of the configuration, and restores the previous configuration afterwards.
+// Promises created with their own scheduler, or with WithSync, are not affected.
+func (s *ManualScheduler) Install(t interface{ Cleanup(func()) }) *ManualScheduler {
+	t.Cleanup(promises.Configure(promises.Config{Scheduler: s}))
+	return s
+}
+
+func (s *ManualScheduler) Schedule(run func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.queue = append(s.queue, run)
+}
+
+// RunNext runs the executor scheduled first and reports whether there was one.
+func (s *ManualScheduler) RunNext() bool {
+	s.mutex.Lock()
+	if len(s.queue) == 0 {
+		s.mutex.Unlock()
+		return false
+	}
+
+	run := s.queue[0]
+	s.queue = s.queue[1:]
+	s.mutex.Unlock()
+
+	run()
+	return true
+}
+
+// RunAll runs scheduled executors, including those they schedule, until none is left, and returns how many ran.
+func (s *ManualScheduler) RunAll() int {
+	n := 0
+	for s.RunNext() {
+		n++
+	}
+
+	return n
+}
+
+// Pending returns the number of executors waiting to run.
+func (s *ManualScheduler) Pending() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.queue)
+}
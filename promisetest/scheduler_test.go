@@ -0,0 +1,41 @@
+package promisetest_test
+
+import (
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestManualScheduler(t *testing.T) {
+	s := NewManualScheduler().Install(t)
+
+	var order []int
+	newPromise := func(i int) *promises.Promise[int] {
+		return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+			order = append(order, i)
+			resolve(i)
+		})
+	}
+
+	a, b := newPromise(1), newPromise(2)
+	if a.IsSettled() || b.IsSettled() || s.Pending() != 2 {
+		t.Fatalf("expected 2 executors to wait for the scheduler, got %d", s.Pending())
+	}
+
+	if !s.RunNext() || !a.IsFulfilled() || b.IsSettled() {
+		t.Fatal("expected RunNext to run only the first executor")
+	}
+
+	if n := s.RunAll(); n != 1 || !b.IsFulfilled() {
+		t.Errorf("expected RunAll to run the last executor, ran %d", n)
+	}
+
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Errorf("expected the executors to run in order, got %v", order)
+	}
+
+	if s.RunNext() {
+		t.Error("expected nothing left to run")
+	}
+}
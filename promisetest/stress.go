@@ -0,0 +1,128 @@
+package promisetest
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// StressOptions configures Stress. Zero values select the defaults.
+type StressOptions struct {
+	// Iterations is the number of calls. Defaults to 1000.
+	Iterations int
+
+	// Concurrency is the maximum number of concurrent calls. Defaults to 4 * GOMAXPROCS.
+	Concurrency int
+
+	// CancelRate is the fraction of calls whose context is canceled at a random time.
+	CancelRate float64
+
+	// MaxJitter bounds the random delay before each call and before each cancellation. Defaults to 1ms.
+	MaxJitter time.Duration
+
+	// SettleTimeout bounds how long each promise may take to settle. Defaults to 5s.
+	SettleTimeout time.Duration
+
+	// Seed seeds the randomness. Zero picks a seed from the current time; it is logged on failure for reproduction.
+	Seed int64
+}
+
+// Stress calls fn concurrently with randomized start jitter and cancellation timing and checks the outcome of every returned promise.
+// Each call receives its own random source, derived from the seed, to randomize settle ordering inside fn.
+// The test fails if check returns an error or if a promise does not settle within the settle timeout,
+// which catches hangs such as lost wake-ups. Run the test with -race to also surface data races.
+func Stress[T any](t testing.TB, opts StressOptions, fn func(ctx context.Context, r *rand.Rand) *promises.Promise[T], check func(v T, err error) error) {
+	t.Helper()
+
+	opts = opts.withDefaults()
+	seeds := rand.New(rand.NewSource(opts.Seed))
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	failures := make(chan string, opts.Iterations)
+	slots := make(chan struct{}, opts.Concurrency)
+	for i := 0; i < opts.Iterations; i++ {
+		r := rand.New(rand.NewSource(seeds.Int63()))
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(r *rand.Rand) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := stressCall(opts, r, fn, check); err != "" {
+				once.Do(func() { failures <- err })
+			}
+		}(r)
+	}
+
+	wg.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Errorf("stress failed with seed %d: %s", opts.Seed, failure)
+	}
+}
+
+func stressCall[T any](opts StressOptions, r *rand.Rand, fn func(ctx context.Context, r *rand.Rand) *promises.Promise[T], check func(v T, err error) error) string {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	time.Sleep(jitter(r, opts.MaxJitter))
+	if r.Float64() < opts.CancelRate {
+		delay := jitter(r, opts.MaxJitter)
+		time.AfterFunc(delay, cancel)
+	}
+
+	p := fn(ctx, r)
+
+	timer := time.NewTimer(opts.SettleTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-p.Done():
+	case <-timer.C:
+		return "promise did not settle within " + opts.SettleTimeout.String()
+	}
+
+	if err := check(p.Value(), p.Reason()); err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
+
+func jitter(r *rand.Rand, max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	return time.Duration(r.Int63n(int64(max)))
+}
+
+func (o StressOptions) withDefaults() StressOptions {
+	if o.Iterations <= 0 {
+		o.Iterations = 1000
+	}
+
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4 * runtime.GOMAXPROCS(0)
+	}
+
+	if o.MaxJitter == 0 {
+		o.MaxJitter = time.Millisecond
+	}
+
+	if o.SettleTimeout <= 0 {
+		o.SettleTimeout = 5 * time.Second
+	}
+
+	if o.Seed == 0 {
+		o.Seed = time.Now().UnixNano()
+	}
+
+	return o
+}
@@ -0,0 +1,61 @@
+package promisetest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetest"
+)
+
+func TestStress(t *testing.T) {
+	opts := StressOptions{Iterations: 200, CancelRate: 0.5, MaxJitter: 100 * time.Microsecond}
+
+	Stress(t, opts, func(ctx context.Context, r *rand.Rand) *promises.Promise[int] {
+		delay := time.Duration(r.Intn(100)) * time.Microsecond
+		return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+			select {
+			case <-ctx.Done():
+				reject(ctx.Err())
+			case <-time.After(delay):
+				resolve(1)
+			}
+		})
+	}, func(v int, err error) error {
+		if err == nil && v != 1 {
+			return fmt.Errorf("expected value to be 1, got %d", v)
+		}
+
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		return nil
+	})
+}
+
+func TestStressDetectsHang(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	opts := StressOptions{Iterations: 10, SettleTimeout: 10 * time.Millisecond, Seed: 1}
+	ft := run(func(tb testing.TB) {
+		Stress(tb, opts, func(ctx context.Context, r *rand.Rand) *promises.Promise[int] {
+			return promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+				// Forgets to honor ctx and never settles.
+				<-release
+			})
+		}, func(v int, err error) error {
+			return nil
+		})
+	})
+
+	if !ft.failed || !strings.Contains(ft.message, "did not settle") || !strings.Contains(ft.message, "seed 1") {
+		t.Errorf("expected failure reporting the hang and seed, got %q", ft.message)
+	}
+}
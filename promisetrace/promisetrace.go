@@ -0,0 +1,119 @@
+// Package promisetrace records a span per promise, settle latencies, pending counts and await times.
+//
+// It does not depend on any tracing library: spans are started through its own Tracer and Span interfaces,
+// and metrics are reported to plain callbacks, so it can feed whichever backend is in use.
+// An OpenTelemetry tracer from go.opentelemetry.io/otel, for example, is adapted in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(name string, start time.Time) promisetrace.Span {
+//		_, span := t.Tracer.Start(context.Background(), name, trace.WithTimestamp(start))
+//		return otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetError(err error) { s.RecordError(err); s.SetStatus(codes.Error, err.Error()) }
+//	func (s otelSpan) End(end time.Time)  { s.Span.End(trace.WithTimestamp(end)) }
+package promisetrace
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Tracer starts spans in a tracing backend, such as an adapted OpenTelemetry trace.Tracer.
+type Tracer interface {
+	Start(name string, start time.Time) Span
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetError marks the span as failed with err.
+	SetError(err error)
+
+	// End ends the span at end.
+	End(end time.Time)
+}
+
+// Options configures an Observer. Every field is optional.
+type Options struct {
+	// Tracer records a span for every promise, from its creation to its settlement.
+	Tracer Tracer
+
+	// Latency is called with the settle latency of every promise, for example to record it in a histogram.
+	Latency func(name string, status promises.Status, latency time.Duration)
+
+	// Pending is called with the number of observed promises still pending whenever it changes, for example to set a gauge.
+	Pending func(n int64)
+
+	// Waited is called with how long a caller was blocked in Await, for example to record it in a histogram.
+	Waited func(name string, waited time.Duration, err error)
+}
+
+// Observer is a promises.Observer and promises.AwaitObserver that records spans and metrics.
+// Register it for every promise with promises.AddObserver, or for a single promise with promises.WithObserver.
+type Observer struct {
+	opts    Options
+	spans   sync.Map
+	pending atomic.Int64
+}
+
+// NewObserver creates an observer that records to opts.
+func NewObserver(opts Options) *Observer {
+	return &Observer{opts: opts}
+}
+
+// Pending returns the number of observed promises still pending.
+func (o *Observer) Pending() int64 {
+	return o.pending.Load()
+}
+
+func (o *Observer) OnCreate(info promises.Info) {
+	if o.opts.Tracer != nil {
+		o.spans.Store(info.ID, o.opts.Tracer.Start(spanName(info), info.CreatedAt))
+	}
+
+	o.setPending(o.pending.Add(1))
+}
+
+func (o *Observer) OnSettle(s promises.Settlement) {
+	if span, ok := o.spans.LoadAndDelete(s.ID); ok {
+		if s.Status == promises.Rejected {
+			span.(Span).SetError(s.Reason)
+		}
+
+		span.(Span).End(s.SettledAt)
+	}
+
+	if o.opts.Latency != nil {
+		o.opts.Latency(s.Name, s.Status, s.Latency())
+	}
+
+	o.setPending(o.pending.Add(-1))
+}
+
+func (o *Observer) OnAwaitStart(info promises.Info) {}
+
+func (o *Observer) OnAwaitEnd(info promises.Info, waited time.Duration, err error) {
+	if o.opts.Waited != nil {
+		o.opts.Waited(info.Name, waited, err)
+	}
+}
+
+func (o *Observer) setPending(n int64) {
+	if o.opts.Pending != nil {
+		o.opts.Pending(n)
+	}
+}
+
+func spanName(info promises.Info) string {
+	if info.Name != "" {
+		return info.Name
+	}
+
+	return "promise"
+}
@@ -0,0 +1,87 @@
+package promisetrace_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/promisetrace"
+)
+
+type fakeTracer struct {
+	spans []*fakeSpan
+	mutex sync.Mutex
+}
+
+type fakeSpan struct {
+	name  string
+	err   error
+	ended bool
+}
+
+func (t *fakeTracer) Start(name string, start time.Time) Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := &fakeSpan{name: name}
+	t.spans = append(t.spans, s)
+	return s
+}
+
+func (s *fakeSpan) SetError(err error) { s.err = err }
+func (s *fakeSpan) End(end time.Time)  { s.ended = true }
+
+func TestObserver(t *testing.T) {
+	ctx := context.Background()
+	tracer := &fakeTracer{}
+	var mutex sync.Mutex
+	var latencies []promises.Status
+	var waits int
+	o := NewObserver(Options{
+		Tracer: tracer,
+		Latency: func(name string, status promises.Status, latency time.Duration) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			latencies = append(latencies, status)
+		},
+		Waited: func(name string, waited time.Duration, err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			waits++
+		},
+	})
+
+	cause := errors.New("failed")
+	block := make(chan struct{})
+	fetch := promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		<-block
+		reject(cause)
+	}, promises.WithName("fetch"), promises.WithObserver(o))
+
+	if n := o.Pending(); n != 1 {
+		t.Errorf("expected 1 pending promise, got %d", n)
+	}
+
+	close(block)
+	fetch.Await(ctx)
+	if n := o.Pending(); n != 0 {
+		t.Errorf("expected no pending promise, got %d", n)
+	}
+
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	if len(tracer.spans) != 1 || tracer.spans[0].name != "fetch" || !tracer.spans[0].ended || tracer.spans[0].err != cause {
+		t.Errorf("expected an ended span for fetch with the reason, got %+v", tracer.spans)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(latencies) != 1 || latencies[0] != promises.Rejected || waits != 1 {
+		t.Errorf("expected one rejected latency and one wait, got %v, %d", latencies, waits)
+	}
+}
@@ -0,0 +1,84 @@
+package promises
+
+import "sync"
+
+// Published is a cold promise that several subscribers share. Its executor does not run until Connect is called,
+// or until the number of subscribers set with AutoConnect have subscribed, and then it runs exactly once.
+type Published[T any] struct {
+	executor    Executor[T]
+	opts        []Option
+	shared      *Promise[T]
+	resolve     Resolve[T]
+	reject      Reject
+	subscribers int
+	autoConnect int
+	connected   bool
+	mutex       sync.Mutex
+}
+
+// Publish returns a cold promise of the executor. The options configure the promise of the run started by Connect, as in New,
+// so a timeout counts from the connection rather than from the subscriptions.
+func Publish[T any](executor Executor[T], opts ...Option) *Published[T] {
+	shared, resolve, reject := newPending[T](nil)
+	return &Published[T]{executor: executor, opts: opts, shared: shared.share(), resolve: resolve, reject: reject}
+}
+
+// AutoConnect makes the n-th subscription connect. It returns pub for chaining.
+// If n subscribers have already subscribed, it connects right away.
+func (pub *Published[T]) AutoConnect(n int) *Published[T] {
+	pub.mutex.Lock()
+	pub.autoConnect = n
+	connect := n > 0 && pub.subscribers >= n
+	pub.mutex.Unlock()
+
+	if connect {
+		pub.Connect()
+	}
+
+	return pub
+}
+
+// Subscribe returns a promise that settles with the outcome of the single run, whether or not it has started.
+// Each subscriber gets a promise of its own, so canceling it cancels the run for the others only if they all canceled theirs.
+func (pub *Published[T]) Subscribe() *Promise[T] {
+	pub.mutex.Lock()
+	pub.subscribers++
+	connect := pub.autoConnect > 0 && pub.subscribers == pub.autoConnect
+	pub.mutex.Unlock()
+
+	if connect {
+		pub.Connect()
+	}
+
+	return pub.shared.handle()
+}
+
+// Subscribers returns the number of subscriptions so far.
+func (pub *Published[T]) Subscribers() int {
+	pub.mutex.Lock()
+	defer pub.mutex.Unlock()
+
+	return pub.subscribers
+}
+
+// Connect starts the executor unless it has already started, and returns a promise of its outcome like Subscribe.
+func (pub *Published[T]) Connect() *Promise[T] {
+	pub.mutex.Lock()
+	connected := pub.connected
+	pub.connected = true
+	pub.mutex.Unlock()
+
+	if !connected {
+		run := New(pub.executor, pub.opts...)
+		link(pub.shared.info, run.info)
+		run.OnSettle(func(r SettledResult[T]) {
+			if r.Status == Fulfilled {
+				pub.resolve(r.Value)
+			} else {
+				pub.reject(r.Reason)
+			}
+		})
+	}
+
+	return pub.shared.handle()
+}
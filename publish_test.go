@@ -0,0 +1,86 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestPublishConnect(t *testing.T) {
+	ctx := context.Background()
+	var runs atomic.Int32
+	pub := Publish(func(resolve Resolve[int], reject Reject) {
+		resolve(int(runs.Add(1)))
+	})
+
+	a, b := pub.Subscribe(), pub.Subscribe()
+	select {
+	case <-a.Done():
+		t.Fatal("expected nothing to run before Connect")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pub.Connect()
+	pub.Connect()
+	late := pub.Subscribe()
+	for _, p := range []*Promise[int]{a, b, late} {
+		if v, err := p.Await(ctx); err != nil || v != 1 {
+			t.Errorf("expected the shared result 1, got %v, %v", v, err)
+		}
+	}
+
+	if runs.Load() != 1 {
+		t.Errorf("expected exactly one run, got %d", runs.Load())
+	}
+
+	if pub.Subscribers() != 3 {
+		t.Errorf("expected 3 subscribers, got %d", pub.Subscribers())
+	}
+}
+
+func TestPublishAutoConnect(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	var runs atomic.Int32
+	pub := Publish(func(resolve Resolve[int], reject Reject) {
+		runs.Add(1)
+		reject(cause)
+	}).AutoConnect(2)
+
+	first := pub.Subscribe()
+	select {
+	case <-first.Done():
+		t.Fatal("expected nothing to run before the second subscriber")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	second := pub.Subscribe()
+	for _, p := range []*Promise[int]{first, second} {
+		if _, err := p.Await(ctx); !errors.Is(err, cause) {
+			t.Errorf("expected the shared rejection, got %v", err)
+		}
+	}
+
+	pub.Subscribe()
+	if runs.Load() != 1 {
+		t.Errorf("expected exactly one run, got %d", runs.Load())
+	}
+}
+
+func TestPublishSubscriberCancel(t *testing.T) {
+	ctx := context.Background()
+	pub := Publish(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithConsumerCancel())
+
+	other := pub.Subscribe()
+	Then(ctx, pub.Subscribe(), func(v int) (int, error) { return v, nil }).Cancel(nil)
+	pub.Connect()
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected a canceled subscriber not to cancel the run for the others, got %v, %v", v, err)
+	}
+}
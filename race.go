@@ -0,0 +1,140 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Indexed is a value together with the index of the promise it came from.
+type Indexed[T any] struct {
+	Index int
+	Value T
+}
+
+// AggregateError is the reason Any is rejected with when every promise is rejected.
+type AggregateError struct {
+	// Errs are the reasons of the promises, in the order of the promises.
+	Errs []error
+}
+
+func (e *AggregateError) Error() string {
+	return fmt.Sprintf("all promises were rejected: %v", errors.Join(e.Errs...))
+}
+
+// Unwrap returns the reasons of the promises, so errors.Is and errors.As match any of them.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errs
+}
+
+// Race returns a promise that settles with the outcome of the first of the promises to settle.
+// With no promises, it never settles. If ctx is done first, it is rejected with the context error.
+// The values the other promises are fulfilled with are released with their disposers, see WithDisposer.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/race
+func Race[T any](ctx context.Context, promises ...*Promise[T]) *Promise[T] {
+	return race(ctx, promises, false, func(i int, v T) T {
+		return v
+	})
+}
+
+// RaceIndexed is like Race, but it is fulfilled with the index of the winning promise along with its value.
+func RaceIndexed[T any](ctx context.Context, promises ...*Promise[T]) *Promise[Indexed[T]] {
+	return race(ctx, promises, false, func(i int, v T) Indexed[T] {
+		return Indexed[T]{Index: i, Value: v}
+	})
+}
+
+// Any returns a promise that is fulfilled with the value of the first of the promises to be fulfilled.
+// If every promise is rejected, including when there are none, it is rejected with an *AggregateError.
+// If ctx is done first, it is rejected with the context error. Like Race, it disposes of the values of the losers.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/any
+func Any[T any](ctx context.Context, promises ...*Promise[T]) *Promise[T] {
+	return race(ctx, promises, true, func(i int, v T) T {
+		return v
+	})
+}
+
+// AnyIndexed is like Any, but it is fulfilled with the index of the winning promise along with its value.
+func AnyIndexed[T any](ctx context.Context, promises ...*Promise[T]) *Promise[Indexed[T]] {
+	return race(ctx, promises, true, func(i int, v T) Indexed[T] {
+		return Indexed[T]{Index: i, Value: v}
+	})
+}
+
+// race settles with the first promise to settle, or to be fulfilled if fulfilledOnly is set.
+// Once settled, it stops waiting for the other promises and disposes of the values they are fulfilled with.
+func race[T, R any](ctx context.Context, promises []*Promise[T], fulfilledOnly bool, result func(i int, v T) R) *Promise[R] {
+	p := New(func(resolve Resolve[R], reject Reject) {
+		if len(promises) == 0 {
+			if fulfilledOnly {
+				reject(&AggregateError{})
+			}
+
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		done := make(chan struct{})
+		var once sync.Once
+		finish := func(settle func()) (won bool) {
+			once.Do(func() {
+				settle()
+				close(done)
+				won = true
+			})
+
+			return won
+		}
+
+		errs := make([]error, len(promises))
+		remaining := len(promises)
+		var mutex sync.Mutex
+		for i, promise := range promises {
+			go func(i int, promise *Promise[T]) {
+				v, err := promise.Await(ctx)
+				if err == nil {
+					if !finish(func() { resolve(result(i, v)) }) {
+						promise.dispose(v)
+					}
+
+					return
+				}
+
+				if ctx.Err() != nil {
+					// The race is over, or was given up on, before this promise settled.
+					finish(func() { reject(err) })
+					promise.disposeLater()
+					return
+				}
+
+				if !fulfilledOnly {
+					finish(func() { reject(err) })
+					return
+				}
+
+				mutex.Lock()
+				errs[i] = err
+				remaining--
+				last := remaining == 0
+				mutex.Unlock()
+
+				if last {
+					finish(func() { reject(&AggregateError{Errs: errs}) })
+				}
+			}(i, promise)
+		}
+
+		<-done
+	})
+
+	for _, promise := range promises {
+		link(p.info, promise.info)
+	}
+
+	return p
+}
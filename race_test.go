@@ -0,0 +1,68 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func after[T any](d time.Duration, v T, err error) *Promise[T] {
+	return New(func(resolve Resolve[T], reject Reject) {
+		time.Sleep(d)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	})
+}
+
+func TestRace(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+
+	v, err := RaceIndexed(ctx, after(50*time.Millisecond, "slow", nil), after(0, "fast", nil)).Await(ctx)
+	if err != nil || v.Index != 1 || v.Value != "fast" {
+		t.Errorf("expected the second promise to win, got %v, %v", v, err)
+	}
+
+	if _, err := Race(ctx, after(50*time.Millisecond, "slow", nil), after(0, "", reason)).Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected the first rejection to win, got %v", err)
+	}
+}
+
+func TestAny(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+
+	v, err := AnyIndexed(ctx, after(0, "", reason), after(10*time.Millisecond, "ok", nil), after(50*time.Millisecond, "slow", nil)).Await(ctx)
+	if err != nil || v.Index != 1 || v.Value != "ok" {
+		t.Errorf("expected the first fulfilled promise to win, got %v, %v", v, err)
+	}
+
+	other := errors.New("other")
+	_, err = Any(ctx, after(0, "", reason), after(0, "", other)).Await(ctx)
+	var aggregate *AggregateError
+	if !errors.As(err, &aggregate) || !errors.Is(err, reason) || !errors.Is(err, other) {
+		t.Errorf("expected an AggregateError of both reasons, got %v", err)
+	}
+
+	if _, err := Any[int](ctx).Await(ctx); !errors.As(err, &aggregate) {
+		t.Errorf("expected an AggregateError for no promises, got %v", err)
+	}
+}
+
+func TestRaceContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+	if _, err := Any(ctx, blocked(block)).Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+// Package reactive bridges promises to a minimal Reactive Streams interface,
+// so that code built on Rx-style observables can interoperate with promises.
+//
+// The interfaces follow the Reactive Streams specification: a Subscriber receives
+// no more items than it has requested through its Subscription.
+package reactive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var (
+	// ErrEmpty is the reason First rejects with when the publisher completes without emitting an item.
+	ErrEmpty = errors.New("publisher completed without items")
+)
+
+// Publisher emits items to its subscribers on demand.
+type Publisher[T any] interface {
+	Subscribe(s Subscriber[T])
+}
+
+// Subscriber receives the items and terminal signal of a Publisher.
+type Subscriber[T any] interface {
+	OnSubscribe(s Subscription)
+	OnNext(v T)
+	OnError(err error)
+	OnComplete()
+}
+
+// Subscription controls the demand of a Subscriber.
+type Subscription interface {
+	Request(n int64)
+	Cancel()
+}
+
+// PublisherFunc adapts a function to a Publisher.
+type PublisherFunc[T any] func(s Subscriber[T])
+
+func (f PublisherFunc[T]) Subscribe(s Subscriber[T]) {
+	f(s)
+}
+
+// FromPromise returns a publisher that emits the value of p and completes, or signals its rejection reason as an error.
+// The promise is awaited with ctx once the subscriber requests an item.
+func FromPromise[T any](ctx context.Context, p *promises.Promise[T]) Publisher[T] {
+	return fromChannel(ctx, nil, p.Await)
+}
+
+// FromChannel returns a publisher that emits the values received from c on demand and completes when c is closed.
+func FromChannel[T any](ctx context.Context, c <-chan T) Publisher[T] {
+	return fromChannel(ctx, c, nil)
+}
+
+// First returns a promise that is fulfilled with the first item of pub and then cancels the subscription.
+// It is rejected with the error signaled by pub, with ErrEmpty if pub completes without items,
+// or with the context error if ctx is done first.
+func First[T any](ctx context.Context, pub Publisher[T]) *promises.Promise[T] {
+	return promises.New(func(resolve promises.Resolve[T], reject promises.Reject) {
+		s := newCollector[T](1)
+		pub.Subscribe(s)
+
+		items, err := s.wait(ctx)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		if len(items) == 0 {
+			reject(ErrEmpty)
+			return
+		}
+
+		resolve(items[0])
+	})
+}
+
+// Collect returns a promise that is fulfilled with every item of pub once it completes.
+func Collect[T any](ctx context.Context, pub Publisher[T]) *promises.Promise[[]T] {
+	return promises.New(func(resolve promises.Resolve[[]T], reject promises.Reject) {
+		s := newCollector[T](math.MaxInt64)
+		pub.Subscribe(s)
+
+		items, err := s.wait(ctx)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(items)
+	})
+}
+
+type chanSubscription[T any] struct {
+	subscriber Subscriber[T]
+	demand     int64
+	wake       chan struct{}
+	cancel     context.CancelFunc
+	mutex      sync.Mutex
+}
+
+func (s *chanSubscription[T]) Request(n int64) {
+	if n <= 0 {
+		s.cancel()
+		s.subscriber.OnError(fmt.Errorf("request must be positive, got %d", n))
+		return
+	}
+
+	s.mutex.Lock()
+	if s.demand > math.MaxInt64-n {
+		s.demand = math.MaxInt64
+	} else {
+		s.demand += n
+	}
+	s.mutex.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *chanSubscription[T]) Cancel() {
+	s.cancel()
+}
+
+func (s *chanSubscription[T]) take() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.demand == 0 {
+		return false
+	}
+
+	if s.demand != math.MaxInt64 {
+		s.demand--
+	}
+
+	return true
+}
+
+// fromChannel emits the values received from c, or the single outcome of settled if it is not nil.
+func fromChannel[T any](ctx context.Context, c <-chan T, settled func(context.Context) (T, error)) Publisher[T] {
+	return PublisherFunc[T](func(subscriber Subscriber[T]) {
+		ctx, cancel := context.WithCancel(ctx)
+		s := &chanSubscription[T]{subscriber: subscriber, wake: make(chan struct{}, 1), cancel: cancel}
+		subscriber.OnSubscribe(s)
+
+		go func() {
+			defer cancel()
+
+			for {
+				for !s.take() {
+					select {
+					case <-ctx.Done():
+						return
+					case <-s.wake:
+					}
+				}
+
+				if settled != nil {
+					v, err := settled(ctx)
+					if ctx.Err() != nil {
+						return
+					}
+
+					if err != nil {
+						subscriber.OnError(err)
+						return
+					}
+
+					subscriber.OnNext(v)
+					subscriber.OnComplete()
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-c:
+					if !ok {
+						subscriber.OnComplete()
+						return
+					}
+
+					subscriber.OnNext(v)
+				}
+			}
+		}()
+	})
+}
+
+type collector[T any] struct {
+	limit int64
+	items []T
+	err   error
+	done  chan struct{}
+	once  sync.Once
+
+	// subscription is set by OnSubscribe, which a Publisher may call asynchronously, and canceled records
+	// that wait gave up before it arrived, so that it is canceled then.
+	subscription Subscription
+	canceled     bool
+	mutex        sync.Mutex
+}
+
+func newCollector[T any](limit int64) *collector[T] {
+	return &collector[T]{limit: limit, done: make(chan struct{})}
+}
+
+func (c *collector[T]) OnSubscribe(s Subscription) {
+	c.mutex.Lock()
+	c.subscription = s
+	canceled := c.canceled
+	c.mutex.Unlock()
+
+	if canceled {
+		s.Cancel()
+		return
+	}
+
+	s.Request(c.limit)
+}
+
+func (c *collector[T]) OnNext(v T) {
+	c.items = append(c.items, v)
+	if int64(len(c.items)) >= c.limit {
+		c.cancel()
+		c.finish(nil)
+	}
+}
+
+func (c *collector[T]) OnError(err error) {
+	c.finish(err)
+}
+
+func (c *collector[T]) OnComplete() {
+	c.finish(nil)
+}
+
+func (c *collector[T]) finish(err error) {
+	c.once.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// cancel cancels the subscription, or makes OnSubscribe cancel it if it has not arrived yet.
+func (c *collector[T]) cancel() {
+	c.mutex.Lock()
+	c.canceled = true
+	s := c.subscription
+	c.mutex.Unlock()
+
+	if s != nil {
+		s.Cancel()
+	}
+}
+
+func (c *collector[T]) wait(ctx context.Context) ([]T, error) {
+	select {
+	case <-ctx.Done():
+		c.cancel()
+		return nil, ctx.Err()
+	case <-c.done:
+		return c.items, c.err
+	}
+}
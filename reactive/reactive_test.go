@@ -0,0 +1,158 @@
+package reactive_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oneofthezombies/promises"
+	. "github.com/oneofthezombies/promises/reactive"
+)
+
+func TestFromPromise(t *testing.T) {
+	ctx := context.Background()
+	p := promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		resolve(1)
+	})
+
+	v, err := Collect(ctx, FromPromise(ctx, p)).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 1 || v[0] != 1 {
+		t.Errorf("expected items to be [1], got %v", v)
+	}
+}
+
+func TestFromPromiseRejected(t *testing.T) {
+	ctx := context.Background()
+	p := promises.New(func(resolve promises.Resolve[int], reject promises.Reject) {
+		reject(errors.New("something went wrong"))
+	})
+
+	_, err := First(ctx, FromPromise(ctx, p)).Await(ctx)
+	if err == nil || err.Error() != "something went wrong" {
+		t.Errorf("expected error to be something went wrong, got %v", err)
+	}
+}
+
+func TestFromChannel(t *testing.T) {
+	ctx := context.Background()
+	c := make(chan int, 3)
+	c <- 1
+	c <- 2
+	c <- 3
+	close(c)
+
+	v, err := Collect(ctx, FromChannel(ctx, c)).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 3 {
+		t.Errorf("expected length to be 3, got %d", len(v))
+	}
+}
+
+func TestFirst(t *testing.T) {
+	ctx := context.Background()
+	c := make(chan string)
+	go func() {
+		c <- "hello"
+		c <- "world"
+	}()
+
+	v, err := First(ctx, FromChannel(ctx, c)).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != "hello" {
+		t.Errorf("expected value to be hello, got %s", v)
+	}
+}
+
+func TestFirstEmpty(t *testing.T) {
+	ctx := context.Background()
+	c := make(chan int)
+	close(c)
+
+	_, err := First(ctx, FromChannel(ctx, c)).Await(ctx)
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected error to be ErrEmpty, got %v", err)
+	}
+}
+
+type countingSubscriber struct {
+	subscription Subscription
+	items        chan int
+}
+
+func (s *countingSubscriber) OnSubscribe(sub Subscription) { s.subscription = sub; sub.Request(1) }
+func (s *countingSubscriber) OnNext(v int)                 { s.items <- v }
+func (s *countingSubscriber) OnError(err error)            {}
+func (s *countingSubscriber) OnComplete()                  {}
+
+func TestBackpressure(t *testing.T) {
+	ctx := context.Background()
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+
+	s := &countingSubscriber{items: make(chan int, 2)}
+	FromChannel(ctx, c).Subscribe(s)
+
+	if v := <-s.items; v != 1 {
+		t.Errorf("expected first item to be 1, got %d", v)
+	}
+
+	select {
+	case v := <-s.items:
+		t.Errorf("expected no item before request, got %d", v)
+	default:
+	}
+
+	s.subscription.Request(1)
+	if v := <-s.items; v != 2 {
+		t.Errorf("expected second item to be 2, got %d", v)
+	}
+
+	s.subscription.Cancel()
+}
+
+// recordingSubscription reports calls to Cancel on canceled.
+type recordingSubscription struct {
+	canceled chan struct{}
+}
+
+func (s recordingSubscription) Request(n int64) {}
+
+func (s recordingSubscription) Cancel() {
+	close(s.canceled)
+}
+
+func TestCollectCanceledBeforeSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	subscribe := make(chan Subscriber[int])
+	pub := PublisherFunc[int](func(s Subscriber[int]) {
+		go func() {
+			subscribe <- s
+		}()
+	})
+
+	p := Collect(ctx, pub)
+	s := <-subscribe
+	cancel()
+	if _, err := p.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	sub := recordingSubscription{canceled: make(chan struct{})}
+	s.OnSubscribe(sub)
+	select {
+	case <-sub.canceled:
+	default:
+		t.Error("expected a subscription that arrived after the cancellation to be canceled")
+	}
+}
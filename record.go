@@ -0,0 +1,131 @@
+package promises
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	// ErrNotRecorded is the reason a replayed promise is rejected with when its recording has no outcome left for it.
+	ErrNotRecorded = errors.New("no recorded outcome")
+)
+
+// Recording holds the outcomes of named promises, so that they can be recorded in one run and replayed in another.
+// Record into it with WithRecord or Config.Record, save it with WriteTo, load it with ReadRecording
+// and replay it with WithReplay or Config.Replay.
+//
+// Outcomes are kept per name in the order the promises were created, and values are encoded with gob,
+// so the value types must be gob-encodable. Promises without a name are neither recorded nor replayed.
+type Recording struct {
+	outcomes map[string][][]byte
+	replayed map[string]int
+	err      error
+	mutex    sync.Mutex
+}
+
+// NewRecording returns an empty recording.
+func NewRecording() *Recording {
+	return &Recording{outcomes: make(map[string][][]byte), replayed: make(map[string]int)}
+}
+
+// ReadRecording reads a recording saved with WriteTo.
+func ReadRecording(r io.Reader) (*Recording, error) {
+	rec := NewRecording()
+	if err := gob.NewDecoder(r).Decode(&rec.outcomes); err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// WriteTo saves the recording to w. Outcomes of promises still pending are saved as missing.
+func (r *Recording) WriteTo(w io.Writer) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cw := &countingWriter{w: w}
+	err := gob.NewEncoder(cw).Encode(r.outcomes)
+	return cw.n, err
+}
+
+// Err returns the first error encoding an outcome, if any.
+func (r *Recording) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.err
+}
+
+// WithRecord records the outcome of the promise into r under the name given with WithName.
+func WithRecord(r *Recording) Option {
+	return func(o *options) {
+		o.record = r
+	}
+}
+
+// WithReplay settles the promise with the next outcome recorded in r under its name instead of running its executor.
+// If none is left, the promise is rejected with an error wrapping ErrNotRecorded.
+func WithReplay(r *Recording) Option {
+	return func(o *options) {
+		o.replay = r
+	}
+}
+
+// reserve returns the slot of a new promise named name, so that outcomes are kept in creation order.
+func (r *Recording) reserve(name string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.outcomes[name] = append(r.outcomes[name], nil)
+	return len(r.outcomes[name]) - 1
+}
+
+func record[T any](r *Recording, name string, slot int, result SettledResult[T]) {
+	b, err := result.MarshalBinary()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("record %s: %w", name, err)
+		}
+
+		return
+	}
+
+	r.outcomes[name][slot] = b
+}
+
+// replay settles a promise named name with its next recorded outcome.
+func replay[T any](r *Recording, name string, resolve Resolve[T], reject Reject) {
+	r.mutex.Lock()
+	i := r.replayed[name]
+	r.replayed[name]++
+	var b []byte
+	if i < len(r.outcomes[name]) {
+		b = r.outcomes[name][i]
+	}
+	r.mutex.Unlock()
+
+	if b == nil {
+		reject(fmt.Errorf("%w: %s #%d", ErrNotRecorded, name, i))
+		return
+	}
+
+	settleEncoded(b, resolve, reject)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	return n, err
+}
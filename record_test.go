@@ -0,0 +1,78 @@
+package promises_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	rec := NewRecording()
+	first := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithName("fetch"), WithRecord(rec))
+	second := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("boom"))
+	}, WithName("fetch"), WithRecord(rec))
+	_, _ = first.Await(ctx)
+	_, _ = second.Await(ctx)
+
+	if err := rec.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restore := Configure(Config{Replay: replayed})
+	defer restore()
+
+	fetch := func() *Promise[int] {
+		return New(func(resolve Resolve[int], reject Reject) {
+			t.Error("expected the executor not to run during replay")
+			resolve(0)
+		}, WithName("fetch"))
+	}
+
+	if v, err := fetch().Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	if _, err := fetch().Await(ctx); err == nil || err.Error() != "boom" {
+		t.Errorf("expected boom, got %v", err)
+	}
+
+	if _, err := fetch().Await(ctx); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded, got %v", err)
+	}
+
+	unnamed := New(func(resolve Resolve[int], reject Reject) {
+		resolve(2)
+	})
+	if v, err := unnamed.Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected unnamed promises to run, got %v, %v", v, err)
+	}
+}
+
+func TestRecordEncodingError(t *testing.T) {
+	rec := NewRecording()
+	p := New(func(resolve Resolve[func()], reject Reject) {
+		resolve(func() {})
+	}, WithName("func"), WithRecord(rec))
+	<-p.Done()
+
+	if err := rec.Err(); err == nil {
+		t.Errorf("expected an encoding error")
+	}
+}
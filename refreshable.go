@@ -0,0 +1,75 @@
+package promises
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Refreshable holds the latest promise of a value that is rotated over time, such as an auth token
+// or a service discovery result. Refreshing swaps in a new promise for later consumers,
+// while consumers already holding the previous one keep awaiting it.
+type Refreshable[T any] struct {
+	fn      func(ctx context.Context) (T, error)
+	opts    []Option
+	current atomic.Pointer[Promise[T]]
+}
+
+// NewRefreshable returns a Refreshable whose first promise runs fn with ctx right away.
+// The options configure every promise it creates as in New.
+func NewRefreshable[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) *Refreshable[T] {
+	r := &Refreshable[T]{fn: fn, opts: opts}
+	r.Refresh(ctx)
+	return r
+}
+
+// Current returns the latest promise. Each caller gets a promise of its own, so canceling it cancels the latest
+// promise for the others only if they all canceled theirs, which leaves it canceled until the next Refresh.
+func (r *Refreshable[T]) Current() *Promise[T] {
+	return r.current.Load().handle()
+}
+
+// Refresh runs fn again with ctx and makes the new promise current before it settles. It returns the new promise
+// like Current.
+func (r *Refreshable[T]) Refresh(ctx context.Context) *Promise[T] {
+	p := New(func(resolve Resolve[T], reject Reject) {
+		v, err := r.fn(ctx)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	}, r.opts...).share()
+
+	r.current.Store(p)
+	return p.handle()
+}
+
+// RefreshEvery refreshes with ctx every interval on the package clock until stop is called or ctx is done.
+func (r *Refreshable[T]) RefreshEvery(ctx context.Context, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			timer := CurrentClock().NewTimer(interval)
+			select {
+			case <-timer.C():
+				r.Refresh(ctx)
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
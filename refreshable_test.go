@@ -0,0 +1,83 @@
+package promises_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestRefreshable(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	block := make(chan struct{})
+	r := NewRefreshable(ctx, func(ctx context.Context) (int, error) {
+		n := int(calls.Add(1))
+		if n == 2 {
+			<-block
+		}
+
+		return n, nil
+	})
+
+	first := r.Current()
+	if v, err := first.Await(ctx); err != nil || v != 1 {
+		t.Fatalf("expected 1, got %v, %v", v, err)
+	}
+
+	r.Refresh(ctx)
+	if r.Current().IsSettled() {
+		t.Error("expected the refreshed promise to be current before it settles")
+	}
+
+	if v, _ := first.Await(ctx); v != 1 {
+		t.Errorf("expected holders of the old promise to keep its value, got %v", v)
+	}
+
+	close(block)
+	if v, err := r.Current().Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected 2, got %v, %v", v, err)
+	}
+}
+
+func TestRefreshEvery(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	r := NewRefreshable(ctx, func(ctx context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	})
+
+	stop := r.RefreshEvery(ctx, time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	stop()
+	stop()
+	if calls.Load() < 3 {
+		t.Fatalf("expected periodic refreshes, got %d calls", calls.Load())
+	}
+
+	if v, _ := r.Current().Await(ctx); v < 3 {
+		t.Errorf("expected the latest value, got %v", v)
+	}
+}
+
+func TestRefreshableCurrentShared(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	r := NewRefreshable(ctx, func(ctx context.Context) (int, error) {
+		<-block
+		return 1, nil
+	}, WithConsumerCancel())
+
+	other := r.Current()
+	Then(ctx, r.Current(), func(v int) (int, error) { return v, nil }).Cancel(nil)
+	close(block)
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected a canceled consumer not to cancel the current value for the others, got %v, %v", v, err)
+	}
+}
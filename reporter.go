@@ -0,0 +1,141 @@
+package promises
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Report describes a panic or an unhandled rejection of a promise, for crash reporters such as Sentry or Rollbar.
+type Report struct {
+	// Info identifies the promise. Info.Site is where it was created, and Info.Stack, for unhandled rejections,
+	// the stack of the goroutine that created it.
+	Info
+
+	// Panic is the recovered panic, with its stack, for reports of OnPanic.
+	Panic *PanicError
+
+	// Reason is the reason the promise was rejected with. For panics, it is Panic.
+	Reason error
+}
+
+// reporters is a list of report functions that can be read without locking.
+type reporters struct {
+	list  atomic.Pointer[[]*func(Report)]
+	mutex sync.Mutex
+}
+
+var (
+	panicReporters     reporters
+	rejectionReporters reporters
+)
+
+// OnPanic registers fn to be called with a report whenever the executor of a promise created from now on panics,
+// whether or not the panic is recovered. It returns a function that removes fn.
+func OnPanic(fn func(Report)) (remove func()) {
+	return panicReporters.add(fn)
+}
+
+// OnUnhandledRejection registers fn to be called with a report for every rejected promise
+// whose reason is never read with Await, Reason, Err or Snapshot, nor passed to an OnSettle callback.
+// The promise is reported when it is garbage collected, so reports are delayed until the next collections.
+// Only promises created while a function is registered are reported, with the stack they were created from.
+// It returns a function that removes fn.
+func OnUnhandledRejection(fn func(Report)) (remove func()) {
+	return rejectionReporters.add(fn)
+}
+
+func (r *reporters) add(fn func(Report)) (remove func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := &fn
+	next := append(r.load(), entry)
+	r.list.Store(&next)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			current := r.load()
+			next := make([]*func(Report), 0, len(current))
+			for _, other := range current {
+				if other != entry {
+					next = append(next, other)
+				}
+			}
+
+			r.list.Store(&next)
+		})
+	}
+}
+
+func (r *reporters) load() []*func(Report) {
+	list := r.list.Load()
+	if list == nil {
+		return nil
+	}
+
+	return *list
+}
+
+func (r *reporters) active() bool {
+	return len(r.load()) > 0
+}
+
+func (r *reporters) report(report Report) {
+	for _, fn := range r.load() {
+		(*fn)(report)
+	}
+}
+
+func reportersActive() bool {
+	return panicReporters.active() || rejectionReporters.active() || leakReporters.active()
+}
+
+// reportPanic reports the panic of the executor of the promise with info, which may be nil.
+func reportPanic(info *Info, err *PanicError) {
+	report := Report{Panic: err, Reason: err}
+	if info != nil {
+		report.Info = *info
+	}
+
+	panicReporters.report(report)
+}
+
+// watchRejection reports the rejected promise when it is garbage collected if its reason was never read.
+func watchRejection[T any](p *Promise[T], reason error) {
+	if p.info == nil || !rejectionReporters.active() {
+		return
+	}
+
+	info := *p.info
+	runtime.SetFinalizer(p, func(p *Promise[T]) {
+		if !p.handled.Load() {
+			rejectionReporters.report(Report{Info: info, Reason: reason})
+		}
+	})
+}
+
+const packagePrefix = "github.com/oneofthezombies/promises"
+
+// creationSite returns the file and line of the first caller outside this module's packages.
+func creationSite() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		rest, ok := strings.CutPrefix(frame.Function, packagePrefix)
+		if !ok || !(strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "/")) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
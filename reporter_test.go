@@ -0,0 +1,127 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestOnPanic(t *testing.T) {
+	ctx := context.Background()
+	reports := make(chan Report, 1)
+	defer OnPanic(func(r Report) {
+		reports <- r
+	})()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	}, WithName("crash"), WithRecover())
+
+	if _, err := p.Await(ctx); err == nil {
+		t.Fatal("expected the panic to reject the promise")
+	}
+
+	r := <-reports
+	if r.Name != "crash" || r.ID == 0 {
+		t.Errorf("expected the report to identify the promise, got %+v", r.Info)
+	}
+
+	if r.Panic == nil || r.Panic.Value != "boom" || len(r.Panic.Stack) == 0 {
+		t.Errorf("expected the recovered value with a stack, got %+v", r.Panic)
+	}
+
+	if !errors.Is(r.Reason, r.Panic) {
+		t.Errorf("expected the reason to be the panic, got %v", r.Reason)
+	}
+
+	if !strings.Contains(r.Site, "reporter_test.go:") {
+		t.Errorf("expected the creation site in this file, got %q", r.Site)
+	}
+}
+
+func TestOnPanicRemove(t *testing.T) {
+	ctx := context.Background()
+	reported := false
+	OnPanic(func(r Report) {
+		reported = true
+	})()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	}, WithRecover())
+
+	_, _ = p.Await(ctx)
+	if reported {
+		t.Error("expected no report after removal")
+	}
+}
+
+// rejectUnread creates a rejected promise named name and drops it without reading its reason.
+func rejectUnread(name string, read bool) {
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New(name))
+	}, WithName(name))
+
+	<-p.Done()
+	if read {
+		_ = p.Err()
+	}
+}
+
+func awaitReport(reports <-chan Report, name string, timeout time.Duration) (Report, bool) {
+	deadline := time.After(timeout)
+	for {
+		runtime.GC()
+		select {
+		case r := <-reports:
+			if r.Name == name {
+				return r, true
+			}
+		case <-deadline:
+			return Report{}, false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestOnUnhandledRejection(t *testing.T) {
+	reports := make(chan Report, 16)
+	defer OnUnhandledRejection(func(r Report) {
+		reports <- r
+	})()
+
+	rejectUnread("unhandled", false)
+	r, ok := awaitReport(reports, "unhandled", 5*time.Second)
+	if !ok {
+		t.Fatal("expected the unhandled rejection to be reported")
+	}
+
+	if r.Reason == nil || r.Reason.Error() != "unhandled" || r.Panic != nil {
+		t.Errorf("expected the rejection reason, got %+v", r)
+	}
+
+	if !strings.Contains(r.Site, "reporter_test.go:") {
+		t.Errorf("expected the creation site in this file, got %q", r.Site)
+	}
+
+	if !strings.Contains(string(r.Stack), "rejectUnread") {
+		t.Errorf("expected the creation stack, got %s", r.Stack)
+	}
+}
+
+func TestOnUnhandledRejectionHandled(t *testing.T) {
+	reports := make(chan Report, 16)
+	defer OnUnhandledRejection(func(r Report) {
+		reports <- r
+	})()
+
+	rejectUnread("handled", true)
+	if r, ok := awaitReport(reports, "handled", 200*time.Millisecond); ok {
+		t.Errorf("expected no report for a handled rejection, got %+v", r)
+	}
+}
@@ -0,0 +1,63 @@
+package promises
+
+import "context"
+
+// Result is a value or the error that prevented it from being produced.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Unwrap returns the value and the error of the result.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// OrElse returns the value of the result, or fallback if the result has an error.
+func (r Result[T]) OrElse(fallback T) T {
+	if r.Err != nil {
+		return fallback
+	}
+
+	return r.Value
+}
+
+// MapResult applies fn to the value of the result. An error in r is passed through without calling fn.
+func MapResult[T, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.Err != nil {
+		return Result[U]{Err: r.Err}
+	}
+
+	v, err := fn(r.Value)
+	return Result[U]{Value: v, Err: err}
+}
+
+// Result returns the settled result as a Result.
+func (r SettledResult[T]) Result() Result[T] {
+	if r.Status == Rejected {
+		return Result[T]{Err: r.Reason}
+	}
+
+	return Result[T]{Value: r.Value}
+}
+
+// Result returns the outcome of the settlement as a Result.
+func (s Settlement) Result() Result[any] {
+	if s.Status == Rejected {
+		return Result[any]{Err: s.Reason}
+	}
+
+	return Result[any]{Value: s.Value}
+}
+
+// ResultChan returns a channel that receives the result of the promise once it is settled, or the context error if ctx is done first.
+// The channel is buffered, so the result is delivered even if nobody receives it.
+func (p *Promise[T]) ResultChan(ctx context.Context) <-chan Result[T] {
+	c := make(chan Result[T], 1)
+	go func() {
+		v, err := p.Await(ctx)
+		c <- Result[T]{Value: v, Err: err}
+	}()
+
+	return c
+}
@@ -0,0 +1,64 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestResultUnwrap(t *testing.T) {
+	v, err := Result[int]{Value: 1}.Unwrap()
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != 1 {
+		t.Errorf("expected value to be 1, got %d", v)
+	}
+}
+
+func TestResultOrElse(t *testing.T) {
+	r := Result[int]{Err: errors.New("something went wrong")}
+	if v := r.OrElse(2); v != 2 {
+		t.Errorf("expected value to be 2, got %d", v)
+	}
+}
+
+func TestMapResult(t *testing.T) {
+	r := MapResult(Result[string]{Value: "42"}, strconv.Atoi)
+	if r.Err != nil || r.Value != 42 {
+		t.Errorf("expected 42, got %d %v", r.Value, r.Err)
+	}
+
+	r = MapResult(Result[string]{Value: "x"}, strconv.Atoi)
+	if r.Err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
+
+func TestResultChan(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+
+	r := <-p.ResultChan(ctx)
+	if r.Err != nil || r.Value != 1 {
+		t.Errorf("expected 1, got %d %v", r.Value, r.Err)
+	}
+}
+
+func TestSettledResultResult(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("something went wrong"))
+	})
+
+	results, _ := AllSettled(ctx, p).Await(ctx)
+	if r := results[0].Result(); r.Err == nil {
+		t.Errorf("expected error to be non-nil")
+	}
+}
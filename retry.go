@@ -0,0 +1,127 @@
+package promises
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryOptions configures Retry.
+type RetryOptions struct {
+	// MaxAttempts is the number of attempts, including the first. It defaults to 3.
+	MaxAttempts int
+
+	// InitialBackoff is how long to wait after the first failed attempt. It defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// Multiplier is the factor the backoff grows by after every failed attempt. It defaults to 2.
+	Multiplier float64
+
+	// MaxBackoff caps the backoff. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Jitter shortens every backoff by a random fraction of it, up to Jitter, so that callers failing together
+	// do not retry together. It is clamped to [0, 1].
+	Jitter float64
+
+	// Retryable reports whether an attempt that failed with err may be retried. If nil, every error is retryable.
+	Retryable func(err error) bool
+
+	// AttemptTimeout is how long each attempt may take before it is rejected with ErrTimeout
+	// and its context is canceled. Zero means no timeout.
+	AttemptTimeout time.Duration
+
+	// Rand returns a pseudo-random number in [0, 1) and must be safe for concurrent use.
+	// If nil, math/rand.Float64 is used.
+	Rand func() float64
+}
+
+// RetryError is the reason a promise created with Retry is rejected with when no attempt succeeds.
+// Errors holds the error of every attempt in order, followed by the context error if ctx was done while waiting,
+// and errors.Is and errors.As match any of them.
+type RetryError struct {
+	Errors []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%d attempts failed: %v", len(e.Errors), e.Errors[len(e.Errors)-1])
+}
+
+func (e *RetryError) Unwrap() []error {
+	return e.Errors
+}
+
+// Retry returns a promise of fn, called with the attempt number from 1 until it succeeds, fails with an error
+// that is not retryable, or MaxAttempts is reached. Failed attempts are followed by an exponential backoff
+// on the package clock. If no attempt succeeds, the promise is rejected with a *RetryError.
+func Retry[T any](ctx context.Context, opts RetryOptions, fn func(ctx context.Context, attempt int) (T, error)) *Promise[T] {
+	opts.defaults()
+
+	return New(func(resolve Resolve[T], reject Reject) {
+		var errs []error
+		for attempt := 1; ; attempt++ {
+			v, err := retryAttempt(ctx, opts.AttemptTimeout, attempt, fn).Await(ctx)
+			if err == nil {
+				resolve(v)
+				return
+			}
+
+			errs = append(errs, err)
+			if attempt >= opts.MaxAttempts || ctx.Err() != nil || (opts.Retryable != nil && !opts.Retryable(err)) {
+				reject(&RetryError{Errors: errs})
+				return
+			}
+
+			delay := Delay(opts.backoff(attempt))
+			if _, err := delay.Await(ctx); err != nil {
+				// Stop the timer of the delay instead of letting it run out the backoff.
+				delay.Cancel(err)
+				reject(&RetryError{Errors: append(errs, err)})
+				return
+			}
+		}
+	})
+}
+
+func retryAttempt[T any](ctx context.Context, timeout time.Duration, attempt int, fn func(ctx context.Context, attempt int) (T, error)) *Promise[T] {
+	var opts []Option
+	if timeout > 0 {
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	return NewWithContext(ctx, func(ctx context.Context, resolve Resolve[T], reject Reject) {
+		settleWith(resolve, reject)(fn(ctx, attempt))
+	}, opts...)
+}
+
+func (o *RetryOptions) defaults() {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = 100 * time.Millisecond
+	}
+
+	if o.Multiplier <= 0 {
+		o.Multiplier = 2
+	}
+
+	o.Jitter = math.Min(math.Max(o.Jitter, 0), 1)
+	if o.Rand == nil {
+		o.Rand = rand.Float64
+	}
+}
+
+// backoff returns how long to wait after the given failed attempt.
+func (o *RetryOptions) backoff(attempt int) time.Duration {
+	d := float64(o.InitialBackoff) * math.Pow(o.Multiplier, float64(attempt-1))
+	if o.MaxBackoff > 0 {
+		d = math.Min(d, float64(o.MaxBackoff))
+	}
+
+	d -= d * o.Jitter * o.Rand()
+	return time.Duration(d)
+}
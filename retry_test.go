@@ -0,0 +1,184 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+// recordingClock fires every timer right away and records the durations it was asked for.
+type recordingClock struct {
+	mutex sync.Mutex
+	waits []time.Duration
+}
+
+func (c *recordingClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *recordingClock) NewTimer(d time.Duration) Timer {
+	c.mutex.Lock()
+	c.waits = append(c.waits, d)
+	c.mutex.Unlock()
+
+	return RealClock().NewTimer(0)
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	clock := &recordingClock{}
+	defer SetClock(clock)()
+
+	var attempts []int
+	v, err := Retry(ctx, RetryOptions{
+		MaxAttempts:    4,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     30 * time.Millisecond,
+	}, func(ctx context.Context, attempt int) (int, error) {
+		attempts = append(attempts, attempt)
+		if attempt < 4 {
+			return 0, errors.New("unavailable")
+		}
+
+		return 42, nil
+	}).Await(ctx)
+	if err != nil || v != 42 {
+		t.Fatalf("expected 42, got %v, %v", v, err)
+	}
+
+	if len(attempts) != 4 || attempts[0] != 1 || attempts[3] != 4 {
+		t.Errorf("expected attempts 1 to 4, got %v", attempts)
+	}
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	if len(clock.waits) != len(want) {
+		t.Fatalf("expected backoffs %v, got %v", want, clock.waits)
+	}
+
+	for i, d := range want {
+		if clock.waits[i] != d {
+			t.Errorf("expected backoffs %v, got %v", want, clock.waits)
+		}
+	}
+}
+
+func TestRetryJitter(t *testing.T) {
+	ctx := context.Background()
+	clock := &recordingClock{}
+	defer SetClock(clock)()
+
+	Retry(ctx, RetryOptions{
+		MaxAttempts:    2,
+		InitialBackoff: 100 * time.Millisecond,
+		Jitter:         0.5,
+		Rand:           func() float64 { return 0.5 },
+	}, func(ctx context.Context, attempt int) (int, error) {
+		return 0, errors.New("unavailable")
+	}).Await(ctx)
+
+	if len(clock.waits) != 1 || clock.waits[0] != 75*time.Millisecond {
+		t.Errorf("expected a backoff of 75ms, got %v", clock.waits)
+	}
+}
+
+func TestRetryHistory(t *testing.T) {
+	ctx := context.Background()
+	defer SetClock(&recordingClock{})()
+
+	first := errors.New("first")
+	fatal := errors.New("fatal")
+	_, err := Retry(ctx, RetryOptions{
+		MaxAttempts: 5,
+		Retryable: func(err error) bool {
+			return err != fatal
+		},
+	}, func(ctx context.Context, attempt int) (int, error) {
+		if attempt == 1 {
+			return 0, first
+		}
+
+		return 0, fatal
+	}).Await(ctx)
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || len(retryErr.Errors) != 2 {
+		t.Fatalf("expected a *RetryError with two attempts, got %v", err)
+	}
+
+	if !errors.Is(err, first) || !errors.Is(err, fatal) {
+		t.Errorf("expected the reason to match the error of every attempt, got %v", err)
+	}
+}
+
+func TestRetryAttemptTimeout(t *testing.T) {
+	ctx := context.Background()
+	_, err := Retry(ctx, RetryOptions{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		AttemptTimeout: time.Millisecond,
+	}, func(ctx context.Context, attempt int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}).Await(ctx)
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || len(retryErr.Errors) != 2 || !errors.Is(retryErr.Errors[1], ErrTimeout) {
+		t.Errorf("expected both attempts to time out, got %v", err)
+	}
+}
+
+// stoppedTimer is a timer that never fires on its own and reports when it is stopped.
+type stoppedTimer struct {
+	c       chan time.Time
+	stopped chan struct{}
+}
+
+func (t *stoppedTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *stoppedTimer) Stop() bool {
+	close(t.stopped)
+	return true
+}
+
+// stoppingClock hands out stoppedTimers that share the stopped channel.
+type stoppingClock struct {
+	stopped chan struct{}
+}
+
+func (c *stoppingClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *stoppingClock) NewTimer(d time.Duration) Timer {
+	return &stoppedTimer{c: make(chan time.Time), stopped: c.stopped}
+}
+
+func TestRetryCancelStopsBackoff(t *testing.T) {
+	clock := &stoppingClock{stopped: make(chan struct{})}
+	defer SetClock(clock)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempted := make(chan struct{})
+	p := Retry(ctx, RetryOptions{MaxAttempts: 2, InitialBackoff: time.Hour}, func(ctx context.Context, attempt int) (int, error) {
+		close(attempted)
+		return 0, errors.New("unavailable")
+	})
+
+	<-attempted
+	cancel()
+	if _, err := p.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the retry to be canceled, got %v", err)
+	}
+
+	select {
+	case <-clock.stopped:
+	case <-time.After(time.Second):
+		t.Error("expected the backoff timer to be stopped")
+	}
+}
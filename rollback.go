@@ -0,0 +1,93 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Op is an operation of AllOrRollback and the undo that reverts it.
+// Undo may be nil if the operation needs no reverting.
+type Op[T any] struct {
+	Execute func(ctx context.Context) (T, error)
+	Undo    func(ctx context.Context, value T) error
+}
+
+// RollbackError is the reason AllOrRollback is rejected with.
+type RollbackError struct {
+	// Errs are the errors of the operations that failed, in the order of the operations.
+	Errs []error
+
+	// UndoErrs are the errors of the undos that failed.
+	UndoErrs []error
+}
+
+func (e *RollbackError) Error() string {
+	if len(e.UndoErrs) == 0 {
+		return fmt.Sprintf("rolled back: %v", errors.Join(e.Errs...))
+	}
+
+	return fmt.Sprintf("rolled back: %v; undo failed: %v", errors.Join(e.Errs...), errors.Join(e.UndoErrs...))
+}
+
+// Unwrap returns the operation errors followed by the undo errors, so errors.Is and errors.As match any of them.
+func (e *RollbackError) Unwrap() []error {
+	return append(append([]error(nil), e.Errs...), e.UndoErrs...)
+}
+
+// AllOrRollback executes the operations concurrently and returns a promise that is fulfilled with their values in order.
+// If any operation fails, it waits for the others, undoes the ones that succeeded and rejects with a *RollbackError.
+// Undos run concurrently and even if ctx is done, with its values but not its cancellation.
+func AllOrRollback[T any](ctx context.Context, ops ...Op[T]) *Promise[[]T] {
+	return New(func(resolve Resolve[[]T], reject Reject) {
+		values := make([]T, len(ops))
+		errs := make([]error, len(ops))
+
+		var wg sync.WaitGroup
+		wg.Add(len(ops))
+		for i, op := range ops {
+			go func(i int, op Op[T]) {
+				defer wg.Done()
+
+				values[i], errs[i] = op.Execute(ctx)
+			}(i, op)
+		}
+
+		wg.Wait()
+
+		rollback := &RollbackError{}
+		for _, err := range errs {
+			if err != nil {
+				rollback.Errs = append(rollback.Errs, err)
+			}
+		}
+
+		if len(rollback.Errs) == 0 {
+			resolve(values)
+			return
+		}
+
+		undoCtx := context.WithoutCancel(ctx)
+		var mutex sync.Mutex
+		for i, op := range ops {
+			if errs[i] != nil || op.Undo == nil {
+				continue
+			}
+
+			wg.Add(1)
+			go func(i int, op Op[T]) {
+				defer wg.Done()
+
+				if err := op.Undo(undoCtx, values[i]); err != nil {
+					mutex.Lock()
+					rollback.UndoErrs = append(rollback.UndoErrs, err)
+					mutex.Unlock()
+				}
+			}(i, op)
+		}
+
+		wg.Wait()
+		reject(rollback)
+	})
+}
@@ -0,0 +1,75 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+type ledger struct {
+	undone []int
+	mutex  sync.Mutex
+}
+
+func (l *ledger) op(v int, err, undoErr error) Op[int] {
+	return Op[int]{
+		Execute: func(ctx context.Context) (int, error) {
+			return v, err
+		},
+		Undo: func(ctx context.Context, value int) error {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+
+			l.undone = append(l.undone, value)
+			return undoErr
+		},
+	}
+}
+
+func TestAllOrRollback(t *testing.T) {
+	ctx := context.Background()
+	l := &ledger{}
+
+	v, err := AllOrRollback(ctx, l.op(1, nil, nil), l.op(2, nil, nil)).Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(v) != 2 || v[0] != 1 || v[1] != 2 {
+		t.Errorf("expected [1 2], got %v", v)
+	}
+
+	if len(l.undone) != 0 {
+		t.Errorf("expected nothing to be undone, got %v", l.undone)
+	}
+}
+
+func TestAllOrRollbackUndoes(t *testing.T) {
+	ctx := context.Background()
+	l := &ledger{}
+	cause := errors.New("cause")
+	undoErr := errors.New("undo failed")
+
+	_, err := AllOrRollback(ctx, l.op(1, nil, nil), l.op(2, cause, nil), l.op(3, nil, undoErr)).Await(ctx)
+	var rollback *RollbackError
+	if !errors.As(err, &rollback) {
+		t.Fatalf("expected *RollbackError, got %v", err)
+	}
+
+	if !errors.Is(err, cause) || !errors.Is(err, undoErr) {
+		t.Errorf("expected the cause and the undo error, got %v", err)
+	}
+
+	if len(l.undone) != 2 {
+		t.Errorf("expected the two successful operations to be undone, got %v", l.undone)
+	}
+
+	for _, v := range l.undone {
+		if v == 2 {
+			t.Error("expected the failed operation not to be undone")
+		}
+	}
+}
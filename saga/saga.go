@@ -0,0 +1,104 @@
+// Package saga runs multi-step operations with compensations, such as writes to several services.
+//
+// The steps of a saga run one after another. If a step fails, the compensations of the steps that completed
+// run in reverse order and the saga is rejected with an *Error holding the cause and any compensation errors.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oneofthezombies/promises"
+)
+
+// Step is one action of a saga and the compensation that undoes it.
+// Compensate may be nil if the action needs no undoing.
+type Step struct {
+	Name       string
+	Action     func(ctx context.Context) error
+	Compensate func(ctx context.Context) error
+}
+
+// Saga is a sequence of steps. It is built with New and Step and started with Run.
+type Saga struct {
+	steps []Step
+}
+
+// Error is the reason a saga is rejected with when a step fails.
+type Error struct {
+	// Step is the name of the step that failed.
+	Step string
+
+	// Cause is the error returned by the failed step, or the context error if the saga was canceled.
+	Cause error
+
+	// CompensationErrors are the errors returned by compensations, in the order they ran.
+	CompensationErrors []error
+}
+
+func (e *Error) Error() string {
+	if len(e.CompensationErrors) == 0 {
+		return fmt.Sprintf("saga step %q failed: %v", e.Step, e.Cause)
+	}
+
+	return fmt.Sprintf("saga step %q failed: %v; compensation failed: %v", e.Step, e.Cause, errors.Join(e.CompensationErrors...))
+}
+
+// Unwrap returns the cause followed by the compensation errors, so errors.Is and errors.As match any of them.
+func (e *Error) Unwrap() []error {
+	return append([]error{e.Cause}, e.CompensationErrors...)
+}
+
+// New creates an empty saga.
+func New() *Saga {
+	return &Saga{}
+}
+
+// Step appends a step and returns the saga for chaining.
+func (s *Saga) Step(name string, action, compensate func(ctx context.Context) error) *Saga {
+	s.steps = append(s.steps, Step{Name: name, Action: action, Compensate: compensate})
+	return s
+}
+
+// Run runs the steps in order and returns a promise that is fulfilled once they all succeed.
+// If a step fails or ctx is done between steps, the completed steps are compensated in reverse order
+// and the promise is rejected with an *Error. Compensations run even if ctx is done, with its values but not its cancellation.
+func (s *Saga) Run(ctx context.Context) *promises.Promise[struct{}] {
+	steps := append([]Step(nil), s.steps...)
+
+	return promises.New(func(resolve promises.Resolve[struct{}], reject promises.Reject) {
+		for i, step := range steps {
+			err := ctx.Err()
+			if err == nil {
+				err = step.Action(ctx)
+			}
+
+			if err != nil {
+				reject(&Error{Step: step.Name, Cause: err, CompensationErrors: compensate(ctx, steps[:i])})
+				return
+			}
+		}
+
+		resolve(struct{}{})
+	})
+}
+
+// compensate runs the compensations of completed steps in reverse order and returns their errors.
+func compensate(ctx context.Context, completed []Step) []error {
+	ctx = context.WithoutCancel(ctx)
+
+	var errs []error
+	for i := len(completed) - 1; i >= 0; i-- {
+		step := completed[i]
+		if step.Compensate == nil {
+			continue
+		}
+
+		if err := step.Compensate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("compensate %q: %w", step.Name, err))
+		}
+	}
+
+	return errs
+}
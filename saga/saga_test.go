@@ -0,0 +1,97 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oneofthezombies/promises/saga"
+)
+
+type journal struct {
+	entries []string
+}
+
+func (j *journal) step(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		j.entries = append(j.entries, name)
+		return err
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	j := &journal{}
+	s := saga.New().
+		Step("a", j.step("a", nil), j.step("undo a", nil)).
+		Step("b", j.step("b", nil), j.step("undo b", nil))
+
+	if _, err := s.Run(ctx).Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(j.entries, want) {
+		t.Errorf("expected %v, got %v", want, j.entries)
+	}
+}
+
+func TestRunCompensates(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("cause")
+	j := &journal{}
+	s := saga.New().
+		Step("a", j.step("a", nil), j.step("undo a", nil)).
+		Step("b", j.step("b", nil), nil).
+		Step("c", j.step("c", nil), j.step("undo c", nil)).
+		Step("d", j.step("d", cause), j.step("undo d", nil))
+
+	_, err := s.Run(ctx).Await(ctx)
+	var sagaErr *saga.Error
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("expected *saga.Error, got %v", err)
+	}
+
+	if sagaErr.Step != "d" || !errors.Is(err, cause) || len(sagaErr.CompensationErrors) != 0 {
+		t.Errorf("expected step d to fail with cause, got %+v", sagaErr)
+	}
+
+	if want := []string{"a", "b", "c", "d", "undo c", "undo a"}; !reflect.DeepEqual(j.entries, want) {
+		t.Errorf("expected %v, got %v", want, j.entries)
+	}
+}
+
+func TestRunCompensationErrors(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("cause")
+	undoErr := errors.New("undo failed")
+	j := &journal{}
+	s := saga.New().
+		Step("a", j.step("a", nil), j.step("undo a", undoErr)).
+		Step("b", j.step("b", cause), nil)
+
+	_, err := s.Run(ctx).Await(ctx)
+	if !errors.Is(err, cause) || !errors.Is(err, undoErr) {
+		t.Errorf("expected both the cause and the compensation error, got %v", err)
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &journal{}
+	s := saga.New().
+		Step("a", func(context.Context) error {
+			cancel()
+			return nil
+		}, j.step("undo a", nil)).
+		Step("b", j.step("b", nil), nil)
+
+	_, err := s.Run(ctx).Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if want := []string{"undo a"}; !reflect.DeepEqual(j.entries, want) {
+		t.Errorf("expected %v, got %v", want, j.entries)
+	}
+}
@@ -0,0 +1,20 @@
+package promises
+
+// Scheduler decides when and where executors run.
+type Scheduler interface {
+	Schedule(run func())
+}
+
+// SchedulerFunc adapts a function to a Scheduler.
+type SchedulerFunc func(run func())
+
+func (f SchedulerFunc) Schedule(run func()) {
+	f(run)
+}
+
+// OSThreadScheduler is a Scheduler that can also run executors on a goroutine locked to one OS thread,
+// for promises created with WithOSThread.
+type OSThreadScheduler interface {
+	Scheduler
+	ScheduleOnOSThread(run func())
+}
@@ -0,0 +1,67 @@
+package promises
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var (
+	// ErrNoPromises is the error Select returns when it is given no promises, which would never settle.
+	ErrNoPromises = errors.New("no promises")
+)
+
+// AnyPromise is implemented by promises of any value type,
+// so that barrier-style combinators such as Select and Join can aggregate differently-typed promises.
+type AnyPromise interface {
+	Done() <-chan struct{}
+	Err() error
+	State() Status
+}
+
+// Select blocks until one of the promises is settled and returns its index.
+// If several promises are already settled, the lowest index is returned.
+// The caller can then read the typed value of the winner itself.
+// If ctx is done first, Select returns -1 and the context error, and with no promises, -1 and ErrNoPromises.
+func Select(ctx context.Context, promises ...AnyPromise) (int, error) {
+	if len(promises) == 0 {
+		return -1, ErrNoPromises
+	}
+
+	for i, p := range promises {
+		select {
+		case <-p.Done():
+			return i, nil
+		default:
+		}
+	}
+
+	cases := make([]reflect.SelectCase, len(promises)+1)
+	for i, p := range promises {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.Done())}
+	}
+
+	cases[len(promises)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+
+	i, _, _ := reflect.Select(cases)
+	if i == len(promises) {
+		return -1, ctx.Err()
+	}
+
+	return i, nil
+}
+
+// Join returns a promise that is fulfilled once all promises are fulfilled, whatever their value types.
+// It is rejected with the reason of the first promise to be rejected as soon as it is known, or with the context error.
+// Values are read from the promises themselves.
+func Join(ctx context.Context, promises ...AnyPromise) *Promise[struct{}] {
+	promises = append([]AnyPromise(nil), promises...)
+	return New(func(resolve Resolve[struct{}], reject Reject) {
+		if err := awaitAll(ctx, promises...); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(struct{}{})
+	})
+}
@@ -0,0 +1,145 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestSelect(t *testing.T) {
+	ctx := context.Background()
+	p1 := New(func(resolve Resolve[int], reject Reject) {
+		time.Sleep(time.Second)
+		resolve(1)
+	})
+	p2 := New(func(resolve Resolve[string], reject Reject) {
+		resolve("hello")
+	})
+
+	i, err := Select(ctx, p1, p2)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if i != 1 {
+		t.Errorf("expected index to be 1, got %d", i)
+	}
+
+	if p2.Value() != "hello" {
+		t.Errorf("expected value to be hello, got %s", p2.Value())
+	}
+}
+
+func TestSelectAlreadySettled(t *testing.T) {
+	ctx := context.Background()
+	p1 := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	})
+	p2 := New(func(resolve Resolve[string], reject Reject) {
+		reject(errors.New("something went wrong"))
+	})
+	<-p1.Done()
+	<-p2.Done()
+
+	i, err := Select(ctx, p1, p2)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if i != 0 {
+		t.Errorf("expected index to be 0, got %d", i)
+	}
+}
+
+func TestSelectCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(func(resolve Resolve[int], reject Reject) {
+		time.Sleep(time.Second)
+		resolve(1)
+	})
+
+	cancel()
+	i, err := Select(ctx, p)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+
+	if i != -1 {
+		t.Errorf("expected index to be -1, got %d", i)
+	}
+}
+
+func TestSelectEmpty(t *testing.T) {
+	if i, err := Select(context.Background()); err != ErrNoPromises || i != -1 {
+		t.Errorf("expected -1 and ErrNoPromises, got %d, %v", i, err)
+	}
+}
+
+func TestState(t *testing.T) {
+	block := make(chan struct{})
+	p := blocked(block)
+	if s := p.State(); s != Pending || s.String() != "pending" {
+		t.Errorf("expected pending, got %v", s)
+	}
+
+	close(block)
+	<-p.Done()
+	if s := p.State(); s != Fulfilled {
+		t.Errorf("expected fulfilled, got %v", s)
+	}
+
+	rejected := after(0, 0, errors.New("reason"))
+	<-rejected.Done()
+	if s := rejected.State(); s != Rejected {
+		t.Errorf("expected rejected, got %v", s)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	block := make(chan struct{})
+	p := blocked(block)
+	if p.Status() != Pending || p.IsSettled() || p.IsFulfilled() || p.IsRejected() {
+		t.Errorf("expected pending, got %v", p.Status())
+	}
+
+	close(block)
+	<-p.Done()
+	if p.Status() != Fulfilled || !p.IsSettled() || !p.IsFulfilled() || p.IsRejected() {
+		t.Errorf("expected fulfilled, got %v", p.Status())
+	}
+
+	rejected := NewRejected[int](errors.New("reason"))
+	if rejected.Status() != Rejected || !rejected.IsSettled() || rejected.IsFulfilled() || !rejected.IsRejected() {
+		t.Errorf("expected rejected, got %v", rejected.Status())
+	}
+
+	if f := rejected.Future(); f.Status() != Rejected {
+		t.Errorf("expected the future to be rejected, got %v", f.Status())
+	}
+}
+
+func TestJoin(t *testing.T) {
+	ctx := context.Background()
+	pi := newResolved(1)
+	ps := New(func(resolve Resolve[string], reject Reject) {
+		resolve("a")
+	})
+
+	if _, err := Join(ctx, pi, ps).Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if pi.Value() != 1 || ps.Value() != "a" {
+		t.Errorf("expected the values to be read from the promises")
+	}
+
+	reason := errors.New("reason")
+	block := make(chan struct{})
+	defer close(block)
+	if _, err := Join(ctx, blocked(block), after(0, "", reason)).Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason without waiting for the blocked promise, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package promises
+
+import (
+	"context"
+	"iter"
+	"runtime"
+)
+
+type indexedResult[T any] struct {
+	index  int
+	result Result[T]
+}
+
+// AllSettledSeq returns an iterator over the results of the promises in the order they settle,
+// each with the index of its promise and the reason of a rejection as its Err, instead of waiting for all of them like AllSettled.
+// If ctx is done, the iteration stops.
+func AllSettledSeq[T any](ctx context.Context, promises ...*Promise[T]) iter.Seq2[int, Result[T]] {
+	return func(yield func(int, Result[T]) bool) {
+		results := make(chan indexedResult[T], len(promises))
+		for i, p := range promises {
+			p.OnSettle(func(r SettledResult[T]) {
+				results <- indexedResult[T]{i, r.Result()}
+			})
+		}
+
+		for range promises {
+			select {
+			case r := <-results:
+				if !yield(r.index, r.result) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Stream returns an iterator that runs the tasks of seq with at most limit of them at a time, and yields their results
+// in the order they settle, each with the index of its task. Tasks are taken from seq only as others finish,
+// so a huge fan-out is processed with bounded memory. A limit of zero or less means GOMAXPROCS.
+// When the iteration stops early or ctx is done, the context given to the running tasks is canceled.
+func Stream[T any](ctx context.Context, limit int, tasks iter.Seq[func(ctx context.Context) (T, error)]) iter.Seq2[int, Result[T]] {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	return func(yield func(int, Result[T]) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		next, stop := iter.Pull(tasks)
+		defer stop()
+
+		results := make(chan indexedResult[T], limit)
+		started, running := 0, 0
+		start := func() {
+			task, ok := next()
+			if !ok {
+				return
+			}
+
+			i := started
+			started++
+			running++
+			FromFunc(ctx, task).OnSettle(func(r SettledResult[T]) {
+				results <- indexedResult[T]{i, r.Result()}
+			})
+		}
+
+		for range limit {
+			start()
+		}
+
+		for running > 0 {
+			select {
+			case r := <-results:
+				running--
+				if !yield(r.index, r.result) {
+					return
+				}
+
+				start()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Collect runs seq to the end and returns the results by index.
+func Collect[T any](seq iter.Seq2[int, Result[T]]) []Result[T] {
+	var results []Result[T]
+	for i, r := range seq {
+		if i >= len(results) {
+			results = append(results, make([]Result[T], i+1-len(results))...)
+		}
+
+		results[i] = r
+	}
+
+	return results
+}
@@ -0,0 +1,96 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAllSettledSeq(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	promises := []*Promise[int]{
+		after(20*time.Millisecond, 0, nil),
+		NewRejected[int](cause),
+		after(10*time.Millisecond, 2, nil),
+	}
+
+	var order []int
+	for i, r := range AllSettledSeq(ctx, promises...) {
+		order = append(order, i)
+		if i == 1 && r.Err != cause {
+			t.Errorf("expected the reason of promise 1, got %v", r.Err)
+		}
+	}
+
+	if !slices.Equal(order, []int{1, 2, 0}) {
+		t.Errorf("expected the results in settlement order, got %v", order)
+	}
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	tasks := func(yield func(func(ctx context.Context) (int, error)) bool) {
+		for i := range 100 {
+			task := func(ctx context.Context) (int, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+				}
+
+				time.Sleep(time.Millisecond)
+				return i * 2, nil
+			}
+
+			if !yield(task) {
+				return
+			}
+		}
+	}
+
+	results := Collect(Stream(ctx, 4, tasks))
+	if len(results) != 100 {
+		t.Fatalf("expected 100 results, got %d", len(results))
+	}
+
+	for i, r := range results {
+		if r.Err != nil || r.Value != i*2 {
+			t.Fatalf("expected result %d to be %d, got %+v", i, i*2, r)
+		}
+	}
+
+	if peak.Load() > 4 {
+		t.Errorf("expected at most 4 tasks at a time, got %d", peak.Load())
+	}
+}
+
+func TestStreamBreak(t *testing.T) {
+	ctx := context.Background()
+	var pulled atomic.Int32
+	tasks := func(yield func(func(ctx context.Context) (int, error)) bool) {
+		for {
+			pulled.Add(1)
+			if !yield(func(ctx context.Context) (int, error) { return 1, nil }) {
+				return
+			}
+		}
+	}
+
+	n := 0
+	for range Stream(ctx, 2, tasks) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+
+	if p := pulled.Load(); p > 6 {
+		t.Errorf("expected tasks to be taken as others finish, got %d pulled", p)
+	}
+}
@@ -0,0 +1,27 @@
+package promises
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// OnSignal returns a promise that is fulfilled with the first of the signals received by the process.
+// If no signals are given, any incoming signal fulfills the promise, as with signal.Notify.
+// If ctx is done first, the promise is rejected with the context error.
+// Signal delivery is stopped once the promise is settled, including when it is canceled with Cancel.
+func OnSignal(ctx context.Context, signals ...os.Signal) *Promise[os.Signal] {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, signals...)
+
+	return NewWithContext(ctx, func(ctx context.Context, resolve Resolve[os.Signal], reject Reject) {
+		defer signal.Stop(c)
+
+		select {
+		case <-ctx.Done():
+			reject(ctx.Err())
+		case sig := <-c:
+			resolve(sig)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestOnSignal(t *testing.T) {
+	ctx := context.Background()
+	p := OnSignal(ctx, syscall.SIGUSR1)
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if v != syscall.SIGUSR1 {
+		t.Errorf("expected signal to be SIGUSR1, got %v", v)
+	}
+}
+
+func TestOnSignalCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := OnSignal(ctx, syscall.SIGUSR2)
+	cancel()
+
+	_, err := p.Await(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+	}
+}
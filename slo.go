@@ -0,0 +1,171 @@
+package promises
+
+import (
+	"sync"
+	"time"
+)
+
+// sloBuckets is the number of buckets a rolling window is divided into.
+const sloBuckets = 60
+
+// SLO is the service level objective of the promises with a given name.
+type SLO struct {
+	// Target is the fraction of promises expected to be fulfilled, such as 0.999.
+	Target float64
+
+	// Window is the rolling period over which the success rate is computed.
+	Window time.Duration
+}
+
+// SLOOptions configures an SLOTracker.
+type SLOOptions struct {
+	// Objectives maps promise names given with WithName to their objectives. Promises with other names are ignored.
+	Objectives map[string]SLO
+
+	// OnExhausted, if set, is called when the error budget of a name runs out.
+	// It is called again only after the budget has recovered and run out once more.
+	OnExhausted func(status SLOStatus)
+}
+
+// SLOStatus describes how a named operation is doing against its objective over the current window.
+type SLOStatus struct {
+	Name      string
+	Total     int
+	Failed    int
+	Objective SLO
+
+	// SuccessRate is the fraction of fulfilled promises, or 1 if there were none.
+	SuccessRate float64
+
+	// RemainingBudget is the fraction of the error budget left, from 1 when nothing failed to 0 when it is exhausted.
+	RemainingBudget float64
+}
+
+// Exhausted reports whether the error budget has run out.
+func (s SLOStatus) Exhausted() bool {
+	return s.Failed > 0 && s.RemainingBudget <= 0
+}
+
+// SLOTracker computes rolling success rates and error budgets of named promises.
+// It is an Observer: register it with AddObserver.
+type SLOTracker struct {
+	opts    SLOOptions
+	windows map[string]*sloWindow
+	mutex   sync.Mutex
+}
+
+type sloWindow struct {
+	buckets   [sloBuckets]sloBucket
+	exhausted bool
+}
+
+type sloBucket struct {
+	start  time.Time
+	total  int
+	failed int
+}
+
+// NewSLOTracker creates a tracker for the objectives in opts.
+func NewSLOTracker(opts SLOOptions) *SLOTracker {
+	return &SLOTracker{opts: opts, windows: make(map[string]*sloWindow)}
+}
+
+// OnCreate implements Observer.
+func (t *SLOTracker) OnCreate(info Info) {}
+
+// OnSettle implements Observer by counting the settlement against the objective of its name.
+func (t *SLOTracker) OnSettle(s Settlement) {
+	slo, ok := t.opts.Objectives[s.Name]
+	if !ok {
+		return
+	}
+
+	t.mutex.Lock()
+	w := t.windows[s.Name]
+	if w == nil {
+		w = &sloWindow{}
+		t.windows[s.Name] = w
+	}
+
+	b := w.bucket(slo.Window, s.SettledAt)
+	b.total++
+	if s.Status != Fulfilled {
+		b.failed++
+	}
+
+	status := w.status(s.Name, slo, s.SettledAt)
+	notify := status.Exhausted() && !w.exhausted
+	w.exhausted = status.Exhausted()
+	t.mutex.Unlock()
+
+	if notify && t.opts.OnExhausted != nil {
+		t.opts.OnExhausted(status)
+	}
+}
+
+// Status returns the status of name over the window ending now on the package clock.
+func (t *SLOTracker) Status(name string) SLOStatus {
+	slo := t.opts.Objectives[name]
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	w := t.windows[name]
+	if w == nil {
+		w = &sloWindow{}
+	}
+
+	return w.status(name, slo, CurrentClock().Now())
+}
+
+// bucket returns the bucket for now, resetting it if it was last used in a previous window.
+func (w *sloWindow) bucket(window time.Duration, now time.Time) *sloBucket {
+	width := window / sloBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	start := now.Truncate(width)
+	i := start.UnixNano() / int64(width) % sloBuckets
+	if i < 0 {
+		i += sloBuckets
+	}
+
+	b := &w.buckets[i]
+	if !b.start.Equal(start) {
+		*b = sloBucket{start: start}
+	}
+
+	return b
+}
+
+func (w *sloWindow) status(name string, slo SLO, now time.Time) SLOStatus {
+	s := SLOStatus{Name: name, Objective: slo, SuccessRate: 1, RemainingBudget: 1}
+	for _, b := range w.buckets {
+		if b.total > 0 && now.Sub(b.start) < slo.Window {
+			s.Total += b.total
+			s.Failed += b.failed
+		}
+	}
+
+	if s.Total == 0 {
+		return s
+	}
+
+	s.SuccessRate = float64(s.Total-s.Failed) / float64(s.Total)
+	allowed := (1 - slo.Target) * float64(s.Total)
+	if allowed <= 0 {
+		if s.Failed > 0 {
+			s.RemainingBudget = 0
+		}
+
+		return s
+	}
+
+	s.RemainingBudget = 1 - float64(s.Failed)/allowed
+	if s.RemainingBudget < 0 {
+		s.RemainingBudget = 0
+	}
+
+	return s
+}
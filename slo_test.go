@@ -0,0 +1,70 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestSLOTracker(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	defer SetClock(clock)()
+
+	var exhausted []SLOStatus
+	tracker := NewSLOTracker(SLOOptions{
+		Objectives: map[string]SLO{"api": {Target: 0.9, Window: time.Minute}},
+		OnExhausted: func(status SLOStatus) {
+			exhausted = append(exhausted, status)
+		},
+	})
+
+	settle := func(name string, status Status) {
+		tracker.OnSettle(Settlement{Info: Info{Name: name}, Status: status, SettledAt: clock.Now()})
+	}
+
+	for i := 0; i < 10; i++ {
+		settle("api", Fulfilled)
+	}
+
+	settle("api", Rejected)
+	settle("other", Rejected)
+
+	s := tracker.Status("api")
+	if s.Total != 11 || s.Failed != 1 || s.Exhausted() {
+		t.Errorf("expected 1 of 11 failed within budget, got %+v", s)
+	}
+
+	if s.RemainingBudget <= 0 || s.RemainingBudget >= 1 {
+		t.Errorf("expected part of the budget to remain, got %v", s.RemainingBudget)
+	}
+
+	settle("api", Rejected)
+	settle("api", Rejected)
+	if len(exhausted) != 1 || exhausted[0].Name != "api" || !exhausted[0].Exhausted() {
+		t.Errorf("expected OnExhausted to be called once, got %+v", exhausted)
+	}
+
+	clock.Add(2 * time.Minute)
+	if s := tracker.Status("api"); s.Total != 0 || s.RemainingBudget != 1 || s.SuccessRate != 1 {
+		t.Errorf("expected the window to roll over, got %+v", s)
+	}
+}
+
+func TestSLOTrackerObserver(t *testing.T) {
+	tracker := NewSLOTracker(SLOOptions{
+		Objectives: map[string]SLO{"api": {Target: 0.99, Window: time.Minute}},
+	})
+	defer AddObserver(tracker)()
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("boom"))
+	}, WithName("api"))
+	_, _ = p.Await(context.Background())
+
+	if s := tracker.Status("api"); s.Total != 1 || s.Failed != 1 || !s.Exhausted() {
+		t.Errorf("expected the rejection to exhaust the budget, got %+v", s)
+	}
+}
@@ -0,0 +1,100 @@
+package promises
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// WithSpill keeps a large value the promise is fulfilled with out of the heap: if its gob encoding is longer
+// than threshold bytes, it is written to a temporary file in dir, or the default directory for temporary files
+// if dir is empty, and decoded again on every read. The file is removed once the promise is garbage collected.
+// Values that cannot be gob encoded or written stay in memory.
+// If the file cannot be read back, Await returns the error, and Value, GetNow and Snapshot report the zero value.
+func WithSpill(threshold int, dir string) Option {
+	return func(o *options) {
+		o.spill = &spillPolicy{threshold: threshold, dir: dir}
+	}
+}
+
+type spillPolicy struct {
+	threshold int
+	dir       string
+}
+
+// spilledValue is a value written to a file.
+type spilledValue struct {
+	path string
+}
+
+// spill writes the value to a file if it is large enough, and returns nil if it stays in memory.
+func (s *spillPolicy) spill(value any) *spilledValue {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil || buf.Len() <= s.threshold {
+		return nil
+	}
+
+	f, err := os.CreateTemp(s.dir, "promise-*.gob")
+	if err != nil {
+		return nil
+	}
+
+	_, err = buf.WriteTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return nil
+	}
+
+	return &spilledValue{path: f.Name()}
+}
+
+func (s *spilledValue) remove() {
+	os.Remove(s.path)
+}
+
+// loadSpilled reads a spilled value back.
+func loadSpilled[T any](s *spilledValue) (T, error) {
+	var v T
+	f, err := os.Open(s.path)
+	if err != nil {
+		return v, fmt.Errorf("promises: read spilled value: %w", err)
+	}
+	defer f.Close()
+
+	if err := gob.NewDecoder(f).Decode(&v); err != nil {
+		return v, fmt.Errorf("promises: read spilled value: %w", err)
+	}
+
+	return v, nil
+}
+
+// spillFulfilled spills the value the promise is about to be fulfilled with, if o asks for it.
+func spillFulfilled[T any](o *options, value T) *spilledValue {
+	if o == nil || o.spill == nil {
+		return nil
+	}
+
+	return o.spill.spill(value)
+}
+
+// keepSpilled removes the file of the spilled value of the promise once the promise is garbage collected.
+func keepSpilled[T any](p *Promise[T], s *spilledValue) {
+	runtime.SetFinalizer(p, func(p *Promise[T]) {
+		s.remove()
+	})
+}
+
+// load returns the value v read from the promise, or its spilled value if there is one.
+func (p *Promise[T]) load(v T, s *spilledValue) (T, error) {
+	if s == nil {
+		return v, nil
+	}
+
+	return loadSpilled[T](s)
+}
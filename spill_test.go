@@ -0,0 +1,84 @@
+package promises_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func spilledFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "promise-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+func TestWithSpill(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	large := strings.Repeat("x", 1024)
+	p := New(func(resolve Resolve[string], reject Reject) {
+		resolve(large)
+	}, WithSpill(100, dir))
+
+	if v, err := p.Await(ctx); err != nil || v != large {
+		t.Fatalf("expected the large value back, got %d bytes, %v", len(v), err)
+	}
+
+	if len(spilledFiles(t, dir)) != 1 {
+		t.Errorf("expected the value to be spilled to one file, got %v", spilledFiles(t, dir))
+	}
+
+	if v, ok := p.GetNow(); !ok || v != large {
+		t.Error("expected GetNow to read the spilled value")
+	}
+
+	if r, ok := p.Snapshot(); !ok || r.Status != Fulfilled || r.Value != large {
+		t.Error("expected Snapshot to read the spilled value")
+	}
+}
+
+func TestWithSpillSmallValue(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New(func(resolve Resolve[string], reject Reject) {
+		resolve("small")
+	}, WithSpill(100, dir))
+
+	if v, err := p.Await(ctx); err != nil || v != "small" {
+		t.Fatalf("expected small, got %v, %v", v, err)
+	}
+
+	if files := spilledFiles(t, dir); len(files) != 0 {
+		t.Errorf("expected a small value to stay in memory, got %v", files)
+	}
+}
+
+func TestWithSpillMissingFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New(func(resolve Resolve[[]byte], reject Reject) {
+		resolve(make([]byte, 1024))
+	}, WithSpill(100, dir))
+	<-p.Done()
+
+	for _, file := range spilledFiles(t, dir) {
+		os.Remove(file)
+	}
+
+	if _, err := p.Await(ctx); err == nil {
+		t.Error("expected an error when the spilled value is gone")
+	}
+
+	if _, ok := p.GetNow(); ok {
+		t.Error("expected GetNow to report no value when the spilled value is gone")
+	}
+}
@@ -0,0 +1,44 @@
+package promises
+
+import "context"
+
+// NewResolved returns a promise that is already fulfilled with value, without running an executor or starting a goroutine.
+// The options apply as with New, except those about the executor.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/resolve
+func NewResolved[T any](value T, opts ...Option) *Promise[T] {
+	o := newOptions(opts)
+	p, _, _ := newPending[T](o)
+	p.fulfill(o, value, nil)
+	return p
+}
+
+// NewRejected returns a promise that is already rejected with reason, without running an executor or starting a goroutine.
+// The options apply as with New, except those about the executor.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/reject
+func NewRejected[T any](reason error, opts ...Option) *Promise[T] {
+	o := newOptions(opts)
+	p, _, _ := newPending[T](o)
+	p.reject(o, reason, nil)
+	return p
+}
+
+// FromFunc returns a promise of fn called with ctx, fulfilled with the value it returns or rejected with its error.
+// The options configure the promise as in New.
+func FromFunc[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) *Promise[T] {
+	return New(func(resolve Resolve[T], reject Reject) {
+		settleWith(resolve, reject)(fn(ctx))
+	}, opts...)
+}
+
+// Wrap turns a blocking call into one that returns a promise of its result. Each call runs fn with a context
+// created as in NewWithContext, so canceling the returned promise cancels the call. The options configure every
+// promise; use WithDisposer to release values, such as response bodies, that arrive after a promise was canceled.
+func Wrap[A, T any](fn func(ctx context.Context, arg A) (T, error), opts ...Option) func(ctx context.Context, arg A) *Promise[T] {
+	return func(ctx context.Context, arg A) *Promise[T] {
+		return NewWithContext(ctx, func(ctx context.Context, resolve Resolve[T], reject Reject) {
+			settleWith(resolve, reject)(fn(ctx, arg))
+		}, opts...)
+	}
+}
@@ -0,0 +1,76 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestNewResolved(t *testing.T) {
+	p := NewResolved(1)
+	if !p.IsFulfilled() {
+		t.Fatal("expected the promise to be fulfilled right away")
+	}
+
+	if v, err := p.Await(context.Background()); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestNewRejected(t *testing.T) {
+	cause := errors.New("failed")
+	p := NewRejected[int](cause)
+	if !p.IsRejected() {
+		t.Fatal("expected the promise to be rejected right away")
+	}
+
+	if _, err := p.Await(context.Background()); !errors.Is(err, cause) {
+		t.Errorf("expected the reason, got %v", err)
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, 2)
+	p := FromFunc(ctx, func(ctx context.Context) (int, error) {
+		return ctx.Value(key{}).(int), nil
+	})
+
+	if v, err := p.Await(ctx); err != nil || v != 2 {
+		t.Errorf("expected 2 from the context, got %v, %v", v, err)
+	}
+
+	cause := errors.New("failed")
+	failed := FromFunc(ctx, func(ctx context.Context) (int, error) {
+		return 0, cause
+	})
+	if _, err := failed.Await(ctx); !errors.Is(err, cause) {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+	double := Wrap(func(ctx context.Context, v int) (int, error) {
+		return v * 2, nil
+	})
+
+	if v, err := double(ctx, 21).Await(ctx); err != nil || v != 42 {
+		t.Errorf("expected 42, got %v, %v", v, err)
+	}
+
+	canceled := make(chan error, 1)
+	wait := Wrap(func(ctx context.Context, _ struct{}) (int, error) {
+		<-ctx.Done()
+		canceled <- context.Cause(ctx)
+		return 0, ctx.Err()
+	})
+
+	reason := errors.New("stop")
+	wait(ctx, struct{}{}).Cancel(reason)
+	if err := <-canceled; err != reason {
+		t.Errorf("expected the call to be canceled with stop, got %v", err)
+	}
+}
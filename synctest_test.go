@@ -0,0 +1,67 @@
+//go:build go1.25
+
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestSynctestAwaitTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		release := make(chan struct{})
+		p := New(func(resolve Resolve[int], reject Reject) {
+			<-release
+			resolve(1)
+		})
+
+		start := time.Now()
+		_, err := p.Await(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected error to be context.DeadlineExceeded, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed != time.Minute {
+			t.Errorf("expected elapsed to be 1m, got %v", elapsed)
+		}
+
+		close(release)
+		<-p.Done()
+	})
+}
+
+func TestSynctestAll(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := context.Background()
+		var promises []*Promise[int]
+		for i := 0; i < 10; i++ {
+			i := i
+			promises = append(promises, New(func(resolve Resolve[int], reject Reject) {
+				time.Sleep(time.Duration(i) * time.Hour)
+				resolve(i)
+			}))
+		}
+
+		start := time.Now()
+		v, err := All(ctx, promises...).Await(ctx)
+		if err != nil {
+			t.Errorf("expected error to be nil, got %v", err)
+		}
+
+		if len(v) != 10 {
+			t.Errorf("expected length to be 10, got %d", len(v))
+		}
+
+		if elapsed := time.Since(start); elapsed != 9*time.Hour {
+			t.Errorf("expected elapsed to be 9h, got %v", elapsed)
+		}
+	})
+}
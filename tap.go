@@ -0,0 +1,33 @@
+package promises
+
+import "context"
+
+// Tap returns a promise that settles with the same outcome as p after calling fn with the value if p is fulfilled.
+// fn observes the value for logging or metrics and cannot alter it. Like Then, it returns right away.
+// If ctx is done before p is settled, fn is not called and the promise is rejected with the context error.
+func (p *Promise[T]) Tap(ctx context.Context, fn func(T)) *Promise[T] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[T], reject Reject) {
+		if r.Status == Rejected {
+			reject(r.Reason)
+			return
+		}
+
+		fn(r.Value)
+		resolve(r.Value)
+	})
+}
+
+// TapErr returns a promise that settles with the same outcome as p after calling fn with the reason if p is rejected.
+// fn observes the reason for logging or metrics and cannot alter it. Like Then, it returns right away.
+// If ctx is done before p is settled, fn is not called and the promise is rejected with the context error.
+func (p *Promise[T]) TapErr(ctx context.Context, fn func(error)) *Promise[T] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[T], reject Reject) {
+		if r.Status == Rejected {
+			fn(r.Reason)
+			reject(r.Reason)
+			return
+		}
+
+		resolve(r.Value)
+	})
+}
@@ -0,0 +1,105 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestTap(t *testing.T) {
+	ctx := context.Background()
+	var tapped int
+	p := newResolved(1).Tap(ctx, func(v int) {
+		tapped = v
+	})
+
+	v, err := p.Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	if tapped != 1 {
+		t.Errorf("expected fn to be called with 1, got %d", tapped)
+	}
+}
+
+func TestTapRejected(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	called := false
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(reason)
+	}).Tap(ctx, func(int) {
+		called = true
+	})
+
+	if _, err := p.Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+
+	if called {
+		t.Error("expected fn not to be called")
+	}
+}
+
+func TestTapErr(t *testing.T) {
+	ctx := context.Background()
+	reason := errors.New("reason")
+	var tapped error
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(reason)
+	}).TapErr(ctx, func(err error) {
+		tapped = err
+	})
+
+	if _, err := p.Await(ctx); !errors.Is(err, reason) {
+		t.Errorf("expected reason, got %v", err)
+	}
+
+	if tapped != reason {
+		t.Errorf("expected fn to be called with reason, got %v", tapped)
+	}
+
+	v, err := newResolved(1).TapErr(ctx, func(error) {
+		t.Error("expected fn not to be called")
+	}).Await(ctx)
+	if err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestTapCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := New(func(resolve Resolve[int], reject Reject) {})
+
+	if _, err := p.Tap(ctx, func(int) {}).Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if _, err := p.TapErr(ctx, func(error) {}).Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTapEventLoop(t *testing.T) {
+	ctx := context.Background()
+	loop := NewEventLoop()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		resolve(1)
+	}, WithEventLoop(loop))
+
+	var got []int
+	p.Tap(ctx, func(v int) {
+		got = append(got, v)
+	}).OnSettle(func(r SettledResult[int]) {
+		got = append(got, r.Value+1)
+	})
+
+	loop.RunUntilIdle()
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("expected fn and the callback of the tapped promise to run on the loop, got %v", got)
+	}
+}
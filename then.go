@@ -0,0 +1,190 @@
+package promises
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	errNilPromise = errors.New("nil promise")
+)
+
+// Then returns a promise of fn applied to the value of p. It returns right away: fn is called asynchronously,
+// like the callbacks of OnSettle, once p is fulfilled. If p is rejected, fn is not called and the returned promise
+// is rejected with the same reason. If ctx is done before p settles, fn is not called and the returned promise
+// is rejected with the context error.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/then
+func (p *Promise[T]) Then(ctx context.Context, fn func(T) (T, error)) *Promise[T] {
+	return Then(ctx, p, fn)
+}
+
+// Catch returns a promise of fn applied to the reason p is rejected with, so that fn can recover with a value.
+// If p is fulfilled, fn is not called and the returned promise is fulfilled with the same value.
+// Like Then, it returns right away and honors ctx.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/catch
+func (p *Promise[T]) Catch(ctx context.Context, fn func(error) (T, error)) *Promise[T] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[T], reject Reject) {
+		if r.Status == Fulfilled {
+			resolve(r.Value)
+			return
+		}
+
+		settleWith(resolve, reject)(fn(r.Reason))
+	})
+}
+
+// Finally returns a promise that settles like p once fn has been called, whatever the outcome of p.
+// Like Then, it returns right away and honors ctx.
+//
+// Reference: https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Promise/finally
+func (p *Promise[T]) Finally(ctx context.Context, fn func()) *Promise[T] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[T], reject Reject) {
+		fn()
+		if r.Status == Rejected {
+			reject(r.Reason)
+			return
+		}
+
+		resolve(r.Value)
+	})
+}
+
+// Recover is the method Catch as a function, to turn a rejection of p back into a fulfillment with the value fn returns.
+func Recover[T any](ctx context.Context, p *Promise[T], fn func(error) (T, error)) *Promise[T] {
+	return p.Catch(ctx, fn)
+}
+
+// CatchIs is Catch for the rejections of p whose reason matches target with errors.Is.
+// Other rejections pass through without calling fn, so that handlers for different failures can be chained.
+func CatchIs[T any](ctx context.Context, p *Promise[T], target error, fn func(error) (T, error)) *Promise[T] {
+	return p.Catch(ctx, func(err error) (T, error) {
+		if !errors.Is(err, target) {
+			var zero T
+			return zero, err
+		}
+
+		return fn(err)
+	})
+}
+
+// CatchAs is Catch for the rejections of p whose reason has an error of type E in its chain, as found by errors.As,
+// which fn is called with. Other rejections pass through without calling fn, as with CatchIs.
+func CatchAs[T any, E error](ctx context.Context, p *Promise[T], fn func(E) (T, error)) *Promise[T] {
+	return p.Catch(ctx, func(err error) (T, error) {
+		var target E
+		if !errors.As(err, &target) {
+			var zero T
+			return zero, err
+		}
+
+		return fn(target)
+	})
+}
+
+// Then returns a promise of fn applied to the value of p, which unlike the method Then can be of another type,
+// so that steps such as fetch, parse and store chain into one promise. The value fn returns fulfills the promise
+// and the error it returns rejects it. Rejections of p and ctx are handled as by the method Then.
+func Then[T, U any](ctx context.Context, p *Promise[T], fn func(T) (U, error)) *Promise[U] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[U], reject Reject) {
+		if r.Status == Rejected {
+			reject(r.Reason)
+			return
+		}
+
+		settleWith(resolve, reject)(fn(r.Value))
+	})
+}
+
+// ThenPromise is Then for a fn that starts more asynchronous work: the promise fn returns is flattened,
+// so the returned promise settles with its outcome instead of being fulfilled with it.
+// If ctx is done before that promise settles, the returned promise is rejected with the context error.
+func ThenPromise[T, U any](ctx context.Context, p *Promise[T], fn func(T) (*Promise[U], error)) *Promise[U] {
+	return derive(ctx, p, func(r SettledResult[T], resolve Resolve[U], reject Reject) {
+		if r.Status == Rejected {
+			reject(r.Reason)
+			return
+		}
+
+		next, err := fn(r.Value)
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		if next == nil {
+			reject(errNilPromise)
+			return
+		}
+
+		stop := context.AfterFunc(ctx, func() {
+			reject(ctx.Err())
+		})
+
+		next.OnSettle(func(r SettledResult[U]) {
+			if !stop() {
+				return
+			}
+
+			if r.Status == Rejected {
+				reject(r.Reason)
+				return
+			}
+
+			resolve(r.Value)
+		})
+	})
+}
+
+// Then is Then of the promise.
+func (f Future[T]) Then(ctx context.Context, fn func(T) (T, error)) *Promise[T] {
+	return f.p.Then(ctx, fn)
+}
+
+// derive returns a promise settled by settle with the outcome of p, called from a callback of p.
+// If ctx is done first, the promise is rejected with the context error and settle is not called.
+// If settle panics, the promise is rejected with a *PanicError. Canceling the promise releases p, see consume.
+// Its callbacks run like those of p, see derivedOptions.
+func derive[T, U any](ctx context.Context, p *Promise[T], settle func(r SettledResult[T], resolve Resolve[U], reject Reject)) *Promise[U] {
+	d, resolve, reject := newPending[U](derivedOptions(p.target().opts))
+	d.upstream = p.consume()
+	link(d.info, p.info)
+
+	stop := context.AfterFunc(ctx, func() {
+		reject(ctx.Err())
+	})
+
+	p.OnSettle(func(r SettledResult[T]) {
+		if !stop() {
+			return
+		}
+
+		defer recoverTo(d.info, reject)
+		settle(r, resolve, reject)
+	})
+
+	return d
+}
+
+// derivedOptions returns the options of o that promises derived from its promise keep,
+// which are those about where and in which order their callbacks run.
+func derivedOptions(o *options) *options {
+	if o == nil || (o.loop == nil && !o.orderedCallbacks) {
+		return nil
+	}
+
+	return &options{loop: o.loop, orderedCallbacks: o.orderedCallbacks}
+}
+
+// settleWith returns a function that settles a promise with a value and an error, as returned by a callback.
+func settleWith[T any](resolve Resolve[T], reject Reject) func(T, error) {
+	return func(v T, err error) {
+		if err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(v)
+	}
+}
@@ -0,0 +1,259 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestThenDoesNotBlock(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	p := blocked(block)
+
+	// Then returns while p is still pending.
+	q := p.Then(ctx, func(v int) (int, error) {
+		return v + 1, nil
+	}).Then(ctx, func(v int) (int, error) {
+		return v * 10, nil
+	})
+
+	if q.State() != Pending {
+		t.Fatalf("expected the chain to be pending, got %v", q.State())
+	}
+
+	close(block)
+	if v, err := q.Await(ctx); err != nil || v != 20 {
+		t.Errorf("expected 20, got %v, %v", v, err)
+	}
+}
+
+func TestThenRejected(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	called := false
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(cause)
+	})
+
+	_, err := p.Then(ctx, func(v int) (int, error) {
+		called = true
+		return v, nil
+	}).Await(ctx)
+	if !errors.Is(err, cause) || called {
+		t.Errorf("expected the rejection to pass through without calling fn, got %v, %v", err, called)
+	}
+
+	_, err = newResolved(1).Then(ctx, func(v int) (int, error) {
+		return 0, cause
+	}).Await(ctx)
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+}
+
+func TestThenPanic(t *testing.T) {
+	ctx := context.Background()
+	_, err := newResolved(1).Then(ctx, func(v int) (int, error) {
+		panic("boom")
+	}).Catch(ctx, func(err error) (int, error) {
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Errorf("expected *PanicError, got %v", err)
+		}
+
+		panic(err)
+	}).Await(ctx)
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected *PanicError, got %v", err)
+	}
+
+	if inner, ok := panicErr.Value.(*PanicError); !ok || inner.Value != "boom" {
+		t.Errorf("expected the panic of Catch to wrap the panic of Then, got %v", panicErr.Value)
+	}
+}
+
+func TestCatch(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(errors.New("failed"))
+	})
+
+	v, err := p.Catch(ctx, func(err error) (int, error) {
+		return -1, nil
+	}).Await(ctx)
+	if err != nil || v != -1 {
+		t.Errorf("expected to recover with -1, got %v, %v", v, err)
+	}
+
+	v, err = newResolved(2).Catch(ctx, func(err error) (int, error) {
+		return -1, nil
+	}).Await(ctx)
+	if err != nil || v != 2 {
+		t.Errorf("expected the value to pass through, got %v, %v", v, err)
+	}
+}
+
+func TestCatchIs(t *testing.T) {
+	ctx := context.Background()
+	notFound := errors.New("not found")
+	other := errors.New("other")
+	fallback := func(err error) (int, error) {
+		return -1, nil
+	}
+
+	if v, err := CatchIs(ctx, NewRejected[int](fmt.Errorf("user: %w", notFound)), notFound, fallback).Await(ctx); err != nil || v != -1 {
+		t.Errorf("expected the matching rejection to recover, got %v, %v", v, err)
+	}
+
+	if _, err := CatchIs(ctx, NewRejected[int](other), notFound, fallback).Await(ctx); err != other {
+		t.Errorf("expected the other rejection to pass through, got %v", err)
+	}
+
+	if v, err := Recover(ctx, NewRejected[int](other), fallback).Await(ctx); err != nil || v != -1 {
+		t.Errorf("expected Recover to recover any rejection, got %v, %v", v, err)
+	}
+}
+
+func TestCatchAs(t *testing.T) {
+	ctx := context.Background()
+	p := New(func(resolve Resolve[int], reject Reject) {
+		panic("boom")
+	})
+
+	chained := CatchIs(ctx, p, context.Canceled, func(err error) (int, error) {
+		t.Error("expected the handler of another failure not to be called")
+		return 0, nil
+	})
+
+	v, err := CatchAs(ctx, chained, func(err *PanicError) (int, error) {
+		return len(err.Stack), nil
+	}).Await(ctx)
+	if err != nil || v == 0 {
+		t.Errorf("expected the panic to be recovered with its stack, got %v, %v", v, err)
+	}
+}
+
+func TestFinally(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("failed")
+	calls := make(chan struct{}, 2)
+	finally := func() {
+		calls <- struct{}{}
+	}
+
+	if v, err := newResolved(3).Finally(ctx, finally).Await(ctx); err != nil || v != 3 {
+		t.Errorf("expected 3, got %v, %v", v, err)
+	}
+
+	p := New(func(resolve Resolve[int], reject Reject) {
+		reject(cause)
+	})
+	if _, err := p.Finally(ctx, finally).Await(ctx); !errors.Is(err, cause) {
+		t.Errorf("expected the rejection to pass through, got %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("expected fn to be called twice, got %d", len(calls))
+	}
+}
+
+func TestThenContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	called := make(chan struct{}, 1)
+	q := blocked(block).Then(ctx, func(v int) (int, error) {
+		called <- struct{}{}
+		return v, nil
+	})
+
+	cancel()
+	if _, err := q.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if len(called) != 0 {
+		t.Error("expected fn not to be called after ctx is done")
+	}
+}
+
+func TestFutureThen(t *testing.T) {
+	ctx := context.Background()
+	v, err := newResolved(4).Future().Then(ctx, func(v int) (int, error) {
+		return v * 2, nil
+	}).Await(ctx)
+	if err != nil || v != 8 {
+		t.Errorf("expected 8, got %v, %v", v, err)
+	}
+}
+
+func TestThenToAnotherType(t *testing.T) {
+	ctx := context.Background()
+	fetched := New(func(resolve Resolve[string], reject Reject) {
+		resolve("42")
+	})
+
+	parsed := Then(ctx, fetched, strconv.Atoi)
+	stored := Then(ctx, parsed, func(v int) (bool, error) {
+		return v == 42, nil
+	})
+
+	if ok, err := stored.Await(ctx); err != nil || !ok {
+		t.Errorf("expected true, got %v, %v", ok, err)
+	}
+
+	bad := New(func(resolve Resolve[string], reject Reject) {
+		resolve("not a number")
+	})
+	if _, err := Then(ctx, bad, strconv.Atoi).Await(ctx); err == nil {
+		t.Error("expected the parse error to reject the promise")
+	}
+}
+
+func TestThenPromise(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	q := ThenPromise(ctx, newResolved(1), func(v int) (*Promise[string], error) {
+		return Then(ctx, blocked(block), func(w int) (string, error) {
+			return strconv.Itoa(v + w), nil
+		}), nil
+	})
+
+	close(block)
+	if v, err := q.Await(ctx); err != nil || v != "2" {
+		t.Errorf("expected the flattened value 2, got %v, %v", v, err)
+	}
+
+	nilPromise := ThenPromise(ctx, newResolved(1), func(v int) (*Promise[string], error) {
+		return nil, nil
+	})
+	if _, err := nilPromise.Await(ctx); err == nil {
+		t.Error("expected a nil promise to reject")
+	}
+}
+
+func TestThenPromiseContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	q := ThenPromise(ctx, newResolved(1), func(v int) (*Promise[int], error) {
+		close(started)
+		return blocked(block), nil
+	})
+
+	<-started
+	cancel()
+	if _, err := q.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
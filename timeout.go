@@ -0,0 +1,33 @@
+package promises
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout is Timeout of the promise.
+func (p *Promise[T]) WithTimeout(d time.Duration) *Promise[T] {
+	return Timeout(p, d)
+}
+
+// Timeout returns a promise that settles like p, or is rejected with ErrTimeout if p does not settle within d
+// on the package clock. On timeout, if p was created with WithConsumerCancel, it is canceled with ErrTimeout as the cause
+// unless other promises derived from it are still pending, so that the work behind p is stopped, not just abandoned.
+// Otherwise p keeps running for its other callers.
+func Timeout[T any](p *Promise[T], d time.Duration) *Promise[T] {
+	t := derive(context.Background(), p, settleLike[T])
+
+	timer := CurrentClock().NewTimer(d)
+	go func() {
+		select {
+		case <-timer.C():
+			if t.reject(nil, ErrTimeout, nil) && t.upstream != nil {
+				t.upstream(ErrTimeout)
+			}
+		case <-t.doneChan():
+			timer.Stop()
+		}
+	}()
+
+	return t
+}
@@ -0,0 +1,96 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestTimeout(t *testing.T) {
+	ctx := context.Background()
+	aborted := make(chan error, 1)
+	p := NewWithContext(ctx, func(ctx context.Context, resolve Resolve[int], reject Reject) {
+		<-ctx.Done()
+		aborted <- context.Cause(ctx)
+	}, WithConsumerCancel())
+
+	_, err := p.WithTimeout(time.Millisecond).Await(ctx)
+	if err != ErrTimeout {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	select {
+	case cause := <-aborted:
+		if cause != ErrTimeout {
+			t.Errorf("expected the work to be canceled with ErrTimeout, got %v", cause)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the work to be stopped")
+	}
+}
+
+func TestTimeoutSettled(t *testing.T) {
+	ctx := context.Background()
+	if v, err := Timeout(newResolved(1), time.Hour).Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+
+	cause := errors.New("failed")
+	if _, err := Timeout(NewRejected[int](cause), time.Hour).Await(ctx); err != cause {
+		t.Errorf("expected the reason of the promise, got %v", err)
+	}
+}
+
+func TestTimeoutSharedSource(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	p := blocked(block)
+	other := p.Then(ctx, func(v int) (int, error) {
+		return v, nil
+	})
+
+	if _, err := Timeout(p, time.Millisecond).Await(ctx); err != ErrTimeout {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	if p.IsSettled() {
+		t.Fatal("expected the source to keep running for its other consumer")
+	}
+
+	close(block)
+	if v, err := other.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected 1, got %v, %v", v, err)
+	}
+}
+
+func TestDelayCancel(t *testing.T) {
+	ctx := context.Background()
+	p := Delay(time.Hour)
+	p.Cancel(nil)
+	if _, err := p.Await(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the delay to be canceled, got %v", err)
+	}
+}
+
+func TestTimeoutKeepsSourceForOthers(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	p := blocked(block)
+
+	if _, err := Timeout(p, time.Millisecond).Await(ctx); err != ErrTimeout {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+
+	all := All(ctx, p)
+	close(block)
+	if v, err := p.Await(ctx); err != nil || v != 1 {
+		t.Errorf("expected the source to be fulfilled with 1, got %v, %v", v, err)
+	}
+
+	if v, err := all.Await(ctx); err != nil || len(v) != 1 || v[0] != 1 {
+		t.Errorf("expected All to be fulfilled with [1], got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,208 @@
+package promises
+
+import (
+	"sync"
+	"time"
+)
+
+// TimingWheel is a Clock whose timers share one hierarchical timing wheel driven by a single ticker,
+// instead of each being a runtime timer. With many concurrent timeouts, delays and backoffs, it avoids
+// contention on the runtime timer heap at the cost of resolution: timers fire on the first tick at or after their
+// duration, so up to one tick late. Install it with SetClock to back every timing feature of the package.
+type TimingWheel struct {
+	tick     time.Duration
+	slots    uint64
+	levels   [][]wheelBucket
+	overflow wheelBucket
+	current  uint64
+	stop     chan struct{}
+	once     sync.Once
+	mutex    sync.Mutex
+}
+
+// wheelBucket is an intrusive doubly linked list of timers, so that stopped timers are unlinked right away.
+type wheelBucket struct {
+	head *wheelTimer
+}
+
+type wheelTimer struct {
+	wheel      *TimingWheel
+	due        uint64
+	c          chan time.Time
+	bucket     *wheelBucket
+	prev, next *wheelTimer
+}
+
+const wheelLevels = 4
+
+// NewTimingWheel starts a timing wheel that advances every tick, with slots buckets per level.
+// Four levels of slots buckets cover tick*slots^4, such as about 4.6 hours for 1ms and 64 slots;
+// longer timers wait in an overflow list. Call Stop when it is no longer used.
+func NewTimingWheel(tick time.Duration, slots int) *TimingWheel {
+	if tick <= 0 || slots < 2 {
+		panic("promises: timing wheel needs a positive tick and at least 2 slots")
+	}
+
+	w := &TimingWheel{tick: tick, slots: uint64(slots), levels: make([][]wheelBucket, wheelLevels), stop: make(chan struct{})}
+	for i := range w.levels {
+		w.levels[i] = make([]wheelBucket, slots)
+	}
+
+	go w.run()
+	return w
+}
+
+// Now returns the current time.
+func (w *TimingWheel) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a timer that fires on the wheel once d has elapsed, rounded up to whole ticks.
+func (w *TimingWheel) NewTimer(d time.Duration) Timer {
+	t := &wheelTimer{wheel: w, c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- time.Now()
+		return t
+	}
+
+	ticks := uint64((d + w.tick - 1) / w.tick)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	t.due = w.current + ticks
+	w.insert(t)
+	return t
+}
+
+// Stop stops the wheel. Timers that have not fired by then never fire.
+func (w *TimingWheel) Stop() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (t *wheelTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *wheelTimer) Stop() bool {
+	w := t.wheel
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if t.bucket == nil {
+		return false
+	}
+
+	t.bucket.remove(t)
+	return true
+}
+
+func (w *TimingWheel) run() {
+	ticker := time.NewTicker(w.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			w.advance(now)
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// advance moves the wheel one tick forward, cascading timers down from the higher levels as their buckets come up,
+// and fires the timers that are due.
+func (w *TimingWheel) advance(now time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.current++
+
+	// Find the highest level whose bucket comes up on this tick, and cascade from there down,
+	// so that timers moved down land in buckets that are still ahead.
+	top := 0
+	for top < wheelLevels && w.current%w.span(top+1) == 0 {
+		top++
+	}
+
+	if top == wheelLevels {
+		w.cascade(&w.overflow)
+	}
+
+	for level := min(top, wheelLevels-1); level > 0; level-- {
+		w.cascade(&w.levels[level][(w.current/w.span(level))%w.slots])
+	}
+
+	bucket := &w.levels[0][w.current%w.slots]
+	for t := bucket.head; t != nil; {
+		next := t.next
+		if t.due <= w.current {
+			bucket.remove(t)
+			t.c <- now
+		}
+
+		t = next
+	}
+}
+
+// span returns the number of ticks a bucket of the level covers.
+func (w *TimingWheel) span(level int) uint64 {
+	span := uint64(1)
+	for i := 0; i < level; i++ {
+		span *= w.slots
+	}
+
+	return span
+}
+
+// cascade takes the timers out of the bucket and inserts them again relative to the current tick.
+func (w *TimingWheel) cascade(bucket *wheelBucket) {
+	for t := bucket.head; t != nil; {
+		next := t.next
+		bucket.remove(t)
+		w.insert(t)
+		t = next
+	}
+}
+
+// insert puts the timer in the lowest level whose range covers its due tick. Timers are never behind the wheel:
+// new ones are due after the current tick, and cascaded ones that are due on it land in the bucket about to fire.
+func (w *TimingWheel) insert(t *wheelTimer) {
+	due := t.due
+	for level := 0; level < wheelLevels; level++ {
+		if span := w.span(level); due-w.current < span*w.slots {
+			w.levels[level][(due/span)%w.slots].add(t)
+			return
+		}
+	}
+
+	w.overflow.add(t)
+}
+
+func (b *wheelBucket) add(t *wheelTimer) {
+	t.bucket = b
+	t.prev = nil
+	t.next = b.head
+	if b.head != nil {
+		b.head.prev = t
+	}
+
+	b.head = t
+}
+
+func (b *wheelBucket) remove(t *wheelTimer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		b.head = t.next
+	}
+
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+
+	t.bucket, t.prev, t.next = nil, nil, nil
+}
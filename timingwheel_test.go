@@ -0,0 +1,101 @@
+package promises_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func fired(timer Timer) bool {
+	select {
+	case <-timer.C():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTimingWheel(t *testing.T) {
+	// The ticker never ticks during the test; the wheel is advanced by hand. 4 slots make every level and the overflow list count.
+	w := NewTimingWheel(time.Hour, 4)
+	defer w.Stop()
+
+	// The wheel starts at a tick that is not a multiple of any level, so timers straddle bucket boundaries.
+	for i := 0; i < 7; i++ {
+		AdvanceWheel(w)
+	}
+
+	durations := make([]int, 700)
+	timers := make([]Timer, len(durations))
+	for i := range durations {
+		durations[i] = i + 1
+		timers[i] = w.NewTimer(time.Duration(durations[i]) * time.Hour)
+	}
+
+	for tick := 1; tick <= 700; tick++ {
+		AdvanceWheel(w)
+		for i, d := range durations {
+			if timers[i] == nil {
+				continue
+			}
+
+			if fired(timers[i]) {
+				if tick != d {
+					t.Errorf("expected the %d-tick timer to fire at tick %d, fired at %d", d, d, tick)
+				}
+
+				timers[i] = nil
+			} else if tick >= d {
+				t.Errorf("expected the %d-tick timer to have fired at tick %d", d, tick)
+				timers[i] = nil
+			}
+		}
+	}
+}
+
+func TestTimingWheelStop(t *testing.T) {
+	w := NewTimingWheel(time.Hour, 4)
+	defer w.Stop()
+
+	timer := w.NewTimer(2 * time.Hour)
+	if !timer.Stop() {
+		t.Error("expected a pending timer to stop")
+	}
+
+	if timer.Stop() {
+		t.Error("expected a stopped timer not to stop again")
+	}
+
+	AdvanceWheel(w)
+	AdvanceWheel(w)
+	if fired(timer) {
+		t.Error("expected a stopped timer not to fire")
+	}
+
+	if !fired(w.NewTimer(0)) {
+		t.Error("expected a zero duration timer to fire right away")
+	}
+}
+
+func TestTimingWheelClock(t *testing.T) {
+	ctx := context.Background()
+	w := NewTimingWheel(time.Millisecond, 64)
+	defer w.Stop()
+	defer SetClock(w)()
+
+	start := time.Now()
+	if _, err := Delay(5 * time.Millisecond).Await(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("expected the delay to last at least 5ms, got %v", elapsed)
+	}
+
+	_, err := New(func(resolve Resolve[int], reject Reject) {}, WithTimeout(5*time.Millisecond)).Await(ctx)
+	if err != ErrTimeout {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package promises
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	inFlight      = make(map[uint64]Info)
+	inFlightMutex sync.Mutex
+)
+
+// InFlight returns the pending promises created with WithTracking, oldest first.
+func InFlight() []Info {
+	inFlightMutex.Lock()
+	infos := make([]Info, 0, len(inFlight))
+	for _, info := range inFlight {
+		infos = append(infos, info)
+	}
+	inFlightMutex.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+func track(info Info) {
+	inFlightMutex.Lock()
+	defer inFlightMutex.Unlock()
+
+	inFlight[info.ID] = info
+}
+
+func untrack(id uint64) {
+	inFlightMutex.Lock()
+	defer inFlightMutex.Unlock()
+
+	delete(inFlight, id)
+	delete(parents, id)
+}
@@ -0,0 +1,76 @@
+package promises
+
+import "context"
+
+// Tuple2 holds the values of two promises of different types, as combined by All2.
+type Tuple2[A, B any] struct {
+	A A
+	B B
+}
+
+// Tuple3 is Tuple2 for three values.
+type Tuple3[A, B, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// Tuple4 is Tuple2 for four values.
+type Tuple4[A, B, C, D any] struct {
+	A A
+	B B
+	C C
+	D D
+}
+
+// All2 is All for two promises of different types: it returns a promise of their values,
+// rejected with the reason of the first promise to be rejected, without waiting for the other.
+func All2[A, B any](ctx context.Context, pa *Promise[A], pb *Promise[B]) *Promise[Tuple2[A, B]] {
+	p := New(func(resolve Resolve[Tuple2[A, B]], reject Reject) {
+		if err := awaitAll(ctx, pa, pb); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(Tuple2[A, B]{pa.Value(), pb.Value()})
+	})
+
+	link(p.info, pa.info)
+	link(p.info, pb.info)
+	return p
+}
+
+// All3 is All2 for three promises.
+func All3[A, B, C any](ctx context.Context, pa *Promise[A], pb *Promise[B], pc *Promise[C]) *Promise[Tuple3[A, B, C]] {
+	p := New(func(resolve Resolve[Tuple3[A, B, C]], reject Reject) {
+		if err := awaitAll(ctx, pa, pb, pc); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(Tuple3[A, B, C]{pa.Value(), pb.Value(), pc.Value()})
+	})
+
+	link(p.info, pa.info)
+	link(p.info, pb.info)
+	link(p.info, pc.info)
+	return p
+}
+
+// All4 is All2 for four promises.
+func All4[A, B, C, D any](ctx context.Context, pa *Promise[A], pb *Promise[B], pc *Promise[C], pd *Promise[D]) *Promise[Tuple4[A, B, C, D]] {
+	p := New(func(resolve Resolve[Tuple4[A, B, C, D]], reject Reject) {
+		if err := awaitAll(ctx, pa, pb, pc, pd); err != nil {
+			reject(err)
+			return
+		}
+
+		resolve(Tuple4[A, B, C, D]{pa.Value(), pb.Value(), pc.Value(), pd.Value()})
+	})
+
+	link(p.info, pa.info)
+	link(p.info, pb.info)
+	link(p.info, pc.info)
+	link(p.info, pd.info)
+	return p
+}
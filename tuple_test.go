@@ -0,0 +1,37 @@
+package promises_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestAll2(t *testing.T) {
+	ctx := context.Background()
+	v, err := All2(ctx, newResolved(1), NewResolved("a")).Await(ctx)
+	if err != nil || v.A != 1 || v.B != "a" {
+		t.Errorf("expected {1 a}, got %v, %v", v, err)
+	}
+}
+
+func TestAll3(t *testing.T) {
+	ctx := context.Background()
+	v, err := All3(ctx, newResolved(1), NewResolved("a"), NewResolved(true)).Await(ctx)
+	if err != nil || v.A != 1 || v.B != "a" || !v.C {
+		t.Errorf("expected {1 a true}, got %v, %v", v, err)
+	}
+}
+
+func TestAll4Rejected(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	defer close(block)
+
+	cause := errors.New("failed")
+	_, err := All4(ctx, blocked(block), NewResolved("a"), NewRejected[bool](cause), NewResolved(1.5)).Await(ctx)
+	if err != cause {
+		t.Errorf("expected the reason without waiting for the pending promise, got %v", err)
+	}
+}
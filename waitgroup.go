@@ -0,0 +1,21 @@
+package promises
+
+import "sync"
+
+// FromWaitGroup returns a promise that is fulfilled when wg.Wait would return.
+// It waits on its own goroutine, which stays blocked for as long as the wait group does.
+func FromWaitGroup(wg *sync.WaitGroup) *Promise[struct{}] {
+	return New(func(resolve Resolve[struct{}], reject Reject) {
+		wg.Wait()
+		resolve(struct{}{})
+	})
+}
+
+// AddToWaitGroup adds the promise to wg, which is done once the promise is settled, whatever its outcome.
+func (p *Promise[T]) AddToWaitGroup(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-p.Done()
+	}()
+}
@@ -0,0 +1,53 @@
+package promises_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/oneofthezombies/promises"
+)
+
+func TestFromWaitGroup(t *testing.T) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p := FromWaitGroup(&wg)
+
+	select {
+	case <-p.Done():
+		t.Fatal("expected the promise to wait for the group")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	wg.Done()
+	if _, err := p.Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+}
+
+func TestAddToWaitGroup(t *testing.T) {
+	var wg sync.WaitGroup
+	block := make(chan struct{})
+	p := blocked(block)
+	p.AddToWaitGroup(&wg)
+
+	waited := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("expected the group to wait for the promise")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(block)
+	<-waited
+	if !p.IsSettled() {
+		t.Error("expected the promise to be settled once the group is done")
+	}
+}
@@ -0,0 +1,242 @@
+// Package workflow runs a graph of dependent steps with as much parallelism as the dependencies allow.
+//
+// Each node of a workflow declares the nodes it depends on and receives their values as inputs.
+// Running the workflow returns a promise per node and a promise for the whole graph.
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oneofthezombies/promises"
+)
+
+var (
+	// ErrCycle is wrapped by the reason a run is rejected with when the dependencies form a cycle.
+	ErrCycle = errors.New("workflow: dependency cycle")
+
+	// ErrUnknownNode is wrapped by the reason a run is rejected with when a node depends on a node that does not exist.
+	ErrUnknownNode = errors.New("workflow: unknown node")
+
+	// ErrDuplicateNode is wrapped by the reason a run is rejected with when two nodes have the same name.
+	ErrDuplicateNode = errors.New("workflow: duplicate node")
+
+	// ErrNoNode is the reason Result rejects with when the run has no node of the given name.
+	ErrNoNode = errors.New("workflow: no such node")
+)
+
+// Func is the work of a node. It receives the values of the nodes it depends on.
+type Func func(ctx context.Context, in Inputs) (any, error)
+
+// Inputs holds the values of the dependencies of a node by name.
+type Inputs map[string]any
+
+// Input returns the value of the dependency as a T, or false if there is no such dependency or it is not a T.
+func Input[T any](in Inputs, name string) (T, bool) {
+	v, ok := in[name].(T)
+	return v, ok
+}
+
+// NodeOption configures a node.
+type NodeOption func(*node)
+
+// DependsOn makes the node wait for the named nodes and receive their values.
+func DependsOn(names ...string) NodeOption {
+	return func(n *node) {
+		n.deps = append(n.deps, names...)
+	}
+}
+
+type node struct {
+	name string
+	fn   Func
+	deps []string
+}
+
+// Workflow is a graph of nodes. It is built with New and Node and started with Run.
+type Workflow struct {
+	nodes []*node
+}
+
+// New creates an empty workflow.
+func New() *Workflow {
+	return &Workflow{}
+}
+
+// Node adds a node and returns the workflow for chaining.
+// Problems with the graph, such as unknown dependencies, are reported when it is run.
+func (w *Workflow) Node(name string, fn Func, opts ...NodeOption) *Workflow {
+	n := &node{name: name, fn: fn}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	w.nodes = append(w.nodes, n)
+	return w
+}
+
+// Run is a running workflow.
+type Run struct {
+	names    []string
+	promises map[string]*promises.Promise[any]
+	all      *promises.Promise[map[string]any]
+}
+
+// Run starts every node as soon as its dependencies are fulfilled.
+// A node whose dependency is rejected is not run and is rejected with the same reason.
+// If the graph is invalid, no node is run and every promise of the run is rejected.
+func (w *Workflow) Run(ctx context.Context) *Run {
+	r := &Run{promises: make(map[string]*promises.Promise[any], len(w.nodes))}
+	for _, n := range w.nodes {
+		r.names = append(r.names, n.name)
+	}
+
+	order, err := w.sort()
+	if err != nil {
+		r.all = promises.New(func(resolve promises.Resolve[map[string]any], reject promises.Reject) {
+			reject(err)
+		})
+
+		for _, n := range w.nodes {
+			r.promises[n.name] = promises.New(func(resolve promises.Resolve[any], reject promises.Reject) {
+				reject(err)
+			})
+		}
+
+		return r
+	}
+
+	for _, n := range order {
+		r.promises[n.name] = r.start(ctx, n)
+	}
+
+	r.all = promises.New(func(resolve promises.Resolve[map[string]any], reject promises.Reject) {
+		results := make(map[string]any, len(r.names))
+		var first error
+		for _, name := range r.names {
+			v, err := r.promises[name].Await(ctx)
+			if err != nil && first == nil {
+				first = err
+			}
+
+			results[name] = v
+		}
+
+		if first != nil {
+			reject(first)
+			return
+		}
+
+		resolve(results)
+	})
+
+	return r
+}
+
+// start runs the node once its dependencies, which are already started, are fulfilled.
+func (r *Run) start(ctx context.Context, n *node) *promises.Promise[any] {
+	deps := make([]*promises.Promise[any], len(n.deps))
+	for i, dep := range n.deps {
+		deps[i] = r.promises[dep]
+	}
+
+	return promises.New(func(resolve promises.Resolve[any], reject promises.Reject) {
+		in := make(Inputs, len(deps))
+		for i, dep := range deps {
+			v, err := dep.Await(ctx)
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			in[n.deps[i]] = v
+		}
+
+		v, err := n.fn(ctx, in)
+		if err != nil {
+			reject(fmt.Errorf("workflow node %q: %w", n.name, err))
+			return
+		}
+
+		resolve(v)
+	})
+}
+
+// Node returns the promise of the named node, or nil if there is no such node.
+func (r *Run) Node(name string) *promises.Promise[any] {
+	return r.promises[name]
+}
+
+// Promise returns a promise that is fulfilled with the values of all nodes by name once every node is settled,
+// or rejected with the reason of the first rejected node in the order the nodes were added.
+func (r *Run) Promise() *promises.Promise[map[string]any] {
+	return r.all
+}
+
+// Result returns the promise of the named node with its value asserted to T.
+func Result[T any](r *Run, name string) *promises.Promise[T] {
+	p := r.Node(name)
+	if p == nil {
+		return promises.New(func(resolve promises.Resolve[T], reject promises.Reject) {
+			reject(fmt.Errorf("%w: %q", ErrNoNode, name))
+		})
+	}
+
+	return promises.Cast[T](p)
+}
+
+// sort returns the nodes so that every node comes after its dependencies,
+// keeping the order in which they were added where possible.
+func (w *Workflow) sort() ([]*node, error) {
+	byName := make(map[string]*node, len(w.nodes))
+	for _, n := range w.nodes {
+		if _, ok := byName[n.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateNode, n.name)
+		}
+
+		byName[n.name] = n
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(w.nodes))
+	order := make([]*node, 0, len(w.nodes))
+	var visit func(n *node, path []string) error
+	visit = func(n *node, path []string) error {
+		switch state[n.name] {
+		case visiting:
+			return fmt.Errorf("%w: %v", ErrCycle, append(path, n.name))
+		case visited:
+			return nil
+		}
+
+		state[n.name] = visiting
+		for _, dep := range n.deps {
+			d, ok := byName[dep]
+			if !ok {
+				return fmt.Errorf("%w: %q depends on %q", ErrUnknownNode, n.name, dep)
+			}
+
+			if err := visit(d, append(path, n.name)); err != nil {
+				return err
+			}
+		}
+
+		state[n.name] = visited
+		order = append(order, n)
+		return nil
+	}
+
+	for _, n := range w.nodes {
+		if err := visit(n, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,137 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oneofthezombies/promises/workflow"
+)
+
+func value(v any) workflow.Func {
+	return func(ctx context.Context, in workflow.Inputs) (any, error) {
+		return v, nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	wf := workflow.New().
+		Node("c", func(ctx context.Context, in workflow.Inputs) (any, error) {
+			a, _ := workflow.Input[int](in, "a")
+			b, _ := workflow.Input[int](in, "b")
+			return a + b, nil
+		}, workflow.DependsOn("a", "b")).
+		Node("a", value(1)).
+		Node("b", value(2))
+
+	run := wf.Run(ctx)
+	c, err := workflow.Result[int](run, "c").Await(ctx)
+	if err != nil || c != 3 {
+		t.Errorf("expected 3, got %v, %v", c, err)
+	}
+
+	results, err := run.Promise().Await(ctx)
+	if err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if len(results) != 3 || results["a"] != 1 || results["c"] != 3 {
+		t.Errorf("expected all results, got %v", results)
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	slow := func(ctx context.Context, in workflow.Inputs) (any, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return nil, nil
+	}
+
+	wf := workflow.New().
+		Node("a", slow).
+		Node("b", slow).
+		Node("c", slow, workflow.DependsOn("a", "b"))
+
+	if _, err := wf.Run(ctx).Promise().Await(ctx); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+
+	if peak.Load() != 2 {
+		t.Errorf("expected a and b to run in parallel, got a peak of %d", peak.Load())
+	}
+}
+
+func TestRunRejected(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("cause")
+	ran := false
+	wf := workflow.New().
+		Node("a", func(ctx context.Context, in workflow.Inputs) (any, error) {
+			return nil, cause
+		}).
+		Node("b", func(ctx context.Context, in workflow.Inputs) (any, error) {
+			ran = true
+			return nil, nil
+		}, workflow.DependsOn("a")).
+		Node("c", value(3))
+
+	run := wf.Run(ctx)
+	if _, err := run.Promise().Await(ctx); !errors.Is(err, cause) {
+		t.Errorf("expected cause, got %v", err)
+	}
+
+	if _, err := run.Node("b").Await(ctx); !errors.Is(err, cause) {
+		t.Errorf("expected the dependent to be rejected with cause, got %v", err)
+	}
+
+	if ran {
+		t.Error("expected the dependent not to run")
+	}
+
+	if v, err := run.Node("c").Await(ctx); err != nil || v != 3 {
+		t.Errorf("expected the independent node to be fulfilled, got %v, %v", v, err)
+	}
+}
+
+func TestRunInvalid(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name string
+		wf   *workflow.Workflow
+		err  error
+	}{
+		{"cycle", workflow.New().Node("a", value(1), workflow.DependsOn("b")).Node("b", value(2), workflow.DependsOn("a")), workflow.ErrCycle},
+		{"unknown", workflow.New().Node("a", value(1), workflow.DependsOn("b")), workflow.ErrUnknownNode},
+		{"duplicate", workflow.New().Node("a", value(1)).Node("a", value(2)), workflow.ErrDuplicateNode},
+	} {
+		run := tt.wf.Run(ctx)
+		if _, err := run.Promise().Await(ctx); !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+
+		if _, err := run.Node("a").Await(ctx); !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected node a to be rejected with %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestResultNoNode(t *testing.T) {
+	ctx := context.Background()
+	run := workflow.New().Run(ctx)
+	if _, err := workflow.Result[int](run, "missing").Await(ctx); !errors.Is(err, workflow.ErrNoNode) {
+		t.Errorf("expected ErrNoNode, got %v", err)
+	}
+}